@@ -1,9 +1,13 @@
 package main
 
 import (
+	"context"
+	"logistic_company/api/service/notify"
 	"logistic_company/api/service/router"
+	"logistic_company/api/service/scheduler"
 	"logistic_company/config"
 	"logistic_company/repository"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 )
@@ -28,6 +32,20 @@ func main() {
 		log.Errorf("Error while creating repository, %s", err)
 	}
 
+	scheduler.Start(context.Background(), scheduler.Job{
+		Name:     "storage",
+		Interval: time.Duration(cfg.StorageJobMinutes) * time.Minute,
+		Run:      repos.PackageRepository.ProcessStorage,
+	}, scheduler.Job{
+		Name:     "lockers",
+		Interval: time.Duration(cfg.LockerJobMinutes) * time.Minute,
+		Run:      repos.LockerRepository.ProcessLockers,
+	}, scheduler.Job{
+		Name:     "notifications",
+		Interval: time.Duration(cfg.NotificationJobMinutes) * time.Minute,
+		Run:      notify.NewDispatcher(repos.NotificationRepository, notify.NewProviders(cfg)).Run,
+	})
+
 	router, err := router.NewRouter(repos, cfg)
 	if err != nil {
 		log.Errorf("Error while creating router, %s", err)
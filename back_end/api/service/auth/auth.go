@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"logistic_company/config"
@@ -72,6 +73,9 @@ func JWTMiddleware(repos *repository.Repository, secretKey []byte) gin.HandlerFu
 		} else if claims.Role == config.RoleEmployee || claims.Role == config.RoleAdmin || claims.Role == config.RoleCourrier {
 			var employee model.Employee
 			err := repos.EmployeeRepository.GetEmployeeById(c.Request.Context(), &employee, claims.ID)
+			if err == nil && employee.ArchivedAt != nil {
+				err = errors.New("employee is archived")
+			}
 			if err != nil {
 				log.Printf("Error getting employee: %v", err)
 				c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
@@ -88,6 +92,16 @@ func JWTMiddleware(repos *repository.Repository, secretKey []byte) gin.HandlerFu
 			return
 		}
 
+		// Attach the authenticated user to the request context so the
+		// repositories can attribute audit log entries to them
+		ctx := repository.WithAuditActor(c.Request.Context(), repository.AuditActor{
+			ID:        c.GetString(config.Id),
+			Role:      c.GetString(config.Role),
+			IP:        c.ClientIP(),
+			RequestID: c.GetString(config.RequestID),
+		})
+		c.Request = c.Request.WithContext(ctx)
+
 		c.Next()
 	}
 }
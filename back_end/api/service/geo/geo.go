@@ -0,0 +1,88 @@
+package geo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"os"
+	"strings"
+)
+
+var ErrAddressNotFound = errors.New("address not found")
+
+const earthRadiusKm = 6371.0
+
+type Point struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// Geocoder resolves a free text address to coordinates.
+type Geocoder interface {
+	Geocode(ctx context.Context, address string) (Point, error)
+}
+
+// StaticGeocoder resolves addresses from a fixed table and works offline.
+// Addresses that are not in the table resolve to the longest known entry
+// they contain, so a table of city or district names gives coarse results
+// for any street in them.
+type StaticGeocoder struct {
+	points map[string]Point
+}
+
+func NewStaticGeocoder(points map[string]Point) *StaticGeocoder {
+	normalized := make(map[string]Point, len(points))
+	for address, point := range points {
+		normalized[normalize(address)] = point
+	}
+	return &StaticGeocoder{points: normalized}
+}
+
+// LoadStaticGeocoder reads the address table from a JSON file mapping
+// addresses to {"latitude": ..., "longitude": ...} objects.
+func LoadStaticGeocoder(path string) (*StaticGeocoder, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	points := map[string]Point{}
+	if err := json.Unmarshal(data, &points); err != nil {
+		return nil, err
+	}
+	return NewStaticGeocoder(points), nil
+}
+
+func (g *StaticGeocoder) Geocode(ctx context.Context, address string) (Point, error) {
+	address = normalize(address)
+	if point, ok := g.points[address]; ok {
+		return point, nil
+	}
+
+	match := ""
+	for known := range g.points {
+		if len(known) > len(match) && strings.Contains(address, known) {
+			match = known
+		}
+	}
+	if match == "" {
+		return Point{}, ErrAddressNotFound
+	}
+	return g.points[match], nil
+}
+
+func normalize(address string) string {
+	return strings.Join(strings.Fields(strings.ToLower(address)), " ")
+}
+
+// Distance returns the great-circle distance between two points in
+// kilometers.
+func Distance(a, b Point) float64 {
+	lat1 := a.Latitude * math.Pi / 180
+	lat2 := b.Latitude * math.Pi / 180
+	dLat := lat2 - lat1
+	dLon := (b.Longitude - a.Longitude) * math.Pi / 180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
@@ -0,0 +1,58 @@
+package geo
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestDistance(t *testing.T) {
+	sofia := Point{Latitude: 42.6977, Longitude: 23.3219}
+	plovdiv := Point{Latitude: 42.1354, Longitude: 24.7453}
+
+	tests := []struct {
+		name string
+		a, b Point
+		want float64
+	}{
+		{"same point", sofia, sofia, 0},
+		{"sofia to plovdiv", sofia, plovdiv, 132.5},
+		{"plovdiv to sofia", plovdiv, sofia, 132.5},
+		{"quarter of the equator", Point{}, Point{Longitude: 90}, math.Pi * earthRadiusKm / 2},
+		{"antipodes", Point{Latitude: 90}, Point{Latitude: -90}, math.Pi * earthRadiusKm},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := Distance(test.a, test.b); math.Abs(got-test.want) > 0.1 {
+				t.Errorf("Distance() = %.2f, want %.2f", got, test.want)
+			}
+		})
+	}
+}
+
+func TestStaticGeocoder(t *testing.T) {
+	geocoder := NewStaticGeocoder(map[string]Point{
+		"Sofia":               {Latitude: 42.6977, Longitude: 23.3219},
+		"Sofia, Vitosha Blvd": {Latitude: 42.69, Longitude: 23.32},
+	})
+
+	tests := []struct {
+		address string
+		want    Point
+		wantErr error
+	}{
+		{"sofia", Point{Latitude: 42.6977, Longitude: 23.3219}, nil},
+		{"12 Rakovski St, SOFIA", Point{Latitude: 42.6977, Longitude: 23.3219}, nil},
+		{"1  Sofia,  Vitosha   Blvd", Point{Latitude: 42.69, Longitude: 23.32}, nil},
+		{"Varna", Point{}, ErrAddressNotFound},
+	}
+	for _, test := range tests {
+		t.Run(test.address, func(t *testing.T) {
+			got, err := geocoder.Geocode(context.Background(), test.address)
+			if !errors.Is(err, test.wantErr) || got != test.want {
+				t.Errorf("Geocode() = %v, %v, want %v, %v", got, err, test.want, test.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package label
+
+import (
+	"fmt"
+	"io"
+	"logistic_company/config"
+	"logistic_company/model"
+)
+
+const margin = 14
+
+// Render writes a PDF with a shipping label for every package. The A6 layout
+// prints one label per page, the A4 layout four labels per sheet.
+func Render(w io.Writer, packages []model.Package, layout string) error {
+	if layout == config.LabelLayoutA4 {
+		document := newPDFDocument(a4Width, a4Height)
+		var page *pdfPage
+		for i := range packages {
+			if i%4 == 0 {
+				page = document.addPage()
+			}
+			x := float64(i%2) * a4Width / 2
+			y := a4Height/2 - float64(i%4/2)*a4Height/2
+			if err := drawLabel(page, x, y, a4Width/2, a4Height/2, &packages[i]); err != nil {
+				return err
+			}
+		}
+		return document.write(w)
+	}
+
+	document := newPDFDocument(a6Width, a6Height)
+	for i := range packages {
+		if err := drawLabel(document.addPage(), 0, 0, a6Width, a6Height, &packages[i]); err != nil {
+			return err
+		}
+	}
+	return document.write(w)
+}
+
+// TrackingID is what the barcodes of a package encode, packages registered
+// before tracking numbers were introduced use their ID.
+func TrackingID(packageModel *model.Package) string {
+	if packageModel.TrackingNumber != "" {
+		return packageModel.TrackingNumber
+	}
+	return packageModel.ID
+}
+
+// drawLabel draws the label of a package in the cell with the bottom left
+// corner x, y.
+func drawLabel(page *pdfPage, x, y, width, height float64, packageModel *model.Package) error {
+	trackingID := TrackingID(packageModel)
+	barcode, err := code128(trackingID)
+	if err != nil {
+		return err
+	}
+	qrModules, err := qr(trackingID)
+	if err != nil {
+		return err
+	}
+
+	left := x + margin
+	top := y + height - margin
+	inner := width - 2*margin
+
+	page.gray(0)
+	page.strokeRect(x+margin/2, y+margin/2, width-margin, height-margin)
+
+	// Company band
+	page.fillRect(left, top-34, inner, 34)
+	page.gray(1)
+	company := "Logistic company"
+	if packageModel.Company != nil {
+		company = packageModel.Company.Name
+	}
+	page.text(left+8, top-23, 15, true, fit(company, 15, inner-16))
+	page.gray(0)
+
+	// The QR code sits next to the addresses, they get the remaining width
+	qrSize := 84.0
+	drawQR(page, left+inner-qrSize, top-44-qrSize, qrSize, qrModules)
+	textWidth := inner - qrSize - 8
+
+	page.text(left, top-52, 7, true, "FROM")
+	if sender := packageModel.Sender; sender != nil {
+		page.text(left, top-65, 10, false, fit(sender.Name, 10, textWidth))
+		page.text(left, top-77, 9, false, fit(sender.Phone, 9, textWidth))
+	}
+
+	page.text(left, top-98, 7, true, "TO")
+	receiverName, receiverPhone := packageModel.ReceiverName, packageModel.ReceiverPhone
+	if receiver := packageModel.Receiver; receiver != nil {
+		receiverName, receiverPhone = receiver.Name, receiver.Phone
+	}
+	page.text(left, top-114, 14, true, fit(receiverName, 14, textWidth))
+	page.text(left, top-128, 10, false, fit(receiverPhone, 10, textWidth))
+	for i, line := range wrap(destination(packageModel), 10, inner, 3) {
+		page.text(left, top-146-float64(i)*13, 10, false, line)
+	}
+
+	page.fillRect(left, top-188, inner, 0.8)
+	details := fmt.Sprintf("Weight %.2f kg", packageModel.Weight)
+	if packageModel.ChargeableWeight > packageModel.Weight {
+		details += fmt.Sprintf("   Chargeable %.2f kg", packageModel.ChargeableWeight)
+	}
+	page.text(left, top-204, 10, true, details)
+	line := top - 218
+	if packageModel.PackageType != nil {
+		page.text(left, line, 9, false, fit(packageModel.PackageType.Name, 9, inner))
+		line -= 13
+	}
+	if packageModel.ParcelNumber > 0 {
+		page.text(left, line, 9, false, fmt.Sprintf("Parcel %d", packageModel.ParcelNumber))
+		line -= 13
+	}
+	if packageModel.CODAmount > 0 {
+		currency := packageModel.CODCurrency
+		if currency == "" {
+			currency = config.DefaultCurrency
+		}
+		page.text(left, line, 10, true, fmt.Sprintf("Cash on delivery %.2f %s", packageModel.CODAmount, currency))
+	}
+
+	// Code 128 with a quiet zone of ten modules on both sides
+	moduleWidth := min(1.2, inner/float64(len(barcode)+20))
+	barcodeX := left + (inner-moduleWidth*float64(len(barcode)))/2
+	for i := 0; i < len(barcode); {
+		if !barcode[i] {
+			i++
+			continue
+		}
+		start := i
+		for i < len(barcode) && barcode[i] {
+			i++
+		}
+		page.fillRect(barcodeX+float64(start)*moduleWidth, y+margin+20, float64(i-start)*moduleWidth, 56)
+	}
+	page.text(left+(inner-float64(len(trackingID))*5.4)/2, y+margin+6, 9, true, trackingID)
+
+	return nil
+}
+
+// drawQR draws the modules of a QR code in a square of size points, leaving
+// a quiet zone of four modules.
+func drawQR(page *pdfPage, x, y, size float64, modules [][]bool) {
+	module := size / float64(len(modules)+8)
+	for row, line := range modules {
+		for column, dark := range line {
+			if dark {
+				page.fillRect(x+float64(column+4)*module, y+size-float64(row+5)*module, module, module)
+			}
+		}
+	}
+}
+
+func destination(packageModel *model.Package) string {
+	if packageModel.IsDeliveredToOffice && packageModel.OfficeDeliveredAt != nil {
+		return "Office: " + packageModel.OfficeDeliveredAt.Location
+	}
+	if packageModel.DeliveryLocation != nil {
+		return *packageModel.DeliveryLocation
+	}
+	return ""
+}
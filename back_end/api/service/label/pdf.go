@@ -0,0 +1,156 @@
+package label
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Page sizes in points
+const (
+	a6Width  = 297.64
+	a6Height = 419.53
+	a4Width  = 595.28
+	a4Height = 841.89
+)
+
+// pdfDocument is a minimal PDF writer with the two standard Helvetica fonts,
+// text and filled or stroked rectangles, enough for labels. Coordinates are
+// in points from the bottom left corner of the page.
+type pdfDocument struct {
+	width  float64
+	height float64
+	pages  []*bytes.Buffer
+}
+
+func newPDFDocument(width, height float64) *pdfDocument {
+	return &pdfDocument{width: width, height: height}
+}
+
+func (d *pdfDocument) addPage() *pdfPage {
+	content := &bytes.Buffer{}
+	d.pages = append(d.pages, content)
+	return &pdfPage{content: content}
+}
+
+// write serializes the document. Objects 1 and 2 are the catalog and the page
+// tree, 3 and 4 the fonts, followed by a page and a content stream object for
+// every page.
+func (d *pdfDocument) write(w io.Writer) error {
+	out := &bytes.Buffer{}
+	offsets := []int{}
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n")
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	for i, content := range d.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] "+
+			"/Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>", d.width, d.height, 6+2*i))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	_, err := w.Write(out.Bytes())
+	return err
+}
+
+type pdfPage struct {
+	content *bytes.Buffer
+}
+
+// gray sets the fill and stroke color, 0 is black and 1 white.
+func (p *pdfPage) gray(level float64) {
+	fmt.Fprintf(p.content, "%.2f g %.2f G\n", level, level)
+}
+
+func (p *pdfPage) fillRect(x, y, width, height float64) {
+	fmt.Fprintf(p.content, "%.2f %.2f %.2f %.2f re f\n", x, y, width, height)
+}
+
+func (p *pdfPage) strokeRect(x, y, width, height float64) {
+	fmt.Fprintf(p.content, "0.8 w %.2f %.2f %.2f %.2f re S\n", x, y, width, height)
+}
+
+func (p *pdfPage) text(x, y, size float64, bold bool, text string) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(p.content, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, pdfString(text))
+}
+
+// pdfString escapes text for a PDF string literal. Characters outside of
+// Latin-1 cannot be shown with the standard fonts and are replaced.
+func pdfString(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 32:
+			b.WriteByte(' ')
+		case r < 128:
+			b.WriteRune(r)
+		case r < 256:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+// fit shortens text to roughly fit width, Helvetica averages about half the
+// font size per character.
+func fit(text string, size, width float64) string {
+	runes := []rune(text)
+	max := int(width / (size * 0.52))
+	if len(runes) <= max || max < 4 {
+		return text
+	}
+	return string(runes[:max-3]) + "..."
+}
+
+// wrap splits text into at most lines lines fitting width, the last line is
+// shortened if the text does not fit.
+func wrap(text string, size, width float64, lines int) []string {
+	max := int(width / (size * 0.52))
+	result := []string{}
+	line := ""
+	for _, word := range strings.Fields(text) {
+		if line != "" && len([]rune(line))+1+len([]rune(word)) > max {
+			result = append(result, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+	if line != "" {
+		result = append(result, line)
+	}
+	if len(result) > lines {
+		result[lines-1] = fit(strings.Join(result[lines-1:], " "), size, width)
+		result = result[:lines]
+	}
+	return result
+}
@@ -0,0 +1,362 @@
+package label
+
+import "fmt"
+
+// qrVersion describes a QR code version at error correction level M. Only
+// the versions with a single error correction block are supported, which
+// holds up to 42 bytes, enough for tracking numbers and package IDs.
+type qrVersion struct {
+	number    int
+	data      int
+	ec        int
+	alignment int
+	remainder int
+}
+
+var qrVersions = []qrVersion{
+	{number: 1, data: 16, ec: 10},
+	{number: 2, data: 28, ec: 16, alignment: 18, remainder: 7},
+	{number: 3, data: 44, ec: 26, alignment: 22, remainder: 7},
+}
+
+// qrFormatLevelM are the format information bits of error correction level M
+const qrFormatLevelM = 0
+
+type qrCode struct {
+	size     int
+	modules  [][]bool
+	reserved [][]bool
+}
+
+// qr encodes data in byte mode at error correction level M and returns the
+// modules of the smallest fitting symbol, true for dark, without the quiet
+// zone.
+func qr(data string) ([][]bool, error) {
+	var version *qrVersion
+	for i := range qrVersions {
+		if len(data) <= qrVersions[i].data-2 {
+			version = &qrVersions[i]
+			break
+		}
+	}
+	if version == nil {
+		return nil, fmt.Errorf("%d bytes do not fit in a QR code", len(data))
+	}
+
+	codewords := qrCodewords(data, version.data)
+	codewords = append(codewords, reedSolomon(codewords, version.ec)...)
+
+	size := 17 + 4*version.number
+	code := &qrCode{size: size, modules: make([][]bool, size), reserved: make([][]bool, size)}
+	for y := range code.modules {
+		code.modules[y] = make([]bool, size)
+		code.reserved[y] = make([]bool, size)
+	}
+	code.drawFunctionPatterns(version)
+	code.drawCodewords(codewords)
+
+	best, bestPenalty := -1, 0
+	for mask := 0; mask < 8; mask++ {
+		code.applyMask(mask)
+		code.drawFormat(mask)
+		if penalty := code.penalty(); best < 0 || penalty < bestPenalty {
+			best, bestPenalty = mask, penalty
+		}
+		code.applyMask(mask)
+	}
+	code.applyMask(best)
+	code.drawFormat(best)
+
+	return code.modules, nil
+}
+
+// qrCodewords builds the data codewords: the byte mode indicator, the
+// length, the data, a terminator and the pad bytes.
+func qrCodewords(data string, capacity int) []byte {
+	bits := []bool{}
+	appendBits := func(value, length int) {
+		for i := length - 1; i >= 0; i-- {
+			bits = append(bits, value>>i&1 == 1)
+		}
+	}
+	appendBits(0b0100, 4)
+	appendBits(len(data), 8)
+	for i := 0; i < len(data); i++ {
+		appendBits(int(data[i]), 8)
+	}
+	for i := 0; i < 4 && len(bits) < capacity*8; i++ {
+		bits = append(bits, false)
+	}
+	for len(bits)%8 != 0 {
+		bits = append(bits, false)
+	}
+
+	codewords := make([]byte, 0, capacity)
+	for i := 0; i < len(bits); i += 8 {
+		var codeword byte
+		for j := 0; j < 8; j++ {
+			codeword <<= 1
+			if bits[i+j] {
+				codeword |= 1
+			}
+		}
+		codewords = append(codewords, codeword)
+	}
+	for pad := byte(0xEC); len(codewords) < capacity; pad ^= 0xEC ^ 0x11 {
+		codewords = append(codewords, pad)
+	}
+	return codewords
+}
+
+var gfExp, gfLog = func() ([512]byte, [256]byte) {
+	var exp [512]byte
+	var log [256]byte
+	x := 1
+	for i := 0; i < 255; i++ {
+		exp[i] = byte(x)
+		log[x] = byte(i)
+		x <<= 1
+		if x&0x100 != 0 {
+			x ^= 0x11D
+		}
+	}
+	for i := 255; i < 512; i++ {
+		exp[i] = exp[i-255]
+	}
+	return exp, log
+}()
+
+func gfMultiply(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return gfExp[int(gfLog[a])+int(gfLog[b])]
+}
+
+// reedSolomon returns the n error correction codewords of data.
+func reedSolomon(data []byte, n int) []byte {
+	generator := []byte{1}
+	for i := 0; i < n; i++ {
+		next := make([]byte, len(generator)+1)
+		for j, coefficient := range generator {
+			next[j] ^= coefficient
+			next[j+1] ^= gfMultiply(coefficient, gfExp[i])
+		}
+		generator = next
+	}
+
+	remainder := make([]byte, len(data)+n)
+	copy(remainder, data)
+	for i := range data {
+		factor := remainder[i]
+		for j := 1; j < len(generator); j++ {
+			remainder[i+j] ^= gfMultiply(generator[j], factor)
+		}
+	}
+	return remainder[len(data):]
+}
+
+func (q *qrCode) setFunction(x, y int, dark bool) {
+	q.modules[y][x] = dark
+	q.reserved[y][x] = true
+}
+
+func (q *qrCode) drawFunctionPatterns(version *qrVersion) {
+	for i := 0; i < q.size; i++ {
+		q.setFunction(6, i, i%2 == 0)
+		q.setFunction(i, 6, i%2 == 0)
+	}
+
+	for _, corner := range [][2]int{{3, 3}, {q.size - 4, 3}, {3, q.size - 4}} {
+		for dy := -4; dy <= 4; dy++ {
+			for dx := -4; dx <= 4; dx++ {
+				x, y := corner[0]+dx, corner[1]+dy
+				if x < 0 || x >= q.size || y < 0 || y >= q.size {
+					continue
+				}
+				distance := max(abs(dx), abs(dy))
+				q.setFunction(x, y, distance != 2 && distance != 4)
+			}
+		}
+	}
+
+	if version.alignment != 0 {
+		center := version.alignment
+		for dy := -2; dy <= 2; dy++ {
+			for dx := -2; dx <= 2; dx++ {
+				q.setFunction(center+dx, center+dy, max(abs(dx), abs(dy)) != 1)
+			}
+		}
+	}
+
+	// Reserve the format areas until the mask is chosen
+	q.drawFormat(0)
+}
+
+// drawFormat draws both copies of the format information and the dark
+// module.
+func (q *qrCode) drawFormat(mask int) {
+	data := qrFormatLevelM<<3 | mask
+	remainder := data
+	for i := 0; i < 10; i++ {
+		remainder = remainder<<1 ^ (remainder>>9)*0x537
+	}
+	bits := (data<<10 | remainder) ^ 0x5412
+	bit := func(i int) bool {
+		return bits>>i&1 == 1
+	}
+
+	for i := 0; i <= 5; i++ {
+		q.setFunction(8, i, bit(i))
+	}
+	q.setFunction(8, 7, bit(6))
+	q.setFunction(8, 8, bit(7))
+	q.setFunction(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		q.setFunction(14-i, 8, bit(i))
+	}
+
+	for i := 0; i < 8; i++ {
+		q.setFunction(q.size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		q.setFunction(8, q.size-15+i, bit(i))
+	}
+	q.setFunction(8, q.size-8, true)
+}
+
+// drawCodewords places the codewords in the zigzag order of the standard,
+// two columns at a time from the bottom right corner, skipping the vertical
+// timing pattern.
+func (q *qrCode) drawCodewords(codewords []byte) {
+	i := 0
+	for right := q.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vertical := 0; vertical < q.size; vertical++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				upward := (right+1)&2 == 0
+				y := vertical
+				if upward {
+					y = q.size - 1 - vertical
+				}
+				if q.reserved[y][x] {
+					continue
+				}
+				if i < len(codewords)*8 {
+					q.modules[y][x] = codewords[i/8]>>(7-i%8)&1 == 1
+				}
+				i++
+			}
+		}
+	}
+}
+
+func (q *qrCode) applyMask(mask int) {
+	for y := 0; y < q.size; y++ {
+		for x := 0; x < q.size; x++ {
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if invert && !q.reserved[y][x] {
+				q.modules[y][x] = !q.modules[y][x]
+			}
+		}
+	}
+}
+
+// penalty scores how hard the symbol is to read, the mask with the lowest
+// score is used.
+func (q *qrCode) penalty() int {
+	at := func(x, y int, vertical bool) bool {
+		if vertical {
+			return q.modules[x][y]
+		}
+		return q.modules[y][x]
+	}
+
+	penalty := 0
+	finder := []bool{true, false, true, true, true, false, true}
+	for _, vertical := range []bool{false, true} {
+		for y := 0; y < q.size; y++ {
+			run := 0
+			for x := 0; x < q.size; x++ {
+				if x > 0 && at(x, y, vertical) == at(x-1, y, vertical) {
+					run++
+				} else {
+					run = 1
+				}
+				if run == 5 {
+					penalty += 3
+				} else if run > 5 {
+					penalty++
+				}
+			}
+
+			for x := 0; x+7 <= q.size; x++ {
+				matches := true
+				for i, dark := range finder {
+					matches = matches && at(x+i, y, vertical) == dark
+				}
+				if matches && (q.light(x-4, x, y, vertical, at) || q.light(x+7, x+11, y, vertical, at)) {
+					penalty += 40
+				}
+			}
+		}
+	}
+
+	dark := 0
+	for y := 0; y < q.size; y++ {
+		for x := 0; x < q.size; x++ {
+			if q.modules[y][x] {
+				dark++
+			}
+			if x > 0 && y > 0 {
+				color := q.modules[y][x]
+				if q.modules[y-1][x] == color && q.modules[y][x-1] == color && q.modules[y-1][x-1] == color {
+					penalty += 3
+				}
+			}
+		}
+	}
+	total := q.size * q.size
+	penalty += (abs(dark*20-total*10)+total-1)/total*10 - 10
+
+	return penalty
+}
+
+// light reports whether the modules from start up to end in a row or column
+// are light, modules outside of the symbol count as light.
+func (q *qrCode) light(start, end, y int, vertical bool, at func(x, y int, vertical bool) bool) bool {
+	for x := start; x < end; x++ {
+		if x >= 0 && x < q.size && at(x, y, vertical) {
+			return false
+		}
+	}
+	return true
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
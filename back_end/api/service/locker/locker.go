@@ -0,0 +1,52 @@
+package locker
+
+import (
+	"context"
+	"errors"
+	"logistic_company/model"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+var ErrUnknownCompartment = errors.New("compartment does not belong to the locker")
+
+// LockerProvider talks to the parcel lockers of a locker operator. Which
+// compartment holds which package is kept by the repository, the provider
+// only operates the lockers.
+type LockerProvider interface {
+	// Open unlocks the door of a compartment
+	Open(ctx context.Context, locker *model.Locker, compartment *model.LockerCompartment) error
+}
+
+// SimulatedProvider stands in for real lockers. It logs the doors it opens
+// and remembers when every compartment was opened last.
+type SimulatedProvider struct {
+	mu     sync.Mutex
+	opened map[string]time.Time
+}
+
+func NewSimulatedProvider() *SimulatedProvider {
+	return &SimulatedProvider{opened: map[string]time.Time{}}
+}
+
+func (p *SimulatedProvider) Open(ctx context.Context, locker *model.Locker, compartment *model.LockerCompartment) error {
+	if compartment.LockerID != locker.ID {
+		return ErrUnknownCompartment
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.opened[compartment.ID] = time.Now()
+	log.Infof("Locker %s opened compartment %d", locker.ID, compartment.Number)
+	return nil
+}
+
+// LastOpened returns when a compartment was opened last.
+func (p *SimulatedProvider) LastOpened(compartmentID string) (time.Time, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	openedAt, ok := p.opened[compartmentID]
+	return openedAt, ok
+}
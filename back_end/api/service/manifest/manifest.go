@@ -0,0 +1,66 @@
+package manifest
+
+import (
+	"html/template"
+	"io"
+	"logistic_company/config"
+	"logistic_company/model"
+)
+
+var manifestTemplate = template.Must(template.New("manifest").Funcs(template.FuncMap{
+	"date": func(run model.DeliveryRun) string {
+		return run.Date.Format(config.DateFormat)
+	},
+	"destination": destination,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Delivery run {{date .}}</title>
+<style>
+body { font-family: sans-serif; font-size: 12px; }
+table { border-collapse: collapse; width: 100%; }
+th, td { border: 1px solid #000; padding: 4px; text-align: left; }
+td.sign { width: 20%; }
+</style>
+</head>
+<body>
+<h1>Delivery run {{date .}}</h1>
+<p>
+Courrier: {{with .Courrier}}{{.Name}} ({{.Phone}}){{end}}<br>
+Office: {{with .Office}}{{.Location}}{{end}}<br>
+Status: {{.Status}}
+</p>
+<table>
+<tr><th>#</th><th>Package</th><th>Receiver</th><th>Destination</th><th>Weight</th><th>Outcome</th><th>Signature</th></tr>
+{{range .Stops}}<tr>
+<td>{{.Sequence}}</td>
+<td>{{.PackageID}}</td>
+<td>{{with .Package}}{{if .Receiver}}{{.Receiver.Name}}<br>{{.Receiver.Phone}}{{else}}{{.ReceiverName}}<br>{{.ReceiverPhone}}{{end}}{{end}}</td>
+<td>{{destination .Package}}</td>
+<td>{{with .Package}}{{printf "%.2f" .Weight}} kg{{end}}</td>
+<td>{{.Outcome}}</td>
+<td class="sign"></td>
+</tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+// Render writes the printable HTML manifest of a delivery run.
+func Render(w io.Writer, run *model.DeliveryRun) error {
+	return manifestTemplate.Execute(w, run)
+}
+
+func destination(packageModel *model.Package) string {
+	if packageModel == nil {
+		return ""
+	}
+	if packageModel.IsDeliveredToOffice && packageModel.OfficeDeliveredAt != nil {
+		return packageModel.OfficeDeliveredAt.Location
+	}
+	if packageModel.DeliveryLocation != nil {
+		return *packageModel.DeliveryLocation
+	}
+	return ""
+}
@@ -0,0 +1,87 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"logistic_company/config"
+	"logistic_company/model"
+	"logistic_company/repository"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// NewProviders sets up a provider per channel from the configuration.
+// Channels without a configured provider write to the notification file.
+func NewProviders(cfg *config.Config) map[string]Provider {
+	file := NewFileProvider(cfg.NotificationFile)
+	providers := map[string]Provider{
+		config.ChannelEmail: file,
+		config.ChannelSMS:   file,
+	}
+	if cfg.SMTPHost != "" {
+		providers[config.ChannelEmail] = NewSMTPProvider(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUser, cfg.SMTPPassword, cfg.SMTPFrom)
+	}
+	if cfg.SMSGatewayURL != "" {
+		providers[config.ChannelSMS] = NewSMSProvider(cfg.SMSGatewayURL, cfg.SMSGatewayToken, cfg.SMSSender)
+	}
+	return providers
+}
+
+// Dispatcher sends the queued notifications through the provider of their
+// channel.
+type Dispatcher struct {
+	repository *repository.NotificationRepository
+	providers  map[string]Provider
+}
+
+func NewDispatcher(repository *repository.NotificationRepository, providers map[string]Provider) *Dispatcher {
+	return &Dispatcher{
+		repository: repository,
+		providers:  providers,
+	}
+}
+
+// Run sends a batch of pending notifications. Failed sends are recorded on
+// the notification and retried on the next run, only failing to record
+// them is returned.
+func (d *Dispatcher) Run(ctx context.Context) error {
+	notifications := []model.Notification{}
+	if err := d.repository.GetPendingNotifications(ctx, &notifications, config.NotificationBatchSize); err != nil {
+		return err
+	}
+
+	var errs []error
+	for i := range notifications {
+		notification := &notifications[i]
+		sendErr := d.send(ctx, notification)
+		if sendErr != nil {
+			log.Warnf("Error while sending notification %s, %s", notification.ID, sendErr)
+		}
+		if err := d.repository.RecordAttempt(ctx, notification, sendErr); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (d *Dispatcher) send(ctx context.Context, notification *model.Notification) error {
+	message, err := render(notification, false)
+	if err != nil {
+		return err
+	}
+	// Only the receiver gets to see the pickup code
+	stored, err := render(notification, true)
+	if err != nil {
+		return err
+	}
+	notification.Recipient = message.To
+	notification.Subject = message.Subject
+	notification.Body = stored.Body
+
+	provider, ok := d.providers[notification.Channel]
+	if !ok {
+		return fmt.Errorf("no provider for channel %s", notification.Channel)
+	}
+	return provider.Send(ctx, message)
+}
@@ -0,0 +1,57 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// SMSProvider sends text messages through an HTTP SMS gateway. Messages are
+// posted as JSON with the token as bearer authorization.
+type SMSProvider struct {
+	url    string
+	token  string
+	sender string
+	client *http.Client
+}
+
+func NewSMSProvider(url, token, sender string) *SMSProvider {
+	return &SMSProvider{
+		url:    url,
+		token:  token,
+		sender: sender,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (p *SMSProvider) Send(ctx context.Context, message Message) error {
+	payload, err := json.Marshal(map[string]string{
+		"from": p.sender,
+		"to":   message.To,
+		"text": message.Body,
+	})
+	if err != nil {
+		return err
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	if p.token != "" {
+		request.Header.Set("Authorization", "Bearer "+p.token)
+	}
+
+	response, err := p.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("sms gateway responded %s", response.Status)
+	}
+	return nil
+}
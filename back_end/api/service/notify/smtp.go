@@ -0,0 +1,50 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"time"
+)
+
+// SMTPProvider sends emails through an SMTP server, authenticating when it
+// has a user.
+type SMTPProvider struct {
+	addr     string
+	host     string
+	user     string
+	password string
+	from     string
+}
+
+func NewSMTPProvider(host, port, user, password, from string) *SMTPProvider {
+	return &SMTPProvider{
+		addr:     net.JoinHostPort(host, port),
+		host:     host,
+		user:     user,
+		password: password,
+		from:     from,
+	}
+}
+
+func (p *SMTPProvider) Send(ctx context.Context, message Message) error {
+	var auth smtp.Auth
+	if p.user != "" {
+		auth = smtp.PlainAuth("", p.user, p.password, p.host)
+	}
+
+	var body bytes.Buffer
+	fmt.Fprintf(&body, "From: %s\r\n", p.from)
+	fmt.Fprintf(&body, "To: %s\r\n", message.To)
+	fmt.Fprintf(&body, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", message.Subject))
+	fmt.Fprintf(&body, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	body.WriteString("MIME-Version: 1.0\r\n")
+	body.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	body.WriteString("\r\n")
+	body.WriteString(message.Body)
+
+	return smtp.SendMail(p.addr, auth, p.from, []string{message.To}, body.Bytes())
+}
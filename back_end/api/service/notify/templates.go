@@ -0,0 +1,163 @@
+package notify
+
+import (
+	"errors"
+	"fmt"
+	"logistic_company/config"
+	"logistic_company/model"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// messageTemplate is a notification as an email with a subject and as a
+// short text message.
+type messageTemplate struct {
+	subject *template.Template
+	email   *template.Template
+	sms     *template.Template
+}
+
+func newTemplate(name, subject, email, sms string) messageTemplate {
+	return messageTemplate{
+		subject: template.Must(template.New(name + ".subject").Parse(subject)),
+		email:   template.Must(template.New(name + ".email").Parse(email)),
+		sms:     template.Must(template.New(name + ".sms").Parse(sms)),
+	}
+}
+
+var templates = map[string]messageTemplate{
+	config.TemplatePackageRegistered: newTemplate(config.TemplatePackageRegistered,
+		`Package {{.TrackingNumber}} registered`,
+		`Hello {{.Name}},
+
+package {{.TrackingNumber}} was registered and is on its way{{if .PickupPoint}} to {{.PickupPoint}}{{end}}.
+`,
+		`Package {{.TrackingNumber}} was registered and is on its way.`),
+	config.TemplateOutForDelivery: newTemplate(config.TemplateOutForDelivery,
+		`Package {{.TrackingNumber}} is out for delivery`,
+		`Hello {{.Name}},
+
+package {{.TrackingNumber}} is out for delivery{{if .Address}} to {{.Address}}{{end}} and arrives today.
+`,
+		`Package {{.TrackingNumber}} is out for delivery and arrives today.`),
+	config.TemplateReadyForPickup: newTemplate(config.TemplateReadyForPickup,
+		`Package {{.TrackingNumber}} is ready for pickup`,
+		`Hello {{.Name}},
+
+package {{.TrackingNumber}} is ready for pickup at {{.PickupPoint}}.{{if .Code}}
+Your pickup code is {{.Code}}.{{end}}{{if .Deadline}}
+Please pick it up by {{.Deadline}}.{{end}}
+`,
+		`Package {{.TrackingNumber}} is ready for pickup at {{.PickupPoint}}.{{if .Code}} Code {{.Code}}.{{end}}{{if .Deadline}} Pick it up by {{.Deadline}}.{{end}}`),
+	config.TemplateDelivered: newTemplate(config.TemplateDelivered,
+		`Package {{.TrackingNumber}} delivered`,
+		`Hello {{.Name}},
+
+package {{.TrackingNumber}} was delivered.
+`,
+		`Package {{.TrackingNumber}} was delivered.`),
+	config.TemplatePriceAdjusted: newTemplate(config.TemplatePriceAdjusted,
+		`Price of package {{.TrackingNumber}} adjusted`,
+		`Hello {{.Name}},
+
+package {{.TrackingNumber}} was accepted. It weighs {{.Weight}} kg instead of the estimated {{.EstimatedWeight}} kg,
+so its price is {{.Price}} instead of the quoted {{.QuotedPrice}}.
+`,
+		`Package {{.TrackingNumber}} weighs {{.Weight}} kg, its price is {{.Price}} instead of {{.QuotedPrice}}.`),
+}
+
+// templateData is what the templates can refer to.
+type templateData struct {
+	Name            string
+	TrackingNumber  string
+	Weight          string
+	EstimatedWeight string
+	Price           string
+	QuotedPrice     string
+	Address         string
+	PickupPoint     string
+	Code            string
+	Deadline        string
+}
+
+func newTemplateData(client *model.Client, packageModel *model.Package) templateData {
+	data := templateData{
+		Name:            client.Name,
+		TrackingNumber:  packageModel.TrackingNumber,
+		Weight:          fmt.Sprintf("%.2f", packageModel.Weight),
+		EstimatedWeight: fmt.Sprintf("%.2f", packageModel.EstimatedWeight),
+		Price:           fmt.Sprintf("%.2f", packageModel.Price),
+		QuotedPrice:     fmt.Sprintf("%.2f", packageModel.QuotedPrice),
+	}
+	if packageModel.DeliveryLocation != nil {
+		data.Address = *packageModel.DeliveryLocation
+	}
+
+	// Only the receiver is told how to pick the package up
+	receiver := packageModel.ReceiverID != nil && client.ID == *packageModel.ReceiverID
+	var deadline *time.Time
+	switch {
+	case packageModel.Locker != nil && packageModel.DeliveryStatus == config.StatusInLocker:
+		data.PickupPoint = packageModel.Locker.Name + ", " + packageModel.Locker.Location
+		deadline = packageModel.LockerDeadline
+		if receiver {
+			data.Code = packageModel.LockerPickupCode
+		}
+	case packageModel.IsDeliveredToOffice && packageModel.OfficeDeliveredAt != nil:
+		data.PickupPoint = packageModel.OfficeDeliveredAt.Location
+		deadline = packageModel.StorageDeadline
+		if receiver {
+			data.Code = packageModel.PickupPIN
+		}
+	}
+	if deadline != nil {
+		data.Deadline = deadline.Format(config.DateFormat)
+	}
+	return data
+}
+
+// render turns a queued notification into the message for its channel. With
+// maskCode the pickup code is replaced by asterisks, for the copy of the
+// message that is stored and shown to staff.
+func render(notification *model.Notification, maskCode bool) (Message, error) {
+	if notification.Client == nil || notification.Package == nil {
+		return Message{}, errors.New("client or package no longer exists")
+	}
+	messageTemplate, ok := templates[notification.Template]
+	if !ok {
+		return Message{}, fmt.Errorf("unknown template %s", notification.Template)
+	}
+	data := newTemplateData(notification.Client, notification.Package)
+	if maskCode {
+		data.Code = strings.Repeat("*", len(data.Code))
+	}
+
+	message := Message{Channel: notification.Channel}
+	var body *template.Template
+	switch notification.Channel {
+	case config.ChannelEmail:
+		message.To = notification.Client.Email
+		body = messageTemplate.email
+		var subject strings.Builder
+		if err := messageTemplate.subject.Execute(&subject, data); err != nil {
+			return Message{}, err
+		}
+		message.Subject = subject.String()
+	case config.ChannelSMS:
+		message.To = notification.Client.Phone
+		body = messageTemplate.sms
+	default:
+		return Message{}, fmt.Errorf("unknown channel %s", notification.Channel)
+	}
+	if message.To == "" {
+		return Message{}, fmt.Errorf("client has no %s recipient", notification.Channel)
+	}
+
+	var text strings.Builder
+	if err := body.Execute(&text, data); err != nil {
+		return Message{}, err
+	}
+	message.Body = text.String()
+	return message, nil
+}
@@ -0,0 +1,41 @@
+package notify
+
+import (
+	"logistic_company/config"
+	"logistic_company/model"
+	"strings"
+	"testing"
+)
+
+func TestRenderMasksPickupCode(t *testing.T) {
+	receiverID := "receiver"
+	office := model.Office{Location: "Sofia, Vitosha 1"}
+	notification := model.Notification{
+		Template: config.TemplateReadyForPickup,
+		Client:   &model.Client{ID: receiverID, Name: "Receiver", Email: "receiver@example.com", Phone: "0888123456"},
+		Package: &model.Package{
+			TrackingNumber: "LC0000000001", ReceiverID: &receiverID, IsDeliveredToOffice: true,
+			OfficeDeliveredAt: &office, DeliveryStatus: config.StatusArrivedAtOffice, PickupPIN: "123456",
+		},
+	}
+
+	for _, channel := range []string{config.ChannelEmail, config.ChannelSMS} {
+		t.Run(channel, func(t *testing.T) {
+			notification.Channel = channel
+			message, err := render(&notification, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(message.Body, "123456") {
+				t.Errorf("render() body = %q, want the pickup code", message.Body)
+			}
+			stored, err := render(&notification, true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if strings.Contains(stored.Body, "123456") || !strings.Contains(stored.Body, "******") {
+				t.Errorf("render() masked body = %q, want the pickup code masked", stored.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package route
+
+import (
+	"time"
+
+	"logistic_company/api/service/geo"
+)
+
+// latenessPenaltyKm is how many kilometers of detour one minute of arriving
+// after a time window closes is worth when comparing routes.
+const latenessPenaltyKm = 1.0
+
+// maxImprovementPasses bounds the 2-opt search on long runs. Every pass
+// tries all moves, most routes stop improving after a few passes.
+const maxImprovementPasses = 25
+
+// Stop is a location the courier has to visit, optionally within a time
+// window.
+type Stop struct {
+	ID          string
+	Point       geo.Point
+	WindowStart *time.Time
+	WindowEnd   *time.Time
+}
+
+type Options struct {
+	Start       geo.Point
+	StartTime   time.Time
+	SpeedKmh    float64
+	ServiceTime time.Duration
+}
+
+type PlannedStop struct {
+	Stop
+	Sequence     int
+	DistanceKm   float64
+	CumulativeKm float64
+	ETA          time.Time
+	Late         bool
+}
+
+type Plan struct {
+	Stops           []PlannedStop
+	TotalDistanceKm float64
+	LateStops       int
+}
+
+// Optimize orders the stops starting from opts.Start. It builds a route
+// with the nearest-neighbour heuristic and improves it with 2-opt moves,
+// scoring routes by distance plus a penalty for every minute a stop is
+// reached after its time window closes. Arriving before a window opens
+// means waiting until it does.
+func Optimize(stops []Stop, opts Options) Plan {
+	if len(stops) == 0 {
+		return Plan{Stops: []PlannedStop{}}
+	}
+
+	order := nearestNeighbour(stops, opts)
+	route := newRoute(stops, order, opts)
+	for pass := 0; pass < maxImprovementPasses; pass++ {
+		improved := false
+		for i := 0; i < len(order)-1; i++ {
+			for j := i + 1; j < len(order); j++ {
+				if route.improves(i, j) {
+					route.reverse(i, j)
+					improved = true
+				}
+			}
+		}
+		if !improved {
+			break
+		}
+	}
+
+	return schedule(stops, route.order, opts)
+}
+
+// nearestNeighbour repeatedly visits the cheapest stop to go to next, where
+// the cost is the travel distance plus the lateness penalty of arriving
+// there now.
+func nearestNeighbour(stops []Stop, opts Options) []int {
+	order := make([]int, 0, len(stops))
+	visited := make([]bool, len(stops))
+	position := opts.Start
+	now := opts.StartTime
+
+	for len(order) < len(stops) {
+		next := -1
+		nextCost := 0.0
+		for i, stop := range stops {
+			if visited[i] {
+				continue
+			}
+			distance := geo.Distance(position, stop.Point)
+			_, lateness := arrive(stop, now.Add(travelTime(distance, opts)))
+			if candidate := distance + lateness.Minutes()*latenessPenaltyKm; next == -1 || candidate < nextCost {
+				next = i
+				nextCost = candidate
+			}
+		}
+
+		distance := geo.Distance(position, stops[next].Point)
+		arrival, _ := arrive(stops[next], now.Add(travelTime(distance, opts)))
+		now = arrival.Add(opts.ServiceTime)
+		position = stops[next].Point
+		visited[next] = true
+		order = append(order, next)
+	}
+
+	return order
+}
+
+func schedule(stops []Stop, order []int, opts Options) Plan {
+	plan := Plan{Stops: make([]PlannedStop, 0, len(order))}
+	position := opts.Start
+	now := opts.StartTime
+
+	for sequence, index := range order {
+		stop := stops[index]
+		distance := geo.Distance(position, stop.Point)
+		arrival, lateness := arrive(stop, now.Add(travelTime(distance, opts)))
+
+		plan.TotalDistanceKm += distance
+		plan.Stops = append(plan.Stops, PlannedStop{
+			Stop:         stop,
+			Sequence:     sequence + 1,
+			DistanceKm:   distance,
+			CumulativeKm: plan.TotalDistanceKm,
+			ETA:          arrival,
+			Late:         lateness > 0,
+		})
+		if lateness > 0 {
+			plan.LateStops++
+		}
+
+		now = arrival.Add(opts.ServiceTime)
+		position = stop.Point
+	}
+
+	return plan
+}
+
+// arrive returns when the courier can serve the stop when reaching it at
+// at, and how late that is.
+func arrive(stop Stop, at time.Time) (time.Time, time.Duration) {
+	if stop.WindowStart != nil && at.Before(*stop.WindowStart) {
+		at = *stop.WindowStart
+	}
+	if stop.WindowEnd != nil && at.After(*stop.WindowEnd) {
+		return at, at.Sub(*stop.WindowEnd)
+	}
+	return at, 0
+}
+
+func travelTime(distanceKm float64, opts Options) time.Duration {
+	if opts.SpeedKmh <= 0 {
+		return 0
+	}
+	return time.Duration(distanceKm / opts.SpeedKmh * float64(time.Hour))
+}
+
+func reverse(order []int, i, j int) {
+	for ; i < j; i, j = i+1, j-1 {
+		order[i], order[j] = order[j], order[i]
+	}
+}
@@ -0,0 +1,196 @@
+package route
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"logistic_company/api/service/geo"
+)
+
+func stopIDs(plan Plan) []string {
+	ids := []string{}
+	for _, stop := range plan.Stops {
+		ids = append(ids, stop.ID)
+	}
+	return ids
+}
+
+func TestOptimize(t *testing.T) {
+	start := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	opts := Options{Start: geo.Point{}, StartTime: start, SpeedKmh: 120, ServiceTime: 10 * time.Minute}
+	deadline := start.Add(time.Hour)
+
+	tests := []struct {
+		name  string
+		stops []Stop
+		want  []string
+	}{
+		{"no stops", nil, []string{}},
+		{
+			name: "stops along a road",
+			stops: []Stop{
+				{ID: "c", Point: geo.Point{Longitude: 0.3}},
+				{ID: "a", Point: geo.Point{Longitude: 0.1}},
+				{ID: "b", Point: geo.Point{Longitude: 0.2}},
+			},
+			want: []string{"a", "b", "c"},
+		},
+		{
+			name: "nearest stop first without time windows",
+			stops: []Stop{
+				{ID: "far", Point: geo.Point{Longitude: 1}},
+				{ID: "near", Point: geo.Point{Longitude: -0.5}},
+			},
+			want: []string{"near", "far"},
+		},
+		{
+			name: "time window moves the far stop first",
+			stops: []Stop{
+				{ID: "far", Point: geo.Point{Longitude: 1}, WindowEnd: &deadline},
+				{ID: "near", Point: geo.Point{Longitude: -0.5}},
+			},
+			want: []string{"far", "near"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			plan := Optimize(test.stops, opts)
+			got := stopIDs(plan)
+			if len(got) != len(test.want) {
+				t.Fatalf("Optimize() = %v, want %v", got, test.want)
+			}
+			for i := range got {
+				if got[i] != test.want[i] {
+					t.Fatalf("Optimize() = %v, want %v", got, test.want)
+				}
+			}
+			if plan.LateStops != 0 {
+				t.Errorf("LateStops = %d, want 0", plan.LateStops)
+			}
+		})
+	}
+}
+
+func TestSchedule(t *testing.T) {
+	start := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	opens := start.Add(2 * time.Hour)
+	closes := start.Add(30 * time.Minute)
+	stops := []Stop{
+		{ID: "waits", Point: geo.Point{Longitude: 0.1}, WindowStart: &opens},
+		{ID: "late", Point: geo.Point{Longitude: 0.2}, WindowEnd: &closes},
+	}
+	plan := schedule(stops, []int{0, 1}, Options{StartTime: start, SpeedKmh: 60, ServiceTime: 5 * time.Minute})
+
+	if !plan.Stops[0].ETA.Equal(opens) {
+		t.Errorf("first ETA = %v, want the window start %v", plan.Stops[0].ETA, opens)
+	}
+	if !plan.Stops[1].Late || plan.LateStops != 1 {
+		t.Errorf("second stop Late = %v, LateStops = %d, want late", plan.Stops[1].Late, plan.LateStops)
+	}
+	if plan.Stops[1].Sequence != 2 || plan.Stops[1].CumulativeKm != plan.TotalDistanceKm {
+		t.Errorf("second stop = %+v, want sequence 2 at the total distance", plan.Stops[1])
+	}
+}
+
+// randomStops places stops around Sofia, every third one with a time window.
+func randomStops(random *rand.Rand, count int, start time.Time) []Stop {
+	stops := make([]Stop, count)
+	for i := range stops {
+		stops[i] = Stop{ID: string(rune('a' + i%26)), Point: geo.Point{
+			Latitude:  42.6 + random.Float64()*0.2,
+			Longitude: 23.2 + random.Float64()*0.3,
+		}}
+		if i%3 == 0 {
+			opens := start.Add(time.Duration(random.Intn(240)) * time.Minute)
+			closes := opens.Add(time.Duration(30+random.Intn(90)) * time.Minute)
+			stops[i].WindowStart, stops[i].WindowEnd = &opens, &closes
+		}
+	}
+	return stops
+}
+
+// plannedCost scores an order by planning the whole route.
+func plannedCost(stops []Stop, order []int, opts Options) float64 {
+	plan := schedule(stops, order, opts)
+	total := plan.TotalDistanceKm
+	for _, stop := range plan.Stops {
+		if stop.WindowEnd != nil && stop.ETA.After(*stop.WindowEnd) {
+			total += stop.ETA.Sub(*stop.WindowEnd).Minutes() * latenessPenaltyKm
+		}
+	}
+	return total
+}
+
+func TestRouteScoresMovesLikeAFullPlan(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	start := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	opts := Options{Start: geo.Point{Latitude: 42.7, Longitude: 23.3}, StartTime: start, SpeedKmh: 30, ServiceTime: 5 * time.Minute}
+
+	for run := 0; run < 20; run++ {
+		stops := randomStops(random, 15, start)
+		order := random.Perm(len(stops))
+		r := newRoute(stops, order, opts)
+		for move := 0; move < 50; move++ {
+			i := random.Intn(len(order) - 1)
+			j := i + 1 + random.Intn(len(order)-i-1)
+
+			reversed := append([]int{}, r.order...)
+			reverse(reversed, i, j)
+			want := plannedCost(stops, reversed, opts) < plannedCost(stops, r.order, opts)-1e-9
+			if got := r.improves(i, j); got != want {
+				t.Fatalf("improves(%d, %d) = %v, want %v", i, j, got, want)
+			}
+
+			r.reverse(i, j)
+			if math.Abs(r.cost()-plannedCost(stops, r.order, opts)) > 1e-6 {
+				t.Fatalf("cost() = %f after reversing, want %f", r.cost(), plannedCost(stops, r.order, opts))
+			}
+		}
+	}
+}
+
+func TestOptimizeLeavesNoImprovingMove(t *testing.T) {
+	random := rand.New(rand.NewSource(2))
+	start := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	opts := Options{Start: geo.Point{Latitude: 42.7, Longitude: 23.3}, StartTime: start, SpeedKmh: 30, ServiceTime: 5 * time.Minute}
+
+	for run := 0; run < 10; run++ {
+		stops := randomStops(random, 12, start)
+		plan := Optimize(stops, opts)
+		order := make([]int, len(plan.Stops))
+		for k, planned := range plan.Stops {
+			for i := range stops {
+				if stops[i].Point == planned.Point {
+					order[k] = i
+				}
+			}
+		}
+		best := plannedCost(stops, order, opts)
+		for i := 0; i < len(order)-1; i++ {
+			for j := i + 1; j < len(order); j++ {
+				reverse(order, i, j)
+				if cost := plannedCost(stops, order, opts); cost < best-1e-9 {
+					t.Fatalf("reversing %d to %d improves the route from %f to %f", i, j, best, cost)
+				}
+				reverse(order, i, j)
+			}
+		}
+	}
+}
+
+func TestOptimizeLongRun(t *testing.T) {
+	start := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	stops := randomStops(rand.New(rand.NewSource(3)), 250, start)
+	opts := Options{Start: geo.Point{Latitude: 42.7, Longitude: 23.3}, StartTime: start, SpeedKmh: 30, ServiceTime: 3 * time.Minute}
+
+	began := time.Now()
+	plan := Optimize(stops, opts)
+	if len(plan.Stops) != len(stops) {
+		t.Fatalf("Optimize() planned %d stops, want %d", len(plan.Stops), len(stops))
+	}
+	if elapsed := time.Since(began); elapsed > 10*time.Second {
+		t.Errorf("Optimize() took %v for %d stops", elapsed, len(stops))
+	}
+}
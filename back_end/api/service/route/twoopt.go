@@ -0,0 +1,150 @@
+package route
+
+import (
+	"time"
+
+	"logistic_company/api/service/geo"
+)
+
+// route keeps the schedule of an order of stops so 2-opt moves can be
+// scored without planning the whole route again. Distances are computed
+// once, the distance change of a move only depends on the two edges it
+// replaces, and the schedule before a move is reused as it stays the same.
+type route struct {
+	stops []Stop
+	opts  Options
+	order []int
+
+	// fromStart[i] is the distance from the start to stop i, between[i][j]
+	// the distance between stops i and j
+	fromStart []float64
+	between   [][]float64
+
+	distanceKm float64
+	// departures[k] is when the courier leaves the stop at position k,
+	// lateness[k] the penalty of arriving there and latenessFrom[k] the sum
+	// of the penalties from position k to the end of the route
+	departures   []time.Time
+	lateness     []float64
+	latenessFrom []float64
+}
+
+func newRoute(stops []Stop, order []int, opts Options) *route {
+	r := &route{
+		stops:        stops,
+		opts:         opts,
+		order:        order,
+		fromStart:    make([]float64, len(stops)),
+		between:      make([][]float64, len(stops)),
+		departures:   make([]time.Time, len(stops)),
+		lateness:     make([]float64, len(stops)),
+		latenessFrom: make([]float64, len(stops)+1),
+	}
+	for i := range stops {
+		r.fromStart[i] = geo.Distance(opts.Start, stops[i].Point)
+		r.between[i] = make([]float64, len(stops))
+		for j := range stops {
+			r.between[i][j] = geo.Distance(stops[i].Point, stops[j].Point)
+		}
+	}
+	for k := range order {
+		r.distanceKm += r.edge(k-1, order[k])
+	}
+	r.reschedule(0)
+	return r
+}
+
+// edge is the distance from the stop at position k to stop, position -1 is
+// the start.
+func (r *route) edge(k, stop int) float64 {
+	if k < 0 {
+		return r.fromStart[stop]
+	}
+	return r.between[r.order[k]][stop]
+}
+
+func (r *route) departure(k int) time.Time {
+	if k < 0 {
+		return r.opts.StartTime
+	}
+	return r.departures[k]
+}
+
+func (r *route) cost() float64 {
+	return r.distanceKm + r.latenessFrom[0]
+}
+
+// visit serves stop after leaving the previous one at departed, it returns
+// when the courier leaves stop and the lateness penalty of arriving there.
+func (r *route) visit(stop int, departed time.Time, distanceKm float64) (time.Time, float64) {
+	arrival, lateness := arrive(r.stops[stop], departed.Add(travelTime(distanceKm, r.opts)))
+	return arrival.Add(r.opts.ServiceTime), lateness.Minutes() * latenessPenaltyKm
+}
+
+// reschedule recomputes the schedule from position k on.
+func (r *route) reschedule(k int) {
+	for ; k < len(r.order); k++ {
+		r.departures[k], r.lateness[k] = r.visit(r.order[k], r.departure(k-1), r.edge(k-1, r.order[k]))
+	}
+	for k := len(r.order) - 1; k >= 0; k-- {
+		r.latenessFrom[k] = r.latenessFrom[k+1] + r.lateness[k]
+	}
+}
+
+// improves reports whether reversing the stops at positions i to j makes
+// the route cheaper. The schedule is only simulated from i on, and stops
+// once it costs more than the current route or catches up with it.
+func (r *route) improves(i, j int) bool {
+	last := len(r.order) - 1
+	distanceKm := r.reversedDistance(i, j)
+	best := r.cost() - 1e-9
+	if distanceKm >= best {
+		return false
+	}
+
+	cost := distanceKm + r.latenessFrom[0] - r.latenessFrom[i]
+	departed := r.departure(i - 1)
+	previous := -1
+	if i > 0 {
+		previous = r.order[i-1]
+	}
+	for k := i; k <= last; k++ {
+		// Positions i to j are visited backwards
+		stop := r.order[k]
+		if k <= j {
+			stop = r.order[i+j-k]
+		}
+		if k > j+1 && departed.Equal(r.departure(k-1)) {
+			// From here on the route is the same as before
+			return cost+r.latenessFrom[k] < best
+		}
+		distance := r.fromStart[stop]
+		if previous >= 0 {
+			distance = r.between[previous][stop]
+		}
+		var lateness float64
+		departed, lateness = r.visit(stop, departed, distance)
+		if cost += lateness; cost >= best {
+			return false
+		}
+		previous = stop
+	}
+	return true
+}
+
+// reversedDistance is the length of the route with the stops at positions
+// i to j reversed. Only the edges into and out of the reversed part change,
+// distances are the same both ways.
+func (r *route) reversedDistance(i, j int) float64 {
+	distanceKm := r.distanceKm - r.edge(i-1, r.order[i]) + r.edge(i-1, r.order[j])
+	if j < len(r.order)-1 {
+		distanceKm += r.between[r.order[i]][r.order[j+1]] - r.between[r.order[j]][r.order[j+1]]
+	}
+	return distanceKm
+}
+
+func (r *route) reverse(i, j int) {
+	r.distanceKm = r.reversedDistance(i, j)
+	reverse(r.order, i, j)
+	r.reschedule(i)
+}
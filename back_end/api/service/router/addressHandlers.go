@@ -0,0 +1,207 @@
+package router
+
+import (
+	"errors"
+	"logistic_company/config"
+	"logistic_company/model"
+	"logistic_company/repository"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// canManageClient reports whether the user can see and change the address
+// book and the settings of the client in the path, which is the client and
+// the staff.
+func canManageClient(c *gin.Context) bool {
+	role, _ := c.Get(config.Role)
+	contextID, _ := c.Get(config.Id)
+	return role == config.RoleAdmin || role == config.RoleEmployee || contextID == c.Param(config.Id)
+}
+
+// addressError responds with the status matching an address book error.
+func addressError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repository.ErrorNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, repository.ErrorInvalidAddress):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// bindAddress reads an address of the client in the path from the request
+// and geocodes it when it comes without coordinates.
+func (r *Router) bindAddress(c *gin.Context, address *model.Address) bool {
+	if err := c.ShouldBindJSON(address); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return false
+	}
+	address.ClientID = c.Param(config.Id)
+	address.Country = strings.ToUpper(strings.TrimSpace(address.Country))
+	address.PostalCode = strings.ToUpper(strings.TrimSpace(address.PostalCode))
+	if address.Latitude == nil {
+		address.Latitude, address.Longitude = r.geocode(c.Request.Context(), address.String())
+	}
+	return true
+}
+
+// @Summary Get client addresses
+// @Description Lists the address book of a client, the default address first
+// @Tags Client
+// @Accept json
+// @Produce json
+// @Param id path string true "Client ID"
+// @Success 200 {object} []model.Address
+// @Failure 403 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/client/{id}/addresses [get]
+// @Security BearerAuth
+func (r *Router) GetClientAddresses(c *gin.Context) {
+	if !canManageClient(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var addresses []model.Address
+	err := r.repository.ClientRepository.GetAddresses(c.Request.Context(), &addresses, c.Param(config.Id))
+	if err != nil {
+		addressError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, addresses)
+}
+
+// @Summary Add client address
+// @Description Adds an address to the address book of a client. Country is the ISO 3166-1 alpha-2 code
+// @Description and the postal code has to match its format. The first address becomes the default one.
+// @Tags Client
+// @Accept json
+// @Produce json
+// @Param id path string true "Client ID"
+// @Param address body model.Address true "Address"
+// @Success 201 {object} model.Address
+// @Failure 400 {object} gin.H
+// @Failure 403 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/client/{id}/addresses [post]
+// @Security BearerAuth
+func (r *Router) CreateClientAddress(c *gin.Context) {
+	if !canManageClient(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var address model.Address
+	if !r.bindAddress(c, &address) {
+		return
+	}
+
+	err := r.repository.ClientRepository.CreateAddress(c.Request.Context(), &address)
+	if err != nil {
+		addressError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, address)
+}
+
+// @Summary Update client address
+// @Description Replaces an address in the address book of a client. Packages sent to it before keep
+// @Description the address they were sent to.
+// @Tags Client
+// @Accept json
+// @Produce json
+// @Param id path string true "Client ID"
+// @Param addressId path string true "Address ID"
+// @Param address body model.Address true "Address"
+// @Success 200 {object} model.Address
+// @Failure 400 {object} gin.H
+// @Failure 403 {object} gin.H
+// @Failure 404 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/client/{id}/addresses/{addressId} [put]
+// @Security BearerAuth
+func (r *Router) UpdateClientAddress(c *gin.Context) {
+	if !canManageClient(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var address model.Address
+	if !r.bindAddress(c, &address) {
+		return
+	}
+	address.ID = c.Param("addressId")
+
+	err := r.repository.ClientRepository.UpdateAddress(c.Request.Context(), &address)
+	if err != nil {
+		addressError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, address)
+}
+
+// @Summary Set default client address
+// @Description Makes an address the default address of its client, packages without a delivery
+// @Description address go there
+// @Tags Client
+// @Accept json
+// @Produce json
+// @Param id path string true "Client ID"
+// @Param addressId path string true "Address ID"
+// @Success 200 {object} model.Address
+// @Failure 403 {object} gin.H
+// @Failure 404 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/client/{id}/addresses/{addressId}/default [post]
+// @Security BearerAuth
+func (r *Router) SetDefaultClientAddress(c *gin.Context) {
+	if !canManageClient(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var address model.Address
+	err := r.repository.ClientRepository.SetDefaultAddress(c.Request.Context(), &address, c.Param(config.Id), c.Param("addressId"))
+	if err != nil {
+		addressError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, address)
+}
+
+// @Summary Delete client address
+// @Description Removes an address from the address book of a client. Addresses packages were sent to
+// @Description are archived instead. When the default address goes the newest remaining one becomes the
+// @Description default.
+// @Tags Client
+// @Accept json
+// @Produce json
+// @Param id path string true "Client ID"
+// @Param addressId path string true "Address ID"
+// @Success 204
+// @Failure 403 {object} gin.H
+// @Failure 404 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/client/{id}/addresses/{addressId} [delete]
+// @Security BearerAuth
+func (r *Router) DeleteClientAddress(c *gin.Context) {
+	if !canManageClient(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	err := r.repository.ClientRepository.DeleteAddress(c.Request.Context(), c.Param(config.Id), c.Param("addressId"))
+	if err != nil {
+		addressError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,206 @@
+package router
+
+import (
+	"errors"
+	"logistic_company/config"
+	"logistic_company/model"
+	"logistic_company/repository"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Record failed delivery attempt
+// @Description Records a failed delivery attempt with a reason code (recipient_not_home, address_not_found,
+// @Description no_access, business_closed or other). When the company's attempt limit is reached the package
+// @Description is held at an office or returned to the sender. Only packages the courrier still has to deliver
+// @Description can have failed attempts, not ones waiting at an office or in a locker, held or going back.
+// @Tags Package
+// @Accept json
+// @Produce json
+// @Param id path string true "Package ID"
+// @Param attempt body model.DeliveryAttemptRequest true "Attempt"
+// @Success 201 {object} model.DeliveryAttempt
+// @Failure 400 {object} gin.H
+// @Failure 409 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/package/{id}/attempt [post]
+// @Security BearerAuth
+func (r *Router) CreateDeliveryAttempt(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	contextID, _ := c.Get(config.Id)
+	id := c.Param(config.Id)
+
+	var packageModel model.Package
+	err := r.repository.PackageRepository.GetPackageById(c.Request.Context(), &packageModel, id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if role != config.RoleAdmin && role != config.RoleEmployee &&
+		(role != config.RoleCourrier || !isCourrier(contextID, &packageModel)) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var request model.DeliveryAttemptRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	attempt := model.DeliveryAttempt{
+		PackageID: id,
+		Reason:    request.Reason,
+		Note:      request.Note,
+	}
+	if role == config.RoleCourrier {
+		attempt.CourrierID = *packageModel.CourrierID
+	}
+
+	err = r.repository.PackageRepository.CreateDeliveryAttempt(c.Request.Context(), &attempt)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrorInvalidDeliveryAttempt):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, repository.ErrorPackageDelivered):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, attempt)
+}
+
+// @Summary Get delivery attempts
+// @Description Get the failed delivery attempts of a package
+// @Tags Package
+// @Accept json
+// @Produce json
+// @Param id path string true "Package ID"
+// @Success 200 {object} []model.DeliveryAttempt
+// @Failure 400 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/package/{id}/attempts [get]
+// @Security BearerAuth
+func (r *Router) GetDeliveryAttempts(c *gin.Context) {
+	id := c.Param(config.Id)
+
+	var packageModel model.Package
+	err := r.repository.PackageRepository.GetPackageById(c.Request.Context(), &packageModel, id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !r.canViewPackage(c, &packageModel) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var attempts []model.DeliveryAttempt
+
+	err = r.repository.PackageRepository.GetDeliveryAttempts(c.Request.Context(), &attempts, id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, attempts)
+}
+
+// @Summary Schedule redelivery
+// @Description Lets the receiver of a package that could not be delivered pick a new delivery
+// @Description date (option "date", date YYYY-MM-DD) or switch to pickup at an office of the
+// @Description company (option "office_pickup", officeID)
+// @Tags Package
+// @Accept json
+// @Produce json
+// @Param id path string true "Package ID"
+// @Param redelivery body model.RedeliveryRequest true "Redelivery"
+// @Success 200 {object} model.Package
+// @Failure 400 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/package/{id}/redelivery [post]
+// @Security BearerAuth
+func (r *Router) ScheduleRedelivery(c *gin.Context) {
+	contextID, _ := c.Get(config.Id)
+	id := c.Param(config.Id)
+
+	var packageModel model.Package
+	err := r.repository.PackageRepository.GetPackageById(c.Request.Context(), &packageModel, id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !isReceiver(contextID, &packageModel) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var request model.RedeliveryRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	err = r.repository.PackageRepository.ScheduleRedelivery(c.Request.Context(), &packageModel, id, request)
+	if err != nil {
+		if errors.Is(err, repository.ErrorInvalidRedelivery) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, packageModel)
+}
+
+// @Summary Return package to sender
+// @Description Creates a return shipment that sends the package back from the receiver to the office
+// @Description the sender handed it in at. The reason is one of refused, undeliverable, sender_recalled or other.
+// @Description Delivered packages and shipment requests that were not handed in yet cannot be returned.
+// @Tags Package
+// @Accept json
+// @Produce json
+// @Param id path string true "Package ID"
+// @Param return body model.ReturnRequest true "Return"
+// @Success 201 {object} model.Package
+// @Failure 400 {object} gin.H
+// @Failure 409 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/package/{id}/return [post]
+// @Security BearerAuth
+func (r *Router) CreateReturn(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	if role != config.RoleAdmin && role != config.RoleEmployee {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var request model.ReturnRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	var returnPackage model.Package
+
+	err := r.repository.PackageRepository.CreateReturn(c.Request.Context(), &returnPackage, c.Param(config.Id), request.Reason)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrorInvalidReturn):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, repository.ErrorNoCourriersAvailable), errors.Is(err, repository.ErrorPackageDelivered),
+			errors.Is(err, repository.ErrorNotHandedIn):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, returnPackage)
+}
@@ -0,0 +1,64 @@
+package router
+
+import (
+	"errors"
+	"logistic_company/config"
+	"logistic_company/model"
+	"logistic_company/repository"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Get audit logs
+// @Description Get the audit trail of write operations, newest first
+// @Tags Audit
+// @Accept json
+// @Produce json
+// @Param actorId query string false "Actor ID"
+// @Param actorRole query string false "Actor role"
+// @Param action query string false "Action"
+// @Param entityType query string false "Entity type"
+// @Param entityId query string false "Entity ID"
+// @Param from query string false "From date (YYYY-MM-DD) or time (RFC 3339)"
+// @Param to query string false "To date (YYYY-MM-DD, inclusive) or time (RFC 3339)"
+// @Param limit query int false "limit"
+// @Param offset query int false "offset"
+// @Success 200 {object} []model.AuditLog
+// @Failure 400 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/audit [get]
+// @Security BearerAuth
+func (r *Router) GetAuditLogs(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	if role != config.RoleAdmin {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	limit, offset, err := extractPagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var filter model.AuditFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	var logs []model.AuditLog
+
+	err = r.repository.AuditRepository.GetAuditLogs(c.Request.Context(), &logs, filter, limit, offset)
+	if errors.Is(err, repository.ErrorInvalidAuditFilter) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, logs)
+}
@@ -0,0 +1,305 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"logistic_company/config"
+	"logistic_company/model"
+	"logistic_company/repository"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func claimError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repository.ErrorNotInsured), errors.Is(err, repository.ErrorInvalidClaim),
+		errors.Is(err, repository.ErrorInvalidClaimTransition):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, repository.ErrorClaimExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// loadClaim loads the claim in the path for the claimant and the staff of
+// the claim's company. It writes the error response itself.
+func (r *Router) loadClaim(c *gin.Context, claim *model.InsuranceClaim) bool {
+	err := r.repository.ClaimRepository.GetClaimByID(c.Request.Context(), claim, c.Param(config.Id))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return false
+	}
+
+	role, _ := c.Get(config.Role)
+	contextID, _ := c.Get(config.Id)
+	if role == config.RoleClient && claim.ClaimantID == contextID {
+		return true
+	}
+	if role != config.RoleClient && claim.Package != nil && r.canViewPackage(c, claim.Package) {
+		return true
+	}
+	c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+	return false
+}
+
+// @Summary File insurance claim
+// @Description Files a claim (type lost or damaged) for an insured package. The sender or the
+// @Description receiver can claim at most the declared value of the package.
+// @Tags Claim
+// @Accept json
+// @Produce json
+// @Param claim body model.ClaimRequest true "Claim"
+// @Success 201 {object} model.InsuranceClaim
+// @Failure 400 {object} gin.H
+// @Failure 409 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/claim [post]
+// @Security BearerAuth
+func (r *Router) CreateClaim(c *gin.Context) {
+	contextID, _ := c.Get(config.Id)
+
+	var request model.ClaimRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	var packageModel model.Package
+	err := r.repository.PackageRepository.GetPackageById(c.Request.Context(), &packageModel, request.PackageID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if contextID != packageModel.SenderID && !isReceiver(contextID, &packageModel) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	claim := model.InsuranceClaim{
+		PackageID:     request.PackageID,
+		ClaimantID:    fmt.Sprint(contextID),
+		Type:          request.Type,
+		Description:   request.Description,
+		ClaimedAmount: request.ClaimedAmount,
+	}
+
+	err = r.repository.ClaimRepository.CreateClaim(c.Request.Context(), &claim)
+	if err != nil {
+		claimError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, claim)
+}
+
+// @Summary Get insurance claim by id
+// @Description Get insurance claim by id
+// @Tags Claim
+// @Accept json
+// @Produce json
+// @Param id path string true "Claim ID"
+// @Success 200 {object} model.InsuranceClaim
+// @Failure 400 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/claim/{id} [get]
+// @Security BearerAuth
+func (r *Router) GetClaimByID(c *gin.Context) {
+	var claim model.InsuranceClaim
+	if !r.loadClaim(c, &claim) {
+		return
+	}
+
+	c.JSON(http.StatusOK, claim)
+}
+
+// @Summary Get insurance claims by package id
+// @Description Get insurance claims by package id
+// @Tags Claim
+// @Accept json
+// @Produce json
+// @Param id path string true "Package ID"
+// @Success 200 {object} []model.InsuranceClaim
+// @Failure 400 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/claim/package/{id} [get]
+// @Security BearerAuth
+func (r *Router) GetClaimsByPackageID(c *gin.Context) {
+	id := c.Param(config.Id)
+
+	var packageModel model.Package
+	err := r.repository.PackageRepository.GetPackageById(c.Request.Context(), &packageModel, id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !r.canViewPackage(c, &packageModel) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var claims []model.InsuranceClaim
+
+	err = r.repository.ClaimRepository.GetClaimsByPackageID(c.Request.Context(), &claims, id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, claims)
+}
+
+// @Summary Get insurance claims by company id
+// @Description Get the insurance claims of a company, optionally with a given status. Employees can only
+// @Description list the claims of their own company.
+// @Tags Claim
+// @Accept json
+// @Produce json
+// @Param id path string true "Company ID"
+// @Param status query string false "Claim status"
+// @Param limit query int false "limit"
+// @Param offset query int false "offset"
+// @Success 200 {object} []model.InsuranceClaim
+// @Failure 400 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/claim/company/{id} [get]
+// @Security BearerAuth
+func (r *Router) GetClaimsByCompanyID(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	if role != config.RoleAdmin && role != config.RoleEmployee || !r.isCompanyStaff(c, c.Param(config.Id)) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	limit, offset, err := extractPagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var claims []model.InsuranceClaim
+
+	err = r.repository.ClaimRepository.GetClaimsByCompanyID(c.Request.Context(), &claims, c.Param(config.Id), c.Query("status"), limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, claims)
+}
+
+// @Summary Update insurance claim status
+// @Description Moves a claim along its workflow: submitted to under_review or rejected, under_review
+// @Description to approved (with approvedAmount) or rejected, and approved to paid
+// @Tags Claim
+// @Accept json
+// @Produce json
+// @Param id path string true "Claim ID"
+// @Param status body model.ClaimStatusRequest true "Status"
+// @Success 200 {object} model.InsuranceClaim
+// @Failure 400 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/claim/{id}/status [patch]
+// @Security BearerAuth
+func (r *Router) UpdateClaimStatus(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	contextID, _ := c.Get(config.Id)
+	if role != config.RoleAdmin && role != config.RoleEmployee {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var request model.ClaimStatusRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	var claim model.InsuranceClaim
+
+	err := r.repository.ClaimRepository.UpdateClaimStatus(c.Request.Context(), &claim, c.Param(config.Id), request, fmt.Sprint(contextID))
+	if err != nil {
+		claimError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, claim)
+}
+
+// @Summary Attach claim evidence
+// @Description Attaches a photo or PDF document to a claim that is still being decided on
+// @Tags Claim
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Claim ID"
+// @Param file formData file true "Evidence"
+// @Success 201 {object} model.ClaimEvidence
+// @Failure 400 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/claim/{id}/evidence [post]
+// @Security BearerAuth
+func (r *Router) AddClaimEvidence(c *gin.Context) {
+	var claim model.InsuranceClaim
+	if !r.loadClaim(c, &claim) {
+		return
+	}
+	contextID, _ := c.Get(config.Id)
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	evidence := model.ClaimEvidence{
+		ClaimID:    claim.ID,
+		Key:        "claim/" + claim.ID + "/" + uuid.New().String(),
+		FileName:   file.Filename,
+		UploadedBy: fmt.Sprint(contextID),
+	}
+	evidence.ContentType, err = r.storeUpload(ctx, evidence.Key, file, "image/", "application/pdf")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err = r.repository.ClaimRepository.AddEvidence(ctx, &evidence)
+	if err != nil {
+		r.deleteBlobs(ctx, evidence.Key)
+		claimError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, evidence)
+}
+
+// @Summary Get claim evidence
+// @Description Downloads a file attached to a claim
+// @Tags Claim
+// @Produce octet-stream
+// @Param id path string true "Claim ID"
+// @Param evidenceId path string true "Evidence ID"
+// @Success 200 {file} file
+// @Failure 400 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/claim/{id}/evidence/{evidenceId} [get]
+// @Security BearerAuth
+func (r *Router) GetClaimEvidence(c *gin.Context) {
+	var claim model.InsuranceClaim
+	if !r.loadClaim(c, &claim) {
+		return
+	}
+
+	var evidence model.ClaimEvidence
+
+	err := r.repository.ClaimRepository.GetEvidence(c.Request.Context(), &evidence, claim.ID, c.Param("evidenceId"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	r.serveBlob(c, evidence.Key, evidence.ContentType)
+}
@@ -0,0 +1,318 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"logistic_company/config"
+	"logistic_company/model"
+	"logistic_company/repository"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Get pending cash on delivery collections
+// @Description Get the cash a courrier collected and has not handed over yet
+// @Tags COD
+// @Accept json
+// @Produce json
+// @Param id path string true "Courrier ID"
+// @Success 200 {object} []model.CODCollection
+// @Failure 400 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/cod/courrier/{id}/pending [get]
+// @Security BearerAuth
+func (r *Router) GetPendingCollections(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	contextID, _ := c.Get(config.Id)
+	id := c.Param(config.Id)
+	if role != config.RoleAdmin && role != config.RoleEmployee && contextID != id {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var collections []model.CODCollection
+
+	err := r.repository.CODRepository.GetPendingCollections(c.Request.Context(), &collections, id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, collections)
+}
+
+// @Summary Record cash handover
+// @Description Records the cash in one currency a courrier handed over for a day. The handover covers every
+// @Description collection in that currency up to that day that was not handed over yet and is flagged when
+// @Description the amounts do not match. The currency defaults to EUR.
+// @Tags COD
+// @Accept json
+// @Produce json
+// @Param handover body model.CashHandoverRequest true "Handover"
+// @Success 201 {object} model.CashHandover
+// @Failure 400 {object} gin.H
+// @Failure 409 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/cod/handover [post]
+// @Security BearerAuth
+func (r *Router) CreateCashHandover(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	contextID, _ := c.Get(config.Id)
+	if role != config.RoleAdmin && role != config.RoleEmployee {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var request model.CashHandoverRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	var handover model.CashHandover
+
+	err := r.repository.CODRepository.CreateHandover(c.Request.Context(), &handover, request, fmt.Sprint(contextID))
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrorInvalidHandover):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, repository.ErrorHandoverExists):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, handover)
+}
+
+// @Summary Get cash handovers by company id
+// @Description Get the cash handovers of a company, optionally only the flagged ones
+// @Tags COD
+// @Accept json
+// @Produce json
+// @Param id path string true "Company ID"
+// @Param flagged query bool false "Only handovers with a discrepancy"
+// @Param limit query int false "limit"
+// @Param offset query int false "offset"
+// @Success 200 {object} []model.CashHandover
+// @Failure 400 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/cod/handover/company/{id} [get]
+// @Security BearerAuth
+func (r *Router) GetCashHandovers(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	if role != config.RoleAdmin && role != config.RoleEmployee {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	limit, offset, err := extractPagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var handovers []model.CashHandover
+
+	err = r.repository.CODRepository.GetHandovers(c.Request.Context(), &handovers, c.Param(config.Id), c.Query("flagged") == "true", limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, handovers)
+}
+
+// @Summary Get cash handover by id
+// @Description Get cash handover by id with its collections
+// @Tags COD
+// @Accept json
+// @Produce json
+// @Param id path string true "Handover ID"
+// @Success 200 {object} model.CashHandover
+// @Failure 400 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/cod/handover/{id} [get]
+// @Security BearerAuth
+func (r *Router) GetCashHandoverByID(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	contextID, _ := c.Get(config.Id)
+	if role != config.RoleAdmin && role != config.RoleEmployee && role != config.RoleCourrier {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var handover model.CashHandover
+
+	err := r.repository.CODRepository.GetHandoverByID(c.Request.Context(), &handover, c.Param(config.Id))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if role == config.RoleCourrier && handover.CourrierID != contextID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	c.JSON(http.StatusOK, handover)
+}
+
+// @Summary Create payout batches
+// @Description Batches the handed over cash of a company that was not paid out yet into one payout
+// @Description per sender and currency. The payout amount is the collected cash minus the COD fees.
+// @Tags COD
+// @Accept json
+// @Produce json
+// @Param payout body model.PayoutRequest true "Payout"
+// @Success 201 {object} []model.PayoutBatch
+// @Failure 400 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/cod/payout [post]
+// @Security BearerAuth
+func (r *Router) CreatePayouts(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	if role != config.RoleAdmin && role != config.RoleEmployee {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var request model.PayoutRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	var batches []model.PayoutBatch
+
+	err := r.repository.CODRepository.CreatePayouts(c.Request.Context(), &batches, request.CompanyID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, batches)
+}
+
+// @Summary Get payout batches
+// @Description Get payout batches by company or sender. Clients only see their own payouts.
+// @Tags COD
+// @Accept json
+// @Produce json
+// @Param companyId query string false "Company ID"
+// @Param senderId query string false "Sender ID"
+// @Param limit query int false "limit"
+// @Param offset query int false "offset"
+// @Success 200 {object} []model.PayoutBatch
+// @Failure 400 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/cod/payout [get]
+// @Security BearerAuth
+func (r *Router) GetPayouts(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	contextID, _ := c.Get(config.Id)
+	companyID, senderID := c.Query("companyId"), c.Query("senderId")
+	switch role {
+	case config.RoleAdmin, config.RoleEmployee:
+	case config.RoleClient:
+		senderID = fmt.Sprint(contextID)
+	default:
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	limit, offset, err := extractPagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var batches []model.PayoutBatch
+
+	err = r.repository.CODRepository.GetPayouts(c.Request.Context(), &batches, companyID, senderID, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, batches)
+}
+
+// @Summary Mark payout batch as paid
+// @Description Mark payout batch as paid
+// @Tags COD
+// @Accept json
+// @Produce json
+// @Param id path string true "Payout batch ID"
+// @Success 200 {object} model.PayoutBatch
+// @Failure 400 {object} gin.H
+// @Failure 409 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/cod/payout/{id}/paid [post]
+// @Security BearerAuth
+func (r *Router) MarkPayoutPaid(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	if role != config.RoleAdmin && role != config.RoleEmployee {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var batch model.PayoutBatch
+
+	err := r.repository.CODRepository.MarkPayoutPaid(c.Request.Context(), &batch, c.Param(config.Id))
+	if errors.Is(err, repository.ErrorPayoutPaid) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, batch)
+}
+
+// @Summary Get company revenue report
+// @Description Get the revenue of a company in a period split into delivery prices and COD fees,
+// @Description together with the COD cash collected and paid out to senders
+// @Tags Company
+// @Accept json
+// @Produce json
+// @Param id path string true "Company ID"
+// @Param start_date query string true "Start date (YYYY-MM-DD)"
+// @Param end_date query string true "End date (YYYY-MM-DD)"
+// @Success 200 {object} model.RevenueReport
+// @Failure 400 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/company/{id}/revenue-report [get]
+// @Security BearerAuth
+func (r *Router) GetCompanyRevenueReport(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	if role != config.RoleAdmin && role != config.RoleEmployee {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	startDate, endDate := c.Query("start_date"), c.Query("end_date")
+	for _, date := range []string{startDate, endDate} {
+		if _, err := time.Parse(config.DateFormat, date); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	var report model.RevenueReport
+
+	// The end date is inclusive
+	err := r.repository.CompanyRepository.GetRevenueReport(c.Request.Context(), &report, c.Param(config.Id), startDate, endDate+" 23:59:59")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	report.EndDate = endDate
+
+	c.JSON(http.StatusOK, report)
+}
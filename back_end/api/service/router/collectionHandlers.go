@@ -0,0 +1,395 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"logistic_company/config"
+	"logistic_company/model"
+	"logistic_company/repository"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+// canViewCollection applies the package visibility rules to a collection.
+func (r *Router) canViewCollection(c *gin.Context, collection *model.Collection) bool {
+	return r.canViewPackage(c, &model.Package{
+		SenderID:  collection.SenderID,
+		CompanyID: collection.CompanyID,
+	})
+}
+
+func collectionError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repository.ErrorNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, repository.ErrorInvalidCollection) || errors.Is(err, repository.ErrorInvalidAddress) ||
+		errors.Is(err, repository.ErrorPackageRejected) || errors.Is(err, repository.ErrorInvalidPackageType) ||
+		errors.Is(err, repository.ErrorDoesNotFitLocker) || errors.Is(err, repository.ErrorInvalidLocker):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, repository.ErrorNoCourriersAvailable) || errors.Is(err, repository.ErrorNoCompartmentAvailable) ||
+		errors.Is(err, repository.ErrorLockerCodesTaken):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// @Summary Schedule collection
+// @Description Schedules a courier to collect parcels from an address of the sender within a time window on one day.
+// @Description Clients schedule collections for themselves, employees for any sender. packageIDs links requested
+// @Description packages waiting to be collected from that address, they are accepted when the collection is completed.
+// @Tags Collection
+// @Accept json
+// @Produce json
+// @Param collection body model.CollectionRequest true "Collection"
+// @Success 201 {object} model.Collection
+// @Failure 400 {object} gin.H
+// @Failure 403 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/collection [post]
+// @Security BearerAuth
+func (r *Router) CreateCollection(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	contextID, _ := c.Get(config.Id)
+	if role != config.RoleAdmin && role != config.RoleEmployee && role != config.RoleClient {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var request model.CollectionRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.Error(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+	if role == config.RoleClient {
+		request.SenderID = fmt.Sprint(contextID)
+	}
+	if request.SenderID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Sender ID is required"})
+		return
+	}
+	windowStart, startErr := time.Parse(time.RFC3339, request.WindowStart)
+	windowEnd, endErr := time.Parse(time.RFC3339, request.WindowEnd)
+	if startErr != nil || endErr != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid time window"})
+		return
+	}
+
+	collection := model.Collection{
+		CompanyID:   request.CompanyID,
+		SenderID:    request.SenderID,
+		AddressID:   request.AddressID,
+		WindowStart: windowStart,
+		WindowEnd:   windowEnd,
+		Parcels:     request.Parcels,
+		Note:        request.Note,
+	}
+	err := r.repository.CollectionRepository.CreateCollection(c.Request.Context(), &collection, request.PackageIDs)
+	if err != nil {
+		collectionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, collection)
+}
+
+// @Summary Get collection by id
+// @Description Get a collection with its address, courier and packages
+// @Tags Collection
+// @Accept json
+// @Produce json
+// @Param id path string true "Collection ID"
+// @Success 200 {object} model.Collection
+// @Failure 403 {object} gin.H
+// @Failure 404 {object} gin.H
+// @Router /api/v1/collection/{id} [get]
+// @Security BearerAuth
+func (r *Router) GetCollectionByID(c *gin.Context) {
+	var collection model.Collection
+	err := r.repository.CollectionRepository.GetCollectionByID(c.Request.Context(), &collection, c.Param(config.Id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if !r.canViewCollection(c, &collection) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	c.JSON(http.StatusOK, collection)
+}
+
+// @Summary Get collections by company id
+// @Description Get the collections of a company in the order of their windows, optionally on a day or with a status
+// @Tags Collection
+// @Accept json
+// @Produce json
+// @Param id path string true "Company ID"
+// @Param date query string false "Date (YYYY-MM-DD)"
+// @Param status query string false "Collection status"
+// @Param limit query int false "limit"
+// @Param offset query int false "offset"
+// @Success 200 {object} []model.Collection
+// @Failure 400 {object} gin.H
+// @Failure 403 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/collection/company/{id} [get]
+// @Security BearerAuth
+func (r *Router) GetCollectionsByCompanyID(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	if role != config.RoleAdmin && role != config.RoleEmployee {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	limit, offset, err := extractPagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var collections []model.Collection
+	err = r.repository.CollectionRepository.GetCollectionsByCompanyID(c.Request.Context(), &collections, c.Param(config.Id),
+		c.Query("date"), c.Query("status"), limit, offset)
+	if err != nil {
+		collectionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, collections)
+}
+
+// @Summary Get collections by sender id
+// @Description Get the collections scheduled for a sender, newest first
+// @Tags Collection
+// @Accept json
+// @Produce json
+// @Param id path string true "Sender ID"
+// @Param limit query int false "limit"
+// @Param offset query int false "offset"
+// @Success 200 {object} []model.Collection
+// @Failure 400 {object} gin.H
+// @Failure 403 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/collection/sender/{id} [get]
+// @Security BearerAuth
+func (r *Router) GetCollectionsBySenderID(c *gin.Context) {
+	if !canManageClient(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	limit, offset, err := extractPagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var collections []model.Collection
+	err = r.repository.CollectionRepository.GetCollectionsBySenderID(c.Request.Context(), &collections, c.Param(config.Id), limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, collections)
+}
+
+// @Summary Assign collection
+// @Description Assigns a collection to a courier of its company, to the courier with the fewest collections
+// @Description that day when courrierID is omitted
+// @Tags Collection
+// @Accept json
+// @Produce json
+// @Param id path string true "Collection ID"
+// @Param assignment body model.CollectionAssignment false "Courier"
+// @Success 200 {object} model.Collection
+// @Failure 400 {object} gin.H
+// @Failure 403 {object} gin.H
+// @Failure 404 {object} gin.H
+// @Failure 409 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/collection/{id}/assign [post]
+// @Security BearerAuth
+func (r *Router) AssignCollection(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	if role != config.RoleAdmin && role != config.RoleEmployee {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var assignment model.CollectionAssignment
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&assignment); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+			return
+		}
+	}
+
+	var collection model.Collection
+	err := r.repository.CollectionRepository.GetCollectionByID(c.Request.Context(), &collection, c.Param(config.Id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if !r.canViewCollection(c, &collection) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var assigned model.Collection
+	err = r.repository.CollectionRepository.AssignCollection(c.Request.Context(), &assigned, collection.ID, assignment.CourrierID)
+	if err != nil {
+		collectionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, assigned)
+}
+
+// @Summary Cancel collection
+// @Description Cancels a collection that was not completed. Its packages stay requested.
+// @Tags Collection
+// @Accept json
+// @Produce json
+// @Param id path string true "Collection ID"
+// @Success 200 {object} model.Collection
+// @Failure 400 {object} gin.H
+// @Failure 403 {object} gin.H
+// @Failure 404 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/collection/{id}/cancel [post]
+// @Security BearerAuth
+func (r *Router) CancelCollection(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	if role != config.RoleAdmin && role != config.RoleEmployee && role != config.RoleClient {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var collection model.Collection
+	err := r.repository.CollectionRepository.GetCollectionByID(c.Request.Context(), &collection, c.Param(config.Id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if !r.canViewCollection(c, &collection) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var cancelled model.Collection
+	err = r.repository.CollectionRepository.CancelCollection(c.Request.Context(), &cancelled, collection.ID)
+	if err != nil {
+		collectionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, cancelled)
+}
+
+// @Summary Complete collection
+// @Description Records that the courier collected the parcels. The linked packages are accepted at the
+// @Description courier's office with the weight and size the courier measured, or the sender's estimate.
+// @Tags Collection
+// @Accept json
+// @Produce json
+// @Param id path string true "Collection ID"
+// @Param completion body model.CollectionCompletion false "Measured parcels"
+// @Success 200 {object} model.Collection
+// @Failure 400 {object} gin.H
+// @Failure 403 {object} gin.H
+// @Failure 404 {object} gin.H
+// @Failure 409 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/collection/{id}/complete [post]
+// @Security BearerAuth
+func (r *Router) CompleteCollection(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	contextID, _ := c.Get(config.Id)
+
+	var completion model.CollectionCompletion
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&completion); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+			return
+		}
+	}
+	for _, parcel := range completion.Parcels {
+		if parcel.Weight <= 0 || parcel.Length < 0 || parcel.Width < 0 || parcel.Height < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid weight or dimensions"})
+			return
+		}
+	}
+
+	var collection model.Collection
+	err := r.repository.CollectionRepository.GetCollectionByID(c.Request.Context(), &collection, c.Param(config.Id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if role != config.RoleAdmin && role != config.RoleEmployee &&
+		(role != config.RoleCourrier || collection.CourrierID == nil || contextID != *collection.CourrierID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+	if !r.canViewCollection(c, &collection) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var completed model.Collection
+	err = r.repository.CollectionRepository.CompleteCollection(c.Request.Context(), &completed, collection.ID, completion)
+	if err != nil {
+		collectionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, completed)
+}
+
+// @Summary Get courrier day
+// @Description Get the collections of a courrier on a day in the order of their windows, alongside
+// @Description the packages they deliver that day
+// @Tags Route
+// @Accept json
+// @Produce json
+// @Param id path string true "Courrier ID"
+// @Param date query string false "Date (YYYY-MM-DD), defaults to today"
+// @Success 200 {object} model.CourrierDay
+// @Failure 400 {object} gin.H
+// @Failure 403 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/route/courrier/{id}/today [get]
+// @Security BearerAuth
+func (r *Router) GetCourrierDay(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	contextID, _ := c.Get(config.Id)
+	id := c.Param(config.Id)
+	if role != config.RoleAdmin && role != config.RoleEmployee && contextID != id {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	date := time.Now()
+	if value := c.Query("date"); value != "" {
+		var err error
+		if date, err = time.ParseInLocation(config.DateFormat, value, time.Local); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date"})
+			return
+		}
+	}
+
+	var day model.CourrierDay
+	err := r.repository.CollectionRepository.GetCourrierDay(c.Request.Context(), &day, id, date)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, day)
+}
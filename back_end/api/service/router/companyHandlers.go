@@ -2,14 +2,40 @@ package router
 
 import (
 	"encoding/json"
+	"errors"
 	"io"
 	"logistic_company/config"
 	"logistic_company/model"
+	"logistic_company/repository"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+// validateCompanySettings checks the configurable delivery settings of a
+// company and returns what is wrong with them.
+func validateCompanySettings(company *model.Company) string {
+	switch {
+	case company.AssignmentStrategy != "" && !repository.IsAssignmentStrategy(company.AssignmentStrategy):
+		return "Unknown assignment strategy"
+	case company.FailedDeliveryAction != "" && !repository.IsFailedDeliveryAction(company.FailedDeliveryAction):
+		return "Unknown failed delivery action"
+	case company.MaxDeliveryAttempts < 0:
+		return "Invalid maximum delivery attempts"
+	case company.ReturnPricing != "" && !repository.IsReturnPricing(company.ReturnPricing):
+		return "Unknown return pricing"
+	case company.ReturnPriceValue < 0 || company.CODFeePercentage < 0 ||
+		company.InsuranceRatePercentage < 0 || company.InsuranceMinPremium < 0 || company.VolumetricDivisor < 0:
+		return "Rates and prices cannot be negative"
+	case company.StorageDays < 0 || company.StorageFreeDays < 0 || company.StorageFeePerDay < 0:
+		return "Storage settings cannot be negative"
+	case company.LockerPickupDays < 0:
+		return "Invalid locker pickup days"
+	}
+	return ""
+}
+
 // @Summary Get all companies
 // @Description Get all companies
 // @Tags Company
@@ -127,6 +153,45 @@ func (r *Router) GetCompanyRevenue(c *gin.Context) {
 	c.JSON(http.StatusOK, company)
 }
 
+// @Summary Get company return report
+// @Description Get how many packages registered in a period were returned to their sender, by return reason
+// @Tags Company
+// @Accept json
+// @Produce json
+// @Param id path string true "Company ID"
+// @Param from query string false "From date (YYYY-MM-DD)"
+// @Param to query string false "To date (YYYY-MM-DD)"
+// @Success 200 {object} model.ReturnReport
+// @Failure 400 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/company/{id}/returns [get]
+// @Security BearerAuth
+func (r *Router) GetCompanyReturnReport(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	if role != config.RoleAdmin && role != config.RoleEmployee {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	from, to := c.Query("from"), c.Query("to")
+	for _, date := range []string{from, to} {
+		if _, err := time.Parse(config.DateFormat, date); date != "" && err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	var report model.ReturnReport
+
+	err := r.repository.CompanyRepository.GetReturnReport(c.Request.Context(), &report, c.Param(config.Id), from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
 // @Summary Create company
 // @Description Create company
 // @Tags Company
@@ -150,6 +215,10 @@ func (r *Router) CreateCompany(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
 		return
 	}
+	if message := validateCompanySettings(&company); message != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": message})
+		return
+	}
 
 	err := r.repository.CompanyRepository.CreateCompany(c.Request.Context(), &company)
 	if err != nil {
@@ -161,7 +230,7 @@ func (r *Router) CreateCompany(c *gin.Context) {
 }
 
 // @Summary Update company
-// @Description Update company
+// @Description Update the given fields of a company, fields left out of the body keep their values
 // @Tags Company
 // @Accept json
 // @Produce json
@@ -169,6 +238,7 @@ func (r *Router) CreateCompany(c *gin.Context) {
 // @Param company body model.Company true "Company details"
 // @Success 200 {object} model.Company
 // @Failure 400 {object} gin.H
+// @Failure 404 {object} gin.H
 // @Failure 500 {object} gin.H
 // @Router /api/v1/company/{id} [patch]
 // @Security BearerAuth
@@ -191,9 +261,22 @@ func (r *Router) UpdateCompany(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
 		return
 	}
+	fields, err := suppliedFields(body, &company)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+	if message := validateCompanySettings(&company); message != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": message})
+		return
+	}
 
 	company.ID = id
-	err = r.repository.CompanyRepository.UpdateCompany(c.Request.Context(), &company)
+	err = r.repository.CompanyRepository.UpdateCompany(c.Request.Context(), &company, fields)
+	if errors.Is(err, repository.ErrorNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -202,14 +285,50 @@ func (r *Router) UpdateCompany(c *gin.Context) {
 	c.JSON(http.StatusOK, company)
 }
 
+// @Summary Get company deletion preview
+// @Description Lists the offices, employees and packages that depend on a company
+// @Tags Company
+// @Accept json
+// @Produce json
+// @Param id path string true "Company ID"
+// @Success 200 {object} model.CompanyDeletionPreview
+// @Failure 400 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/company/{id}/deletion-preview [get]
+// @Security BearerAuth
+func (r *Router) GetCompanyDeletionPreview(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	if role != config.RoleAdmin {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+	id := c.Param(config.Id)
+
+	var preview model.CompanyDeletionPreview
+
+	err := r.repository.CompanyRepository.GetCompanyDeletionPreview(c.Request.Context(), &preview, id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, preview)
+}
+
 // @Summary Delete company
-// @Description Delete company
+// @Description Delete company. By default the deletion is refused while anything depends on the company,
+// @Description "archive" archives the company with everything depending on it and
+// @Description "transfer" moves the staff and undelivered packages to another company before archiving.
+// @Description Transfers are refused while undelivered packages are accepted at or delivered to the company's offices.
 // @Tags Company
 // @Accept json
 // @Produce json
 // @Param id path string true "Company ID"
+// @Param mode query string false "Deletion mode" Enums(refuse, archive, transfer)
+// @Param targetCompanyId query string false "Company to transfer to"
 // @Success 200 {object} gin.H
 // @Failure 400 {object} gin.H
+// @Failure 409 {object} gin.H
 // @Failure 500 {object} gin.H
 // @Router /api/v1/company/{id} [delete]
 // @Security BearerAuth
@@ -220,8 +339,18 @@ func (r *Router) DeleteCompany(c *gin.Context) {
 		return
 	}
 	id := c.Param(config.Id)
+	mode := c.DefaultQuery("mode", config.CompanyDeletionRefuse)
+	targetCompanyID := c.Query("targetCompanyId")
 
-	err := r.repository.CompanyRepository.DeleteCompany(c.Request.Context(), id)
+	err := r.repository.CompanyRepository.DeleteCompany(c.Request.Context(), id, mode, targetCompanyID)
+	if errors.Is(err, repository.ErrorCompanyHasDependents) || errors.Is(err, repository.ErrorPackagesNeedOffice) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, repository.ErrorInvalidDeletionMode) || errors.Is(err, repository.ErrorInvalidTransferCompany) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -229,3 +358,100 @@ func (r *Router) DeleteCompany(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"message": "Company deleted successfully"})
 }
+
+// @Summary Get courrier zones
+// @Description Get the courrier zones used by the zone based assignment strategy
+// @Tags Company
+// @Accept json
+// @Produce json
+// @Param id path string true "Company ID"
+// @Success 200 {object} []model.CourrierZone
+// @Failure 400 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/company/{id}/courrier-zones [get]
+// @Security BearerAuth
+func (r *Router) GetCourrierZones(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	if role != config.RoleAdmin && role != config.RoleEmployee {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+	id := c.Param(config.Id)
+
+	var zones []model.CourrierZone
+
+	err := r.repository.CompanyRepository.GetCourrierZones(c.Request.Context(), &zones, id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, zones)
+}
+
+// @Summary Create courrier zone
+// @Description Assign deliveries whose location contains the pattern to a courrier
+// @Tags Company
+// @Accept json
+// @Produce json
+// @Param id path string true "Company ID"
+// @Param zone body model.CourrierZone true "Courrier zone"
+// @Success 201 {object} model.CourrierZone
+// @Failure 400 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/company/{id}/courrier-zones [post]
+// @Security BearerAuth
+func (r *Router) CreateCourrierZone(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	if role != config.RoleAdmin {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var zone model.CourrierZone
+	if err := c.ShouldBindJSON(&zone); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+	zone.CompanyID = c.Param(config.Id)
+
+	err := r.repository.CompanyRepository.CreateCourrierZone(c.Request.Context(), &zone)
+	if errors.Is(err, repository.ErrorNotFound) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Courrier not found in company"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, zone)
+}
+
+// @Summary Delete courrier zone
+// @Description Delete courrier zone
+// @Tags Company
+// @Accept json
+// @Produce json
+// @Param id path string true "Company ID"
+// @Param zoneId path string true "Zone ID"
+// @Success 200 {object} gin.H
+// @Failure 400 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/company/{id}/courrier-zones/{zoneId} [delete]
+// @Security BearerAuth
+func (r *Router) DeleteCourrierZone(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	if role != config.RoleAdmin {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	err := r.repository.CompanyRepository.DeleteCourrierZone(c.Request.Context(), c.Param(config.Id), c.Param("zoneId"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Courrier zone deleted successfully"})
+}
@@ -0,0 +1,316 @@
+package router
+
+import (
+	"errors"
+	"logistic_company/api/service/manifest"
+	"logistic_company/config"
+	"logistic_company/model"
+	"logistic_company/repository"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// authorizeDeliveryRun allows staff to manage every run and couriers to
+// manage only their own runs. It writes the error response itself.
+func (r *Router) authorizeDeliveryRun(c *gin.Context, id string) bool {
+	role, _ := c.Get(config.Role)
+	contextID, _ := c.Get(config.Id)
+	if role == config.RoleAdmin || role == config.RoleEmployee {
+		return true
+	}
+
+	var run model.DeliveryRun
+	err := r.repository.DeliveryRunRepository.GetDeliveryRunByID(c.Request.Context(), &run, id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return false
+	}
+	if role != config.RoleCourrier || run.CourrierID != contextID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return false
+	}
+	return true
+}
+
+func deliveryRunError(c *gin.Context, err error) {
+	if errors.Is(err, repository.ErrorInvalidDeliveryRun) || errors.Is(err, repository.ErrorInvalidDeliveryAttempt) ||
+		errors.Is(err, repository.ErrorInvalidReturn) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, repository.ErrorPackageDelivered) || errors.Is(err, repository.ErrorNoCourriersAvailable) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
+
+// @Summary Create delivery run
+// @Description Creates the delivery run of a courrier for a day. Without packageIDs
+// @Description all packages the courrier can deliver that are not on another open run are included. Packages
+// @Description waiting at an office or in a locker and packages going back to their sender are left out.
+// @Tags DeliveryRun
+// @Accept json
+// @Produce json
+// @Param run body model.DeliveryRunRequest true "Delivery run"
+// @Success 201 {object} model.DeliveryRun
+// @Failure 400 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/run [post]
+// @Security BearerAuth
+func (r *Router) CreateDeliveryRun(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	contextID, _ := c.Get(config.Id)
+
+	var request model.DeliveryRunRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	if role != config.RoleAdmin && role != config.RoleEmployee &&
+		(role != config.RoleCourrier || contextID != request.CourrierID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var run model.DeliveryRun
+
+	err := r.repository.DeliveryRunRepository.CreateDeliveryRun(c.Request.Context(), &run, request)
+	if err != nil {
+		deliveryRunError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, run)
+}
+
+// @Summary Get delivery runs by courrier id
+// @Description Get delivery runs by courrier id, optionally for a single date
+// @Tags DeliveryRun
+// @Accept json
+// @Produce json
+// @Param id path string true "Courrier ID"
+// @Param date query string false "Date (YYYY-MM-DD)"
+// @Param limit query int false "limit"
+// @Param offset query int false "offset"
+// @Success 200 {object} []model.DeliveryRun
+// @Failure 400 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/run/courrier/{id} [get]
+// @Security BearerAuth
+func (r *Router) GetDeliveryRunsByCourrierID(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	contextID, _ := c.Get(config.Id)
+	id := c.Param(config.Id)
+	if role != config.RoleAdmin && role != config.RoleEmployee && contextID != id {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	limit, offset, err := extractPagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var runs []model.DeliveryRun
+
+	err = r.repository.DeliveryRunRepository.GetDeliveryRunsByCourrierID(c.Request.Context(), &runs, id, c.Query("date"), limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, runs)
+}
+
+// @Summary Get delivery run by id
+// @Description Get delivery run by id
+// @Tags DeliveryRun
+// @Accept json
+// @Produce json
+// @Param id path string true "Delivery run ID"
+// @Success 200 {object} model.DeliveryRun
+// @Failure 400 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/run/{id} [get]
+// @Security BearerAuth
+func (r *Router) GetDeliveryRunByID(c *gin.Context) {
+	id := c.Param(config.Id)
+	if !r.authorizeDeliveryRun(c, id) {
+		return
+	}
+
+	var run model.DeliveryRun
+
+	err := r.repository.DeliveryRunRepository.GetDeliveryRunByID(c.Request.Context(), &run, id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, run)
+}
+
+// @Summary Get delivery run manifest
+// @Description Get the printable manifest of a delivery run
+// @Tags DeliveryRun
+// @Produce html
+// @Param id path string true "Delivery run ID"
+// @Success 200 {string} string
+// @Failure 400 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/run/{id}/manifest [get]
+// @Security BearerAuth
+func (r *Router) GetDeliveryRunManifest(c *gin.Context) {
+	id := c.Param(config.Id)
+	if !r.authorizeDeliveryRun(c, id) {
+		return
+	}
+
+	var run model.DeliveryRun
+
+	err := r.repository.DeliveryRunRepository.GetDeliveryRunByID(c.Request.Context(), &run, id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Type", "text/html; charset=utf-8")
+	c.Status(http.StatusOK)
+	if err := manifest.Render(c.Writer, &run); err != nil {
+		c.Error(err)
+	}
+}
+
+// @Summary Reorder delivery run
+// @Description Sets the order of the stops, stopIDs has to list every stop of the run
+// @Tags DeliveryRun
+// @Accept json
+// @Produce json
+// @Param id path string true "Delivery run ID"
+// @Param order body model.DeliveryRunOrderRequest true "Stop order"
+// @Success 200 {object} model.DeliveryRun
+// @Failure 400 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/run/{id}/order [put]
+// @Security BearerAuth
+func (r *Router) ReorderDeliveryRun(c *gin.Context) {
+	id := c.Param(config.Id)
+	if !r.authorizeDeliveryRun(c, id) {
+		return
+	}
+
+	var request model.DeliveryRunOrderRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	var run model.DeliveryRun
+
+	err := r.repository.DeliveryRunRepository.ReorderDeliveryRun(c.Request.Context(), &run, id, request.StopIDs)
+	if err != nil {
+		deliveryRunError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, run)
+}
+
+// @Summary Start delivery run
+// @Description Starts a planned delivery run and sends its packages out for delivery. Packages that can no longer
+// @Description be delivered by the courrier are skipped.
+// @Tags DeliveryRun
+// @Accept json
+// @Produce json
+// @Param id path string true "Delivery run ID"
+// @Success 200 {object} model.DeliveryRun
+// @Failure 400 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/run/{id}/start [post]
+// @Security BearerAuth
+func (r *Router) StartDeliveryRun(c *gin.Context) {
+	id := c.Param(config.Id)
+	if !r.authorizeDeliveryRun(c, id) {
+		return
+	}
+
+	var run model.DeliveryRun
+
+	err := r.repository.DeliveryRunRepository.StartDeliveryRun(c.Request.Context(), &run, id)
+	if err != nil {
+		deliveryRunError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, run)
+}
+
+// @Summary Finish delivery run
+// @Description Finishes a delivery run, stops without an outcome are skipped. Their packages go back to their
+// @Description previous status unless they were delivered or moved on during the run.
+// @Tags DeliveryRun
+// @Accept json
+// @Produce json
+// @Param id path string true "Delivery run ID"
+// @Success 200 {object} model.DeliveryRun
+// @Failure 400 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/run/{id}/finish [post]
+// @Security BearerAuth
+func (r *Router) FinishDeliveryRun(c *gin.Context) {
+	id := c.Param(config.Id)
+	if !r.authorizeDeliveryRun(c, id) {
+		return
+	}
+
+	var run model.DeliveryRun
+
+	err := r.repository.DeliveryRunRepository.FinishDeliveryRun(c.Request.Context(), &run, id)
+	if err != nil {
+		deliveryRunError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, run)
+}
+
+// @Summary Record stop outcome
+// @Description Records the outcome of a stop (delivered, failed or refused) and updates the package status.
+// @Description Failed stops are recorded as delivery attempts with the given reason code.
+// @Tags DeliveryRun
+// @Accept json
+// @Produce json
+// @Param id path string true "Delivery run ID"
+// @Param stopId path string true "Stop ID"
+// @Param outcome body model.DeliveryRunStopOutcomeRequest true "Outcome"
+// @Success 200 {object} model.DeliveryRunStop
+// @Failure 400 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/run/{id}/stop/{stopId} [patch]
+// @Security BearerAuth
+func (r *Router) RecordStopOutcome(c *gin.Context) {
+	id := c.Param(config.Id)
+	if !r.authorizeDeliveryRun(c, id) {
+		return
+	}
+
+	var request model.DeliveryRunStopOutcomeRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	var stop model.DeliveryRunStop
+
+	err := r.repository.DeliveryRunRepository.RecordStopOutcome(c.Request.Context(), &stop, id, c.Param("stopId"), request)
+	if err != nil {
+		deliveryRunError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stop)
+}
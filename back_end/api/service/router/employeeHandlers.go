@@ -2,10 +2,13 @@ package router
 
 import (
 	"encoding/json"
+	"errors"
 	"io"
 	"logistic_company/config"
 	"logistic_company/model"
+	"logistic_company/repository"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
@@ -238,6 +241,7 @@ func (r *Router) UpdateEmployee(c *gin.Context) {
 // @Param id path string true "Employee ID"
 // @Success 200 {object} gin.H
 // @Failure 400 {object} gin.H
+// @Failure 409 {object} gin.H
 // @Failure 500 {object} gin.H
 // @Router /api/v1/employee/{id} [delete]
 // @Security BearerAuth
@@ -251,6 +255,10 @@ func (r *Router) DeleteEmployee(c *gin.Context) {
 	}
 
 	err := r.repository.EmployeeRepository.DeleteEmployee(c.Request.Context(), id)
+	if errors.Is(err, repository.ErrorNoCourriersAvailable) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -258,3 +266,50 @@ func (r *Router) DeleteEmployee(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"message": "Employee deleted successfully"})
 }
+
+// @Summary Reassign employee packages
+// @Description Distributes the undelivered packages of an employee over colleagues of the same company
+// @Description by office and current open workload. With preview=true the proposed assignment is only returned.
+// @Tags Employee
+// @Accept json
+// @Produce json
+// @Param id path string true "Employee ID"
+// @Param preview query bool false "Only preview the assignment"
+// @Success 200 {object} model.ReassignmentPlan
+// @Failure 400 {object} gin.H
+// @Failure 409 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/employee/{id}/reassign [post]
+// @Security BearerAuth
+func (r *Router) ReassignEmployeePackages(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	if role != config.RoleAdmin && role != config.RoleEmployee {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	id := c.Param(config.Id)
+	preview, err := strconv.ParseBool(c.DefaultQuery("preview", "false"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var plan model.ReassignmentPlan
+
+	if preview {
+		err = r.repository.EmployeeRepository.PreviewReassignment(c.Request.Context(), &plan, id)
+	} else {
+		err = r.repository.EmployeeRepository.ReassignPackages(c.Request.Context(), &plan, id)
+	}
+	if errors.Is(err, repository.ErrorNoCourriersAvailable) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, plan)
+}
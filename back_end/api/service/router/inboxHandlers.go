@@ -0,0 +1,91 @@
+package router
+
+import (
+	"errors"
+	"logistic_company/config"
+	"logistic_company/model"
+	"logistic_company/repository"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Get inbox
+// @Description Lists the in-app notifications of the current user, newest first, with how many are unread
+// @Tags Notification
+// @Accept json
+// @Produce json
+// @Param limit query int false "limit"
+// @Param offset query int false "offset"
+// @Param unread query bool false "Only unread notifications"
+// @Success 200 {object} model.Inbox
+// @Failure 400 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/notifications [get]
+// @Security BearerAuth
+func (r *Router) GetInbox(c *gin.Context) {
+	contextID, _ := c.Get(config.Id)
+	limit, offset, err := extractPagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var inbox model.Inbox
+	err = r.repository.InboxRepository.GetInbox(c.Request.Context(), &inbox, contextID.(string), c.Query("unread") == "true", limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, inbox)
+}
+
+// @Summary Mark notification as read
+// @Description Marks an in-app notification of the current user as read
+// @Tags Notification
+// @Accept json
+// @Produce json
+// @Param id path string true "Notification ID"
+// @Success 200 {object} model.InboxNotification
+// @Failure 404 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/notifications/{id}/read [post]
+// @Security BearerAuth
+func (r *Router) MarkNotificationRead(c *gin.Context) {
+	contextID, _ := c.Get(config.Id)
+
+	var notification model.InboxNotification
+	err := r.repository.InboxRepository.MarkRead(c.Request.Context(), &notification, contextID.(string), c.Param(config.Id))
+	if err != nil {
+		if errors.Is(err, repository.ErrorNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, notification)
+}
+
+// @Summary Mark all notifications as read
+// @Description Marks the whole inbox of the current user as read
+// @Tags Notification
+// @Accept json
+// @Produce json
+// @Success 200 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/notifications/read-all [post]
+// @Security BearerAuth
+func (r *Router) MarkAllNotificationsRead(c *gin.Context) {
+	contextID, _ := c.Get(config.Id)
+
+	read, err := r.repository.InboxRepository.MarkAllRead(c.Request.Context(), contextID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"read": read})
+}
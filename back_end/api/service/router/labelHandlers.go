@@ -0,0 +1,62 @@
+package router
+
+import (
+	"bytes"
+	"logistic_company/api/service/label"
+	"logistic_company/config"
+	"logistic_company/model"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Get shipping label
+// @Description Renders the shipping label of a package as PDF, with a Code 128 barcode and a QR code
+// @Description of its tracking number. The a6 layout prints one label per page, a4 four per sheet.
+// @Tags Package
+// @Produce application/pdf
+// @Param id path string true "Package ID"
+// @Param layout query string false "a6 (default) or a4"
+// @Param copies query int false "Number of copies, at most 4"
+// @Success 200 {file} file
+// @Failure 400 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/package/{id}/label [get]
+// @Security BearerAuth
+func (r *Router) GetPackageLabel(c *gin.Context) {
+	layout := c.DefaultQuery("layout", config.LabelLayoutA6)
+	if layout != config.LabelLayoutA6 && layout != config.LabelLayoutA4 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid layout"})
+		return
+	}
+	copies, err := strconv.Atoi(c.DefaultQuery("copies", "1"))
+	if err != nil || copies < 1 || copies > config.MaxLabelCopies {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid number of copies"})
+		return
+	}
+
+	var packageModel model.Package
+	err = r.repository.PackageRepository.GetPackageById(c.Request.Context(), &packageModel, c.Param(config.Id))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !r.canViewPackage(c, &packageModel) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	packages := make([]model.Package, copies)
+	for i := range packages {
+		packages[i] = packageModel
+	}
+	var pdf bytes.Buffer
+	if err := label.Render(&pdf, packages, layout); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Disposition", `inline; filename="label-`+label.TrackingID(&packageModel)+`.pdf"`)
+	c.Data(http.StatusOK, "application/pdf", pdf.Bytes())
+}
@@ -0,0 +1,257 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"logistic_company/config"
+	"logistic_company/model"
+	"logistic_company/repository"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// lockerError responds with the status matching a locker repository error.
+func lockerError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repository.ErrorNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, repository.ErrorInvalidLocker), errors.Is(err, repository.ErrorInvalidLockerCode):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, repository.ErrorLockerInUse), errors.Is(err, repository.ErrorNoLockerCode),
+		errors.Is(err, repository.ErrorLockerCodesTaken):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, repository.ErrorLockerLocked):
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// @Summary Get lockers by company
+// @Description Lists the parcel lockers of a company with their compartments
+// @Tags Locker
+// @Accept json
+// @Produce json
+// @Param id path string true "Company ID"
+// @Param limit query int false "limit"
+// @Param offset query int false "offset"
+// @Success 200 {object} []model.Locker
+// @Failure 400 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/locker/company/{id} [get]
+// @Security BearerAuth
+func (r *Router) GetLockersByCompanyID(c *gin.Context) {
+	limit, offset, err := extractPagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var lockers []model.Locker
+	err = r.repository.LockerRepository.GetLockersByCompanyID(c.Request.Context(), &lockers, c.Param(config.Id), limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, lockers)
+}
+
+// @Summary Get locker by ID
+// @Description Returns a parcel locker with its compartments and which packages they hold
+// @Tags Locker
+// @Accept json
+// @Produce json
+// @Param id path string true "Locker ID"
+// @Success 200 {object} model.Locker
+// @Failure 404 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/locker/{id} [get]
+// @Security BearerAuth
+func (r *Router) GetLockerByID(c *gin.Context) {
+	var locker model.Locker
+	err := r.repository.LockerRepository.GetLockerById(c.Request.Context(), &locker, c.Param(config.Id))
+	if err != nil {
+		lockerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, locker)
+}
+
+// @Summary Create locker
+// @Description Creates a parcel locker with the given number of compartments per size (small,
+// @Description medium, large). Packages not picked up in time are taken back to officeID.
+// @Tags Locker
+// @Accept json
+// @Produce json
+// @Param locker body model.LockerRequest true "Locker"
+// @Success 201 {object} model.Locker
+// @Failure 400 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/locker [post]
+// @Security BearerAuth
+func (r *Router) CreateLocker(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	if role != config.RoleAdmin {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var request model.LockerRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+	locker := model.Locker{
+		CompanyID: request.CompanyID,
+		Name:      request.Name,
+		Location:  request.Location,
+		Latitude:  request.Latitude,
+		Longitude: request.Longitude,
+		OfficeID:  request.OfficeID,
+	}
+	if locker.Latitude == nil {
+		locker.Latitude, locker.Longitude = r.geocode(c.Request.Context(), locker.Location)
+	}
+
+	err := r.repository.LockerRepository.CreateLocker(c.Request.Context(), &locker, request.Compartments)
+	if err != nil {
+		lockerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, locker)
+}
+
+// @Summary Delete locker
+// @Description Deletes a parcel locker, lockers with reserved compartments cannot be deleted
+// @Tags Locker
+// @Accept json
+// @Produce json
+// @Param id path string true "Locker ID"
+// @Success 204
+// @Failure 404 {object} gin.H
+// @Failure 409 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/locker/{id} [delete]
+// @Security BearerAuth
+func (r *Router) DeleteLocker(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	if role != config.RoleAdmin {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	err := r.repository.LockerRepository.DeleteLocker(c.Request.Context(), c.Param(config.Id))
+	if err != nil {
+		lockerError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Enter locker code
+// @Description Opens the compartment a code entered at the locker belongs to. The courier code drops
+// @Description the package off, or takes it back to the locker's office once the pickup expired. The
+// @Description pickup code hands the package over to the receiver. Every code works once.
+// @Description Couriers can only enter the courier codes of their packages and receivers the pickup codes
+// @Description of theirs, the staff of the locker's company can enter any code. After too many wrong codes
+// @Description in a row the locker takes no codes for a while.
+// @Tags Locker
+// @Accept json
+// @Produce json
+// @Param id path string true "Locker ID"
+// @Param code body model.LockerCodeRequest true "Code"
+// @Success 200 {object} model.LockerOpening
+// @Failure 400 {object} gin.H
+// @Failure 403 {object} gin.H
+// @Failure 404 {object} gin.H
+// @Failure 429 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/locker/{id}/open [post]
+// @Security BearerAuth
+func (r *Router) OpenLockerCompartment(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	contextID, _ := c.Get(config.Id)
+
+	var request model.LockerCodeRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	opener := repository.LockerOpener{}
+	switch role {
+	case config.RoleClient:
+		opener.ReceiverID = fmt.Sprint(contextID)
+	case config.RoleCourrier:
+		opener.CourrierID = fmt.Sprint(contextID)
+	case config.RoleAdmin:
+		opener.Staff = true
+	default:
+		var locker model.Locker
+		if err := r.repository.LockerRepository.GetLockerById(c.Request.Context(), &locker, c.Param(config.Id)); err != nil {
+			lockerError(c, err)
+			return
+		}
+		var employee model.Employee
+		err := r.repository.EmployeeRepository.GetEmployeeById(c.Request.Context(), &employee, fmt.Sprint(contextID))
+		if err != nil || employee.CompanyID == nil || *employee.CompanyID != locker.CompanyID {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+			return
+		}
+		opener.Staff = true
+	}
+
+	var opening model.LockerOpening
+	err := r.repository.LockerRepository.OpenCompartment(c.Request.Context(), &opening, c.Param(config.Id), request.Code, opener, r.lockers.Open)
+	if err != nil {
+		lockerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, opening)
+}
+
+// @Summary Get locker code
+// @Description Returns the locker, compartment and code of a locker delivery. The receiver gets the
+// @Description pickup code once the package is in the locker, the courier and the company staff get
+// @Description the courier code to drop the package off or take it back.
+// @Tags Package
+// @Accept json
+// @Produce json
+// @Param id path string true "Package ID"
+// @Success 200 {object} model.LockerAccess
+// @Failure 403 {object} gin.H
+// @Failure 409 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/package/{id}/locker [get]
+// @Security BearerAuth
+func (r *Router) GetLockerAccess(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	contextID, _ := c.Get(config.Id)
+
+	var packageModel model.Package
+	err := r.repository.PackageRepository.GetPackageById(c.Request.Context(), &packageModel, c.Param(config.Id))
+	if err != nil {
+		lockerError(c, err)
+		return
+	}
+	courrier := role != config.RoleClient
+	if (!courrier && !isReceiver(contextID, &packageModel)) || !r.canViewPackage(c, &packageModel) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var access model.LockerAccess
+	err = r.repository.LockerRepository.GetLockerAccess(c.Request.Context(), &access, &packageModel, courrier)
+	if err != nil {
+		lockerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, access)
+}
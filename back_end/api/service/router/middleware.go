@@ -0,0 +1,31 @@
+package router
+
+import (
+	"logistic_company/config"
+	"logistic_company/repository"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// requestIDMiddleware tags every request with an ID, reusing the one sent by
+// the caller if present, and attaches the client IP and request ID to the
+// request context so unauthenticated mutations are audited as well.
+func requestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(config.RequestIDHeader)
+		if requestID == "" {
+			requestID = uuid.New().String()
+		}
+		c.Set(config.RequestID, requestID)
+		c.Header(config.RequestIDHeader, requestID)
+
+		ctx := repository.WithAuditActor(c.Request.Context(), repository.AuditActor{
+			IP:        c.ClientIP(),
+			RequestID: requestID,
+		})
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
@@ -0,0 +1,479 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"logistic_company/config"
+	"logistic_company/model"
+	"logistic_company/repository"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func networkError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repository.ErrorNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, repository.ErrorInvalidLinehaul), errors.Is(err, repository.ErrorNoNetworkPath):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, repository.ErrorInvalidTransfer), errors.Is(err, repository.ErrorPackageDelivered):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// @Summary Get linehauls
+// @Description Get the linehaul connections between the offices and hubs of a company
+// @Tags Network
+// @Accept json
+// @Produce json
+// @Param id path string true "Company ID"
+// @Success 200 {object} []model.Linehaul
+// @Failure 500 {object} gin.H
+// @Router /api/v1/network/company/{id} [get]
+// @Security BearerAuth
+func (r *Router) GetLinehauls(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	if role != config.RoleAdmin && role != config.RoleEmployee {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var linehauls []model.Linehaul
+	err := r.repository.NetworkRepository.GetLinehaulsByCompanyID(c.Request.Context(), &linehauls, c.Param(config.Id))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, linehauls)
+}
+
+// @Summary Create linehaul
+// @Description Connects two offices of a company, packages travel from fromOfficeID to toOfficeID in
+// @Description transitHours. Linehauls are one way, a connection in both directions needs two.
+// @Tags Network
+// @Accept json
+// @Produce json
+// @Param linehaul body model.Linehaul true "Linehaul"
+// @Success 201 {object} model.Linehaul
+// @Failure 400 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/network/linehaul [post]
+// @Security BearerAuth
+func (r *Router) CreateLinehaul(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	if role != config.RoleAdmin {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	linehaul := model.Linehaul{Active: true}
+	if err := c.ShouldBindJSON(&linehaul); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	err := r.repository.NetworkRepository.CreateLinehaul(c.Request.Context(), &linehaul)
+	if err != nil {
+		networkError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, linehaul)
+}
+
+// @Summary Update linehaul
+// @Description Changes the transit time of a linehaul and whether it runs. Paths that were already
+// @Description planned keep using it.
+// @Tags Network
+// @Accept json
+// @Produce json
+// @Param id path string true "Linehaul ID"
+// @Param linehaul body model.Linehaul true "Linehaul"
+// @Success 200 {object} model.Linehaul
+// @Failure 400 {object} gin.H
+// @Failure 404 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/network/linehaul/{id} [patch]
+// @Security BearerAuth
+func (r *Router) UpdateLinehaul(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	if role != config.RoleAdmin {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var linehaul model.Linehaul
+	if err := c.ShouldBindJSON(&linehaul); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+	linehaul.ID = c.Param(config.Id)
+
+	err := r.repository.NetworkRepository.UpdateLinehaul(c.Request.Context(), &linehaul)
+	if err != nil {
+		networkError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, linehaul)
+}
+
+// @Summary Delete linehaul
+// @Description Delete linehaul
+// @Tags Network
+// @Accept json
+// @Produce json
+// @Param id path string true "Linehaul ID"
+// @Success 204
+// @Failure 404 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/network/linehaul/{id} [delete]
+// @Security BearerAuth
+func (r *Router) DeleteLinehaul(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	if role != config.RoleAdmin {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	err := r.repository.NetworkRepository.DeleteLinehaul(c.Request.Context(), c.Param(config.Id))
+	if err != nil {
+		networkError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Find network path
+// @Description Finds the fastest path of running linehauls between two offices of a company
+// @Tags Network
+// @Accept json
+// @Produce json
+// @Param id path string true "Company ID"
+// @Param from query string true "Office ID to start from"
+// @Param to query string true "Office ID to arrive at"
+// @Success 200 {object} model.NetworkPath
+// @Failure 400 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/network/company/{id}/path [get]
+// @Security BearerAuth
+func (r *Router) FindNetworkPath(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	if role != config.RoleAdmin && role != config.RoleEmployee {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+	from, to := c.Query("from"), c.Query("to")
+	if from == "" || to == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	var path model.NetworkPath
+	err := r.repository.NetworkRepository.FindPath(c.Request.Context(), &path, c.Param(config.Id), from, to)
+	if err != nil {
+		networkError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, path)
+}
+
+// @Summary Get package hops
+// @Description Get the planned path of a package between offices and how far along it is
+// @Tags Package
+// @Accept json
+// @Produce json
+// @Param id path string true "Package ID"
+// @Success 200 {object} []model.PackageHop
+// @Failure 500 {object} gin.H
+// @Router /api/v1/package/{id}/hops [get]
+// @Security BearerAuth
+func (r *Router) GetPackageHops(c *gin.Context) {
+	id := c.Param(config.Id)
+
+	var packageModel model.Package
+	err := r.repository.PackageRepository.GetPackageById(c.Request.Context(), &packageModel, id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !r.canViewPackage(c, &packageModel) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var hops []model.PackageHop
+	err = r.repository.NetworkRepository.GetPackageHops(c.Request.Context(), &hops, id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, hops)
+}
+
+// @Summary Plan package hops
+// @Description Plans the path of a package from where it is now to its destination office again,
+// @Description replacing the hops that have not started yet
+// @Tags Package
+// @Accept json
+// @Produce json
+// @Param id path string true "Package ID"
+// @Success 200 {object} []model.PackageHop
+// @Failure 400 {object} gin.H
+// @Failure 409 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/package/{id}/hops/plan [post]
+// @Security BearerAuth
+func (r *Router) PlanPackageHops(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	if role != config.RoleAdmin && role != config.RoleEmployee {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var hops []model.PackageHop
+	err := r.repository.NetworkRepository.PlanPackageHops(c.Request.Context(), &hops, c.Param(config.Id))
+	if err != nil {
+		networkError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, hops)
+}
+
+// transferOffice checks that the caller may scan a transfer batch at the
+// office, admins may scan anywhere and employees at their own office. It
+// writes the error response itself.
+func (r *Router) transferOffice(c *gin.Context, officeID string) bool {
+	role, _ := c.Get(config.Role)
+	contextID, _ := c.Get(config.Id)
+	if role == config.RoleAdmin {
+		return true
+	}
+	if role == config.RoleEmployee {
+		var employee model.Employee
+		err := r.repository.EmployeeRepository.GetEmployeeById(c.Request.Context(), &employee, fmt.Sprint(contextID))
+		if err == nil && employee.OfficeID != nil && *employee.OfficeID == officeID {
+			return true
+		}
+	}
+	c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+	return false
+}
+
+// @Summary Create transfer batch
+// @Description Opens a transfer batch for packages moving along the linehaul between two offices
+// @Tags Transfer
+// @Accept json
+// @Produce json
+// @Param batch body model.TransferBatchRequest true "Transfer batch"
+// @Success 201 {object} model.TransferBatch
+// @Failure 400 {object} gin.H
+// @Failure 409 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/transfer [post]
+// @Security BearerAuth
+func (r *Router) CreateTransferBatch(c *gin.Context) {
+	contextID, _ := c.Get(config.Id)
+
+	var request model.TransferBatchRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+	if !r.transferOffice(c, request.FromOfficeID) {
+		return
+	}
+
+	var office model.Office
+	err := r.repository.OfficeRepository.GetOfficeById(c.Request.Context(), &office, request.FromOfficeID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	batch := model.TransferBatch{
+		CompanyID:    office.CompanyID,
+		FromOfficeID: request.FromOfficeID,
+		ToOfficeID:   request.ToOfficeID,
+		CreatedByID:  fmt.Sprint(contextID),
+	}
+	err = r.repository.NetworkRepository.CreateTransferBatch(c.Request.Context(), &batch)
+	if err != nil {
+		networkError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, batch)
+}
+
+// @Summary Get transfer batch
+// @Description Get a transfer batch with the hops of its packages, which is its manifest
+// @Tags Transfer
+// @Accept json
+// @Produce json
+// @Param id path string true "Transfer batch ID"
+// @Success 200 {object} model.TransferBatch
+// @Failure 404 {object} gin.H
+// @Router /api/v1/transfer/{id} [get]
+// @Security BearerAuth
+func (r *Router) GetTransferBatchByID(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	if role != config.RoleAdmin && role != config.RoleEmployee {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var batch model.TransferBatch
+	err := r.repository.NetworkRepository.GetTransferBatchByID(c.Request.Context(), &batch, c.Param(config.Id))
+	if err != nil {
+		networkError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, batch)
+}
+
+// @Summary Get transfer batches by office
+// @Description Get the batches leaving an office, or arriving at it with incoming=true
+// @Tags Transfer
+// @Accept json
+// @Produce json
+// @Param id path string true "Office ID"
+// @Param incoming query bool false "List the batches arriving at the office"
+// @Param status query string false "open, departed or arrived"
+// @Param limit query int false "limit"
+// @Param offset query int false "offset"
+// @Success 200 {object} []model.TransferBatch
+// @Failure 400 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/transfer/office/{id} [get]
+// @Security BearerAuth
+func (r *Router) GetTransferBatchesByOfficeID(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	if role != config.RoleAdmin && role != config.RoleEmployee {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+	limit, offset, err := extractPagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var batches []model.TransferBatch
+	err = r.repository.NetworkRepository.GetTransferBatchesByOfficeID(c.Request.Context(), &batches, c.Param(config.Id),
+		c.Query("incoming") == "true", c.Query("status"), limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, batches)
+}
+
+// loadTransferBatch loads the batch in the path. It writes the error
+// response itself.
+func (r *Router) loadTransferBatch(c *gin.Context, batch *model.TransferBatch) bool {
+	err := r.repository.NetworkRepository.GetTransferBatchByID(c.Request.Context(), batch, c.Param(config.Id))
+	if err != nil {
+		networkError(c, err)
+		return false
+	}
+	return true
+}
+
+// @Summary Add packages to transfer batch
+// @Description Puts packages on an open batch by their tracking numbers or IDs. Every package has to
+// @Description be routed along the batch's linehaul.
+// @Tags Transfer
+// @Accept json
+// @Produce json
+// @Param id path string true "Transfer batch ID"
+// @Param packages body model.TransferPackagesRequest true "Packages"
+// @Success 200 {object} model.TransferBatch
+// @Failure 400 {object} gin.H
+// @Failure 409 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/transfer/{id}/packages [post]
+// @Security BearerAuth
+func (r *Router) AddTransferPackages(c *gin.Context) {
+	var batch model.TransferBatch
+	if !r.loadTransferBatch(c, &batch) || !r.transferOffice(c, batch.FromOfficeID) {
+		return
+	}
+
+	var request model.TransferPackagesRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	err := r.repository.NetworkRepository.AddTransferPackages(c.Request.Context(), &batch, batch.ID, request.TrackingCodes)
+	if err != nil {
+		networkError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, batch)
+}
+
+// @Summary Scan transfer batch departure
+// @Description Records that a batch left its office, its packages are in transit
+// @Tags Transfer
+// @Accept json
+// @Produce json
+// @Param id path string true "Transfer batch ID"
+// @Success 200 {object} model.TransferBatch
+// @Failure 409 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/transfer/{id}/depart [post]
+// @Security BearerAuth
+func (r *Router) DepartTransferBatch(c *gin.Context) {
+	var batch model.TransferBatch
+	if !r.loadTransferBatch(c, &batch) || !r.transferOffice(c, batch.FromOfficeID) {
+		return
+	}
+
+	err := r.repository.NetworkRepository.DepartTransferBatch(c.Request.Context(), &batch, batch.ID)
+	if err != nil {
+		networkError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, batch)
+}
+
+// @Summary Scan transfer batch arrival
+// @Description Records that a batch arrived at its office. Packages at the end of their path have
+// @Description arrived at their destination office.
+// @Tags Transfer
+// @Accept json
+// @Produce json
+// @Param id path string true "Transfer batch ID"
+// @Success 200 {object} model.TransferBatch
+// @Failure 409 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/transfer/{id}/arrive [post]
+// @Security BearerAuth
+func (r *Router) ArriveTransferBatch(c *gin.Context) {
+	var batch model.TransferBatch
+	if !r.loadTransferBatch(c, &batch) || !r.transferOffice(c, batch.ToOfficeID) {
+		return
+	}
+
+	err := r.repository.NetworkRepository.ArriveTransferBatch(c.Request.Context(), &batch, batch.ID)
+	if err != nil {
+		networkError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, batch)
+}
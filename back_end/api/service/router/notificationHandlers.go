@@ -0,0 +1,157 @@
+package router
+
+import (
+	"errors"
+	"logistic_company/config"
+	"logistic_company/model"
+	"logistic_company/repository"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Get client notifications
+// @Description Lists the notifications sent or queued for a client, newest first
+// @Tags Client
+// @Accept json
+// @Produce json
+// @Param id path string true "Client ID"
+// @Param limit query int false "limit"
+// @Param offset query int false "offset"
+// @Success 200 {object} []model.Notification
+// @Failure 400 {object} gin.H
+// @Failure 403 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/client/{id}/notifications [get]
+// @Security BearerAuth
+func (r *Router) GetClientNotifications(c *gin.Context) {
+	if !canManageClient(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+	limit, offset, err := extractPagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var notifications []model.Notification
+	err = r.repository.NotificationRepository.GetNotificationsByClientID(c.Request.Context(), &notifications, c.Param(config.Id), limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, notifications)
+}
+
+// @Summary Get notification preferences
+// @Description Returns the channels a client is notified on, clients who never chose get emails only
+// @Tags Client
+// @Accept json
+// @Produce json
+// @Param id path string true "Client ID"
+// @Success 200 {object} model.NotificationPreference
+// @Failure 403 {object} gin.H
+// @Failure 404 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/client/{id}/notification-preferences [get]
+// @Security BearerAuth
+func (r *Router) GetNotificationPreference(c *gin.Context) {
+	if !canManageClient(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var preference model.NotificationPreference
+	err := r.repository.NotificationRepository.GetPreference(c.Request.Context(), &preference, c.Param(config.Id))
+	if err != nil {
+		if errors.Is(err, repository.ErrorNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, preference)
+}
+
+// @Summary Set notification preferences
+// @Description Chooses whether a client is notified by email, by text message, both or not at all
+// @Tags Client
+// @Accept json
+// @Produce json
+// @Param id path string true "Client ID"
+// @Param preference body model.NotificationPreference true "Preference"
+// @Success 200 {object} model.NotificationPreference
+// @Failure 400 {object} gin.H
+// @Failure 403 {object} gin.H
+// @Failure 404 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/client/{id}/notification-preferences [put]
+// @Security BearerAuth
+func (r *Router) SetNotificationPreference(c *gin.Context) {
+	if !canManageClient(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var preference model.NotificationPreference
+	if err := c.ShouldBindJSON(&preference); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+	preference.ClientID = c.Param(config.Id)
+
+	err := r.repository.NotificationRepository.SetPreference(c.Request.Context(), &preference)
+	if err != nil {
+		if errors.Is(err, repository.ErrorNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, preference)
+}
+
+// @Summary Get package notifications
+// @Description Lists the notifications about a package with whether they were sent
+// @Tags Package
+// @Accept json
+// @Produce json
+// @Param id path string true "Package ID"
+// @Success 200 {object} []model.Notification
+// @Failure 403 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/package/{id}/notifications [get]
+// @Security BearerAuth
+func (r *Router) GetPackageNotifications(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	if role != config.RoleAdmin && role != config.RoleEmployee {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var packageModel model.Package
+	err := r.repository.PackageRepository.GetPackageById(c.Request.Context(), &packageModel, c.Param(config.Id))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !r.canViewPackage(c, &packageModel) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var notifications []model.Notification
+	err = r.repository.NotificationRepository.GetNotificationsByPackageID(c.Request.Context(), &notifications, packageModel.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, notifications)
+}
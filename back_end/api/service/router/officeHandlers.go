@@ -2,14 +2,53 @@ package router
 
 import (
 	"encoding/json"
+	"errors"
 	"io"
+	"logistic_company/api/service/geo"
 	"logistic_company/config"
 	"logistic_company/model"
+	"logistic_company/repository"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+// validateOffice checks the package limits, capacity and opening hours of an
+// office.
+func validateOffice(office *model.Office) string {
+	if office.MaxWeight < 0 || office.MaxDimension < 0 || office.Capacity < 0 {
+		return "Limits cannot be negative"
+	}
+	for _, code := range office.RejectedPackageTypes {
+		if !repository.IsPackageTypeCode(code) {
+			return "Unknown package type " + code
+		}
+	}
+	if !repository.ValidOpeningHours(office.OpeningHours) {
+		return "Invalid opening hours"
+	}
+	return ""
+}
+
+// officeAddress is what an office is geocoded by, its street address when it
+// has one and its location otherwise.
+func officeAddress(office *model.Office) string {
+	if office.Street == "" {
+		return office.Location
+	}
+	parts := []string{office.Street}
+	for _, part := range []string{strings.TrimSpace(office.PostalCode + " " + office.City), office.Country} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
 // @Summary Get all offices
 // @Description Get all offices
 // @Tags Office
@@ -92,6 +131,13 @@ func (r *Router) CreateOffice(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
 		return
 	}
+	if message := validateOffice(&office); message != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": message})
+		return
+	}
+	if office.Latitude == nil {
+		office.Latitude, office.Longitude = r.geocode(c.Request.Context(), officeAddress(&office))
+	}
 
 	err := r.repository.OfficeRepository.CreateOffice(c.Request.Context(), &office)
 	if err != nil {
@@ -137,7 +183,15 @@ func (r *Router) UpdateOffice(c *gin.Context) {
 		return
 	}
 
+	if message := validateOffice(&office); message != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": message})
+		return
+	}
+
 	office.ID = id
+	if (office.Location != "" || office.Street != "") && office.Latitude == nil {
+		office.Latitude, office.Longitude = r.geocode(c.Request.Context(), officeAddress(&office))
+	}
 	err = r.repository.OfficeRepository.UpdateOffice(c.Request.Context(), &office)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -237,3 +291,174 @@ func (r *Router) GetOfficesByCompanyID(c *gin.Context) {
 
 	c.JSON(http.StatusOK, offices)
 }
+
+// @Summary Get nearest offices
+// @Description Get the offices open to clients closest to a point, given by latitude and longitude
+// @Description or by a location to geocode. Hubs and permanently closed offices are left out.
+// @Tags Office
+// @Accept json
+// @Produce json
+// @Param latitude query number false "Latitude"
+// @Param longitude query number false "Longitude"
+// @Param location query string false "Address to search around"
+// @Param companyID query string false "Only offices of this company"
+// @Param limit query int false "Number of offices, 5 by default"
+// @Success 200 {object} []model.NearbyOffice
+// @Failure 400 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/office/nearest [get]
+// @Security BearerAuth
+func (r *Router) GetNearestOffices(c *gin.Context) {
+	var point geo.Point
+	latitude, latitudeErr := strconv.ParseFloat(c.Query("latitude"), 64)
+	longitude, longitudeErr := strconv.ParseFloat(c.Query("longitude"), 64)
+	switch {
+	case latitudeErr == nil && longitudeErr == nil:
+		point = geo.Point{Latitude: latitude, Longitude: longitude}
+	case c.Query("location") != "":
+		latitude, longitude := r.geocode(c.Request.Context(), c.Query("location"))
+		if latitude == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown location"})
+			return
+		}
+		point = geo.Point{Latitude: *latitude, Longitude: *longitude}
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Latitude and longitude or location are required"})
+		return
+	}
+	limit := config.DefaultNearestOffices
+	if value := c.Query("limit"); value != "" {
+		var err error
+		if limit, err = strconv.Atoi(value); err != nil || limit < 1 || limit > config.MaxNearestOffices {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+			return
+		}
+	}
+
+	var offices []model.Office
+	err := r.repository.OfficeRepository.GetNearbyOfficeCandidates(c.Request.Context(), &offices, c.Query("companyID"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	nearby := make([]model.NearbyOffice, len(offices))
+	for i, office := range offices {
+		nearby[i] = model.NearbyOffice{
+			Office:     office,
+			DistanceKm: geo.Distance(point, geo.Point{Latitude: *office.Latitude, Longitude: *office.Longitude}),
+		}
+	}
+	sort.Slice(nearby, func(i, j int) bool {
+		return nearby[i].DistanceKm < nearby[j].DistanceKm
+	})
+	if len(nearby) > limit {
+		nearby = nearby[:limit]
+	}
+
+	c.JSON(http.StatusOK, nearby)
+}
+
+// @Summary Get office holidays
+// @Description Get the days an office is closed
+// @Tags Office
+// @Accept json
+// @Produce json
+// @Param id path string true "Office ID"
+// @Success 200 {object} []model.OfficeHoliday
+// @Failure 500 {object} gin.H
+// @Router /api/v1/office/{id}/holidays [get]
+// @Security BearerAuth
+func (r *Router) GetOfficeHolidays(c *gin.Context) {
+	var holidays []model.OfficeHoliday
+	err := r.repository.OfficeRepository.GetOfficeHolidays(c.Request.Context(), &holidays, c.Param(config.Id))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, holidays)
+}
+
+// @Summary Create office holiday
+// @Description Closes an office on a date (YYYY-MM-DD)
+// @Tags Office
+// @Accept json
+// @Produce json
+// @Param id path string true "Office ID"
+// @Param holiday body model.OfficeHolidayRequest true "Holiday"
+// @Success 201 {object} model.OfficeHoliday
+// @Failure 400 {object} gin.H
+// @Failure 409 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/office/{id}/holidays [post]
+// @Security BearerAuth
+func (r *Router) CreateOfficeHoliday(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	if role != config.RoleAdmin {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var request model.OfficeHolidayRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+	date, err := time.ParseInLocation(config.DateFormat, request.Date, time.Local)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date"})
+		return
+	}
+
+	var office model.Office
+	if err := r.repository.OfficeRepository.GetOfficeById(c.Request.Context(), &office, c.Param(config.Id)); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	holiday := model.OfficeHoliday{OfficeID: office.ID, Date: date, Name: request.Name}
+	err = r.repository.OfficeRepository.CreateOfficeHoliday(c.Request.Context(), &holiday)
+	if errors.Is(err, repository.ErrorHolidayExists) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, holiday)
+}
+
+// @Summary Delete office holiday
+// @Description Delete office holiday
+// @Tags Office
+// @Accept json
+// @Produce json
+// @Param id path string true "Office ID"
+// @Param holidayId path string true "Holiday ID"
+// @Success 204
+// @Failure 404 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/office/{id}/holidays/{holidayId} [delete]
+// @Security BearerAuth
+func (r *Router) DeleteOfficeHoliday(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	if role != config.RoleAdmin {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	err := r.repository.OfficeRepository.DeleteOfficeHoliday(c.Request.Context(), c.Param(config.Id), c.Param("holidayId"))
+	if errors.Is(err, repository.ErrorNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
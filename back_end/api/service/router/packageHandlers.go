@@ -2,9 +2,11 @@ package router
 
 import (
 	"encoding/json"
+	"errors"
 	"io"
 	"logistic_company/config"
 	"logistic_company/model"
+	"logistic_company/repository"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -211,19 +213,28 @@ func (r *Router) GetPackageByID(c *gin.Context) {
 }
 
 // @Summary Create package
-// @Description Create package
+// @Description Create package. When courrierID is omitted the courier is picked by the assignment strategy of the company,
+// @Description a given courrierID has to be a courier of the company.
+// @Description The price is computed from the chargeable weight, the larger of the actual and the volumetric weight,
+// @Description and the package type. Packages over the limits of their type or of the offices involved are refused.
+// @Description Packages with a lockerID are delivered to that locker and get a compartment reserved. Address
+// @Description deliveries go to deliveryAddressID from the receiver's address book, or to the receiver's default
+// @Description address when neither it nor deliveryLocation is given. Receivers without an account are given by
+// @Description receiverName and receiverPhone or receiverEmail instead of receiverID.
 // @Tags Package
 // @Accept json
 // @Produce json
 // @Param package body model.Package true "Package"
 // @Success 200 {object} model.Package
 // @Failure 400 {object} gin.H
+// @Failure 409 {object} gin.H
 // @Failure 500 {object} gin.H
 // @Router /api/v1/package [post]
 // @Security BearerAuth
 func (r *Router) CreatePackage(c *gin.Context) {
 	var packageModel model.Package
 	role, _ := c.Get(config.Role)
+	contextID, _ := c.Get(config.Id)
 
 	if role != config.RoleAdmin && role != config.RoleEmployee {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
@@ -235,20 +246,48 @@ func (r *Router) CreatePackage(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
 		return
 	}
+	registeredByID := contextID.(string)
+	packageModel.RegisteredByID = &registeredByID
+
+	if packageModel.ReceiverID == nil && (packageModel.ReceiverName == "" || (packageModel.ReceiverPhone == "" && packageModel.ReceiverEmail == "")) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A receiver ID or the receiver's name and phone or email is required"})
+		return
+	}
+	if packageModel.IsDeliveredToOffice && packageModel.OfficeDeliveredAtID == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Office ID is required"})
+		return
+	}
+	if packageModel.Weight <= 0 || packageModel.Length < 0 || packageModel.Width < 0 || packageModel.Height < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid weight or dimensions"})
+		return
+	}
 
-	if packageModel.IsDeliveredToOffice {
-		if packageModel.OfficeDeliveredAtID == "" {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Office ID is required"})
-			return
-		}
-		packageModel.Price = config.DeliveryToOfficePricePerKillogram
-	} else {
-		packageModel.Price = config.DeliveryToAddressPricePerKillogram
+	if packageModel.CODAmount < 0 || (packageModel.CODCurrency != "" && len(packageModel.CODCurrency) != 3) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid cash on delivery amount"})
+		return
+	}
+	if packageModel.DeclaredValue < 0 || (packageModel.Insured && packageModel.DeclaredValue == 0) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Insured packages need a declared value"})
+		return
 	}
 
-	packageModel.Price *= packageModel.Weight
+	if !packageModel.IsDeliveredToOffice && packageModel.LockerID == nil && packageModel.DeliveryLocation != nil && packageModel.DeliveryLatitude == nil {
+		packageModel.DeliveryLatitude, packageModel.DeliveryLongitude = r.geocode(c.Request.Context(), *packageModel.DeliveryLocation)
+	}
 
 	err := r.repository.PackageRepository.CreatePackage(c.Request.Context(), &packageModel)
+	if errors.Is(err, repository.ErrorNoCourriersAvailable) || errors.Is(err, repository.ErrorNoCompartmentAvailable) ||
+		errors.Is(err, repository.ErrorLockerCodesTaken) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, repository.ErrorPackageRejected) || errors.Is(err, repository.ErrorInvalidPackageType) ||
+		errors.Is(err, repository.ErrorInvalidLocker) || errors.Is(err, repository.ErrorDoesNotFitLocker) ||
+		errors.Is(err, repository.ErrorInvalidAddress) || errors.Is(err, repository.ErrorInvalidReceiver) ||
+		errors.Is(err, repository.ErrorInvalidCourrier) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -258,7 +297,8 @@ func (r *Router) CreatePackage(c *gin.Context) {
 }
 
 // @Summary Update package
-// @Description Update package
+// @Description Update package. Prices and fees are calculated, changing the size, package type, offices, cash on
+// @Description delivery or insurance of an open package prices it again and checks the package type and office limits.
 // @Tags Package
 // @Accept json
 // @Produce json
@@ -266,6 +306,7 @@ func (r *Router) CreatePackage(c *gin.Context) {
 // @Param package body model.Package true "Package"
 // @Success 200 {object} model.Package
 // @Failure 400 {object} gin.H
+// @Failure 409 {object} gin.H
 // @Failure 500 {object} gin.H
 // @Router /api/v1/package/{id} [patch]
 // @Security BearerAuth
@@ -290,7 +331,19 @@ func (r *Router) UpdatePackage(c *gin.Context) {
 		return
 	}
 	packageModel.ID = c.Param(config.Id)
+	if packageModel.DeliveryLocation != nil && packageModel.DeliveryLatitude == nil {
+		packageModel.DeliveryLatitude, packageModel.DeliveryLongitude = r.geocode(c.Request.Context(), *packageModel.DeliveryLocation)
+	}
 	err = r.repository.PackageRepository.UpdatePackage(c.Request.Context(), &packageModel)
+	if errors.Is(err, repository.ErrorInvalidAddress) || errors.Is(err, repository.ErrorInvalidCourrier) ||
+		errors.Is(err, repository.ErrorPackageRejected) || errors.Is(err, repository.ErrorInvalidPackageType) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, repository.ErrorPackageDelivered) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
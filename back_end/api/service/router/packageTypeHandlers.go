@@ -0,0 +1,162 @@
+package router
+
+import (
+	"errors"
+	"logistic_company/config"
+	"logistic_company/model"
+	"logistic_company/repository"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func validatePackageType(packageType *model.PackageType) string {
+	switch {
+	case packageType.Code != "" && !repository.IsPackageTypeCode(packageType.Code):
+		return "Unknown package type, use document, parcel, pallet or fragile"
+	case packageType.MaxWeight < 0 || packageType.MaxLength < 0 || packageType.MaxWidth < 0 || packageType.MaxHeight < 0:
+		return "Limits cannot be negative"
+	case packageType.PriceMultiplier < 0 || packageType.Surcharge < 0:
+		return "Rates and prices cannot be negative"
+	}
+	return ""
+}
+
+// @Summary Get package types
+// @Description Get the package type catalogue of a company
+// @Tags Company
+// @Accept json
+// @Produce json
+// @Param id path string true "Company ID"
+// @Success 200 {object} []model.PackageType
+// @Failure 400 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/company/{id}/package-types [get]
+// @Security BearerAuth
+func (r *Router) GetPackageTypes(c *gin.Context) {
+	var packageTypes []model.PackageType
+
+	err := r.repository.CompanyRepository.GetPackageTypes(c.Request.Context(), &packageTypes, c.Param(config.Id))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, packageTypes)
+}
+
+// @Summary Create package type
+// @Description Adds a package type (document, parcel, pallet or fragile) to the catalogue of a company
+// @Tags Company
+// @Accept json
+// @Produce json
+// @Param id path string true "Company ID"
+// @Param packageType body model.PackageType true "Package type"
+// @Success 201 {object} model.PackageType
+// @Failure 400 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/company/{id}/package-types [post]
+// @Security BearerAuth
+func (r *Router) CreatePackageType(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	if role != config.RoleAdmin {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var packageType model.PackageType
+	if err := c.ShouldBindJSON(&packageType); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+	if message := validatePackageType(&packageType); message != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": message})
+		return
+	}
+	packageType.CompanyID = c.Param(config.Id)
+	if packageType.PriceMultiplier == 0 {
+		packageType.PriceMultiplier = 1
+	}
+
+	err := r.repository.CompanyRepository.CreatePackageType(c.Request.Context(), &packageType)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, packageType)
+}
+
+// @Summary Update package type
+// @Description Update the limits and pricing of a package type
+// @Tags Company
+// @Accept json
+// @Produce json
+// @Param id path string true "Company ID"
+// @Param typeId path string true "Package type ID"
+// @Param packageType body model.PackageType true "Package type"
+// @Success 200 {object} model.PackageType
+// @Failure 400 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/company/{id}/package-types/{typeId} [patch]
+// @Security BearerAuth
+func (r *Router) UpdatePackageType(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	if role != config.RoleAdmin {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var packageType model.PackageType
+	if err := c.ShouldBindJSON(&packageType); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+	if message := validatePackageType(&packageType); message != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": message})
+		return
+	}
+	packageType.ID = c.Param("typeId")
+	packageType.CompanyID = c.Param(config.Id)
+
+	err := r.repository.CompanyRepository.UpdatePackageType(c.Request.Context(), &packageType)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, packageType)
+}
+
+// @Summary Delete package type
+// @Description Removes a package type no package was registered with from the catalogue
+// @Tags Company
+// @Accept json
+// @Produce json
+// @Param id path string true "Company ID"
+// @Param typeId path string true "Package type ID"
+// @Success 200 {object} gin.H
+// @Failure 400 {object} gin.H
+// @Failure 409 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/company/{id}/package-types/{typeId} [delete]
+// @Security BearerAuth
+func (r *Router) DeletePackageType(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	if role != config.RoleAdmin {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	err := r.repository.CompanyRepository.DeletePackageType(c.Request.Context(), c.Param(config.Id), c.Param("typeId"))
+	if errors.Is(err, repository.ErrorInvalidPackageType) {
+		c.JSON(http.StatusConflict, gin.H{"error": "Package type is used by packages"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Package type deleted successfully"})
+}
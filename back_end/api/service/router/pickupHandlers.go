@@ -0,0 +1,118 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"logistic_company/config"
+	"logistic_company/model"
+	"logistic_company/repository"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Get pickup details
+// @Description Returns the pickup PIN, the office, the storage deadline and the storage fee so far
+// @Description of an office delivery waiting to be picked up. Only the receiver can see them.
+// @Tags Package
+// @Accept json
+// @Produce json
+// @Param id path string true "Package ID"
+// @Success 200 {object} model.PickupInfo
+// @Failure 403 {object} gin.H
+// @Failure 409 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/package/{id}/pickup [get]
+// @Security BearerAuth
+func (r *Router) GetPickupInfo(c *gin.Context) {
+	contextID, _ := c.Get(config.Id)
+
+	var packageModel model.Package
+	err := r.repository.PackageRepository.GetPackageById(c.Request.Context(), &packageModel, c.Param(config.Id))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !isReceiver(contextID, &packageModel) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var info model.PickupInfo
+	err = r.repository.PackageRepository.GetPickupInfo(c.Request.Context(), &info, &packageModel)
+	if err != nil {
+		if errors.Is(err, repository.ErrorNotReadyForPickup) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, info)
+}
+
+// @Summary Pick up package
+// @Description Hands an office delivery over to its receiver at the pickup office. The receiver is
+// @Description verified by the pickup PIN, or by the staff checking their identity document
+// @Description (identityChecked) and entering the name on it (recipientName). Storage fees are
+// @Description charged up to the pickup. Employees can only hand over packages at their own office.
+// @Tags Package
+// @Accept json
+// @Produce json
+// @Param id path string true "Package ID"
+// @Param pickup body model.PickupRequest true "Pickup"
+// @Success 200 {object} model.Package
+// @Failure 400 {object} gin.H
+// @Failure 403 {object} gin.H
+// @Failure 409 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/package/{id}/pickup [post]
+// @Security BearerAuth
+func (r *Router) PickUpPackage(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	contextID, _ := c.Get(config.Id)
+	if role != config.RoleAdmin && role != config.RoleEmployee {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	officeID := ""
+	if role == config.RoleEmployee {
+		var employee model.Employee
+		err := r.repository.EmployeeRepository.GetEmployeeById(c.Request.Context(), &employee, fmt.Sprint(contextID))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if employee.OfficeID == nil {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+			return
+		}
+		officeID = *employee.OfficeID
+	}
+
+	var request model.PickupRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	var packageModel model.Package
+	err := r.repository.PackageRepository.PickUp(c.Request.Context(), &packageModel, c.Param(config.Id), officeID, request)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrorNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, repository.ErrorInvalidPickup):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, repository.ErrorNotReadyForPickup), errors.Is(err, repository.ErrorPackageDelivered):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, packageModel)
+}
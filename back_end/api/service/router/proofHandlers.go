@@ -0,0 +1,318 @@
+package router
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"logistic_company/config"
+	"logistic_company/model"
+	"logistic_company/repository"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// canViewPackage allows the sender, the receiver and the staff of the
+// package's company to see a package's details.
+func (r *Router) canViewPackage(c *gin.Context, packageModel *model.Package) bool {
+	role, _ := c.Get(config.Role)
+	contextID, _ := c.Get(config.Id)
+	if role == config.RoleClient {
+		return contextID == packageModel.SenderID || isReceiver(contextID, packageModel)
+	}
+	return r.isCompanyStaff(c, packageModel.CompanyID)
+}
+
+// isCompanyStaff reports whether the user works for the company. Admins
+// without a company can access every company.
+func (r *Router) isCompanyStaff(c *gin.Context, companyID string) bool {
+	role, _ := c.Get(config.Role)
+	contextID, _ := c.Get(config.Id)
+
+	var employee model.Employee
+	err := r.repository.EmployeeRepository.GetEmployeeById(c.Request.Context(), &employee, fmt.Sprint(contextID))
+	if err != nil || employee.CompanyID == nil {
+		return role == config.RoleAdmin
+	}
+	return *employee.CompanyID == companyID
+}
+
+// isReceiver reports whether the user is the registered receiver of a
+// package.
+func isReceiver(contextID any, packageModel *model.Package) bool {
+	return packageModel.ReceiverID != nil && contextID == *packageModel.ReceiverID
+}
+
+// isCourrier reports whether the user is the courrier assigned to the
+// package.
+func isCourrier(contextID any, packageModel *model.Package) bool {
+	return packageModel.CourrierID != nil && contextID == *packageModel.CourrierID
+}
+
+// storeImage saves an uploaded image in blob storage and returns its content
+// type.
+func (r *Router) storeImage(ctx context.Context, key string, header *multipart.FileHeader) (string, error) {
+	return r.storeUpload(ctx, key, header, "image/")
+}
+
+// storeUpload saves an uploaded file in blob storage and returns its content
+// type. Files that are too large or whose sniffed content type does not start
+// with one of the allowed prefixes are rejected.
+func (r *Router) storeUpload(ctx context.Context, key string, header *multipart.FileHeader, allowed ...string) (string, error) {
+	if r.cfg.MaxUploadBytes > 0 && header.Size > r.cfg.MaxUploadBytes {
+		return "", fmt.Errorf("%s is larger than %d bytes", header.Filename, r.cfg.MaxUploadBytes)
+	}
+	file, err := header.Open()
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	sniff := make([]byte, 512)
+	n, err := io.ReadFull(file, sniff)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return "", err
+	}
+	contentType := http.DetectContentType(sniff[:n])
+	accepted := false
+	for _, prefix := range allowed {
+		accepted = accepted || strings.HasPrefix(contentType, prefix)
+	}
+	if !accepted {
+		return "", fmt.Errorf("%s has an unsupported file type %s", header.Filename, contentType)
+	}
+
+	return contentType, r.storage.Put(ctx, key, io.MultiReader(bytes.NewReader(sniff[:n]), file))
+}
+
+func parseCoordinate(value string) (*float64, error) {
+	if value == "" {
+		return nil, nil
+	}
+	coordinate, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &coordinate, nil
+}
+
+// @Summary Record proof of delivery
+// @Description Stores the recipient name, signature and optional photo taken by the courrier
+// @Description at handover and marks the package as delivered. Office and locker deliveries are handed over
+// @Description with their pickup PIN or locker code instead.
+// @Tags Package
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Package ID"
+// @Param recipientName formData string true "Name of the person who received the package"
+// @Param signature formData file true "Signature image"
+// @Param photo formData file false "Photo of the delivered package"
+// @Param latitude formData number false "Latitude of the handover"
+// @Param longitude formData number false "Longitude of the handover"
+// @Success 201 {object} model.ProofOfDelivery
+// @Failure 400 {object} gin.H
+// @Failure 409 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/package/{id}/proof [post]
+// @Security BearerAuth
+func (r *Router) CreateProofOfDelivery(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	contextID, _ := c.Get(config.Id)
+	id := c.Param(config.Id)
+
+	var packageModel model.Package
+	err := r.repository.PackageRepository.GetPackageById(c.Request.Context(), &packageModel, id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if role != config.RoleCourrier || !isCourrier(contextID, &packageModel) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+	if repository.IsPackageClosed(&packageModel) {
+		c.JSON(http.StatusConflict, gin.H{"error": repository.ErrorPackageDelivered.Error()})
+		return
+	}
+	if packageModel.IsDeliveredToOffice || packageModel.LockerID != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": repository.ErrorNotAddressDelivery.Error()})
+		return
+	}
+
+	recipientName := strings.TrimSpace(c.PostForm("recipientName"))
+	signature, err := c.FormFile("signature")
+	if recipientName == "" || err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+	latitude, err := parseCoordinate(c.PostForm("latitude"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+	longitude, err := parseCoordinate(c.PostForm("longitude"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	// Blob keys are unique per upload so a rejected proof never overwrites
+	// the files of an accepted one
+	prefix := "proof/" + id + "/" + uuid.New().String()
+	proof := model.ProofOfDelivery{
+		PackageID:     id,
+		CourrierID:    *packageModel.CourrierID,
+		RecipientName: recipientName,
+		SignatureKey:  prefix + "-signature",
+		Latitude:      latitude,
+		Longitude:     longitude,
+	}
+	ctx := c.Request.Context()
+
+	proof.SignatureContentType, err = r.storeImage(ctx, proof.SignatureKey, signature)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if photo, err := c.FormFile("photo"); err == nil {
+		photoKey := prefix + "-photo"
+		proof.PhotoKey = &photoKey
+		proof.PhotoContentType, err = r.storeImage(ctx, photoKey, photo)
+		if err != nil {
+			r.deleteBlobs(ctx, proof.SignatureKey, photoKey)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	err = r.repository.PackageRepository.CreateProofOfDelivery(ctx, &proof)
+	if err != nil {
+		keys := []string{proof.SignatureKey}
+		if proof.PhotoKey != nil {
+			keys = append(keys, *proof.PhotoKey)
+		}
+		r.deleteBlobs(ctx, keys...)
+		if errors.Is(err, repository.ErrorPackageDelivered) || errors.Is(err, repository.ErrorNotAddressDelivery) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	proof.HasPhoto = proof.PhotoKey != nil
+
+	c.JSON(http.StatusCreated, proof)
+}
+
+func (r *Router) deleteBlobs(ctx context.Context, keys ...string) {
+	for _, key := range keys {
+		if err := r.storage.Delete(ctx, key); err != nil {
+			log.Warnf("Could not delete blob %s, %s", key, err)
+		}
+	}
+}
+
+// loadProof loads the proof of delivery of the package in the path after
+// checking the caller may see it. It writes the error response itself.
+func (r *Router) loadProof(c *gin.Context, proof *model.ProofOfDelivery) bool {
+	id := c.Param(config.Id)
+
+	var packageModel model.Package
+	err := r.repository.PackageRepository.GetPackageById(c.Request.Context(), &packageModel, id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return false
+	}
+	if !r.canViewPackage(c, &packageModel) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return false
+	}
+
+	err = r.repository.PackageRepository.GetProofOfDelivery(c.Request.Context(), proof, id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return false
+	}
+	return true
+}
+
+// @Summary Get proof of delivery
+// @Description Get the proof of delivery of a package
+// @Tags Package
+// @Accept json
+// @Produce json
+// @Param id path string true "Package ID"
+// @Success 200 {object} model.ProofOfDelivery
+// @Failure 400 {object} gin.H
+// @Failure 404 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/package/{id}/proof [get]
+// @Security BearerAuth
+func (r *Router) GetProofOfDelivery(c *gin.Context) {
+	var proof model.ProofOfDelivery
+	if !r.loadProof(c, &proof) {
+		return
+	}
+
+	c.JSON(http.StatusOK, proof)
+}
+
+// @Summary Get proof of delivery signature
+// @Description Get the signature image of the proof of delivery of a package
+// @Tags Package
+// @Produce image/png
+// @Param id path string true "Package ID"
+// @Success 200 {file} file
+// @Failure 404 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/package/{id}/proof/signature [get]
+// @Security BearerAuth
+func (r *Router) GetProofSignature(c *gin.Context) {
+	var proof model.ProofOfDelivery
+	if !r.loadProof(c, &proof) {
+		return
+	}
+
+	r.serveBlob(c, proof.SignatureKey, proof.SignatureContentType)
+}
+
+// @Summary Get proof of delivery photo
+// @Description Get the photo of the proof of delivery of a package
+// @Tags Package
+// @Produce image/jpeg
+// @Param id path string true "Package ID"
+// @Success 200 {file} file
+// @Failure 404 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/package/{id}/proof/photo [get]
+// @Security BearerAuth
+func (r *Router) GetProofPhoto(c *gin.Context) {
+	var proof model.ProofOfDelivery
+	if !r.loadProof(c, &proof) {
+		return
+	}
+	if proof.PhotoKey == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No photo was taken"})
+		return
+	}
+
+	r.serveBlob(c, *proof.PhotoKey, proof.PhotoContentType)
+}
+
+func (r *Router) serveBlob(c *gin.Context, key, contentType string) {
+	blob, err := r.storage.Get(c.Request.Context(), key)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer blob.Close()
+
+	c.DataFromReader(http.StatusOK, -1, contentType, blob, nil)
+}
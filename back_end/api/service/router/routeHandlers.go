@@ -0,0 +1,237 @@
+package router
+
+import (
+	"context"
+	"logistic_company/api/service/geo"
+	"logistic_company/api/service/route"
+	"logistic_company/config"
+	"logistic_company/model"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+// geocode resolves an address, returning nil coordinates when the geocoder
+// does not know it.
+func (r *Router) geocode(ctx context.Context, address string) (*float64, *float64) {
+	point, err := r.geocoder.Geocode(ctx, address)
+	if err != nil {
+		log.Warnf("Could not geocode %q, %s", address, err)
+		return nil, nil
+	}
+	return &point.Latitude, &point.Longitude
+}
+
+// packageDestination returns where a package has to be delivered, which is
+// the office for office deliveries.
+func (r *Router) packageDestination(ctx context.Context, packageModel *model.Package) (geo.Point, string, bool) {
+	if packageModel.IsDeliveredToOffice && packageModel.OfficeDeliveredAt != nil {
+		office := packageModel.OfficeDeliveredAt
+		if office.Latitude != nil && office.Longitude != nil {
+			return geo.Point{Latitude: *office.Latitude, Longitude: *office.Longitude}, office.Location, true
+		}
+		point, err := r.geocoder.Geocode(ctx, office.Location)
+		return point, office.Location, err == nil
+	}
+
+	address := ""
+	if packageModel.DeliveryLocation != nil {
+		address = *packageModel.DeliveryLocation
+	}
+	if packageModel.DeliveryLatitude != nil && packageModel.DeliveryLongitude != nil {
+		return geo.Point{Latitude: *packageModel.DeliveryLatitude, Longitude: *packageModel.DeliveryLongitude}, address, true
+	}
+	point, err := r.geocoder.Geocode(ctx, address)
+	return point, address, err == nil
+}
+
+// planCourrierRoute orders the deliveries of packages for a courier starting
+// from their office at startTime.
+func (r *Router) planCourrierRoute(ctx context.Context, courrier *model.Employee, packages []model.Package, startTime time.Time) model.CourrierRoute {
+	courrierRoute := model.CourrierRoute{
+		CourrierID:          courrier.ID,
+		StartTime:           startTime,
+		Stops:               []model.RouteStop{},
+		UnlocatedPackageIDs: []string{},
+	}
+
+	stops := []route.Stop{}
+	addresses := map[string]string{}
+	for i := range packages {
+		point, address, ok := r.packageDestination(ctx, &packages[i])
+		if !ok {
+			courrierRoute.UnlocatedPackageIDs = append(courrierRoute.UnlocatedPackageIDs, packages[i].ID)
+			continue
+		}
+		addresses[packages[i].ID] = address
+		stops = append(stops, route.Stop{
+			ID:          packages[i].ID,
+			Point:       point,
+			WindowStart: packages[i].DeliveryWindowStart,
+			WindowEnd:   packages[i].DeliveryWindowEnd,
+		})
+	}
+	if len(stops) == 0 {
+		return courrierRoute
+	}
+
+	start := stops[0].Point
+	if office := courrier.Office; office != nil {
+		if office.Latitude != nil && office.Longitude != nil {
+			start = geo.Point{Latitude: *office.Latitude, Longitude: *office.Longitude}
+		} else if point, err := r.geocoder.Geocode(ctx, office.Location); err == nil {
+			start = point
+		}
+	}
+	courrierRoute.StartLatitude = start.Latitude
+	courrierRoute.StartLongitude = start.Longitude
+
+	plan := route.Optimize(stops, route.Options{
+		Start:       start,
+		StartTime:   startTime,
+		SpeedKmh:    r.cfg.CourrierSpeedKmh,
+		ServiceTime: time.Duration(r.cfg.StopServiceMinutes) * time.Minute,
+	})
+	courrierRoute.TotalDistanceKm = plan.TotalDistanceKm
+	courrierRoute.LateStops = plan.LateStops
+	for _, stop := range plan.Stops {
+		courrierRoute.Stops = append(courrierRoute.Stops, model.RouteStop{
+			Sequence:     stop.Sequence,
+			PackageID:    stop.ID,
+			Address:      addresses[stop.ID],
+			Latitude:     stop.Point.Latitude,
+			Longitude:    stop.Point.Longitude,
+			DistanceKm:   stop.DistanceKm,
+			CumulativeKm: stop.CumulativeKm,
+			ETA:          stop.ETA,
+			WindowStart:  stop.WindowStart,
+			WindowEnd:    stop.WindowEnd,
+			Late:         stop.Late,
+		})
+	}
+
+	return courrierRoute
+}
+
+func routeStartTime(c *gin.Context) (time.Time, error) {
+	start := c.Query("start")
+	if start == "" {
+		return time.Now(), nil
+	}
+	return time.Parse(time.RFC3339, start)
+}
+
+// @Summary Get courrier route
+// @Description Orders the packages a courrier can deliver on the day of the start time into a route starting at
+// @Description their office and returns the distance and estimated arrival for every stop. Packages waiting at an
+// @Description office or in a locker, held, going back or not collected yet are left out.
+// @Tags Route
+// @Accept json
+// @Produce json
+// @Param id path string true "Courrier ID"
+// @Param start query string false "Start time (RFC3339), defaults to now"
+// @Success 200 {object} model.CourrierRoute
+// @Failure 400 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/route/courrier/{id} [get]
+// @Security BearerAuth
+func (r *Router) GetCourrierRoute(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	contextID, _ := c.Get(config.Id)
+	id := c.Param(config.Id)
+	if role != config.RoleAdmin && role != config.RoleEmployee && contextID != id {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	startTime, err := routeStartTime(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var courrier model.Employee
+	err = r.repository.EmployeeRepository.GetEmployeeById(c.Request.Context(), &courrier, id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	var packages []model.Package
+	err = r.repository.PackageRepository.GetDeliverablePackagesByCourrierID(c.Request.Context(), &packages, id, startTime)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, r.planCourrierRoute(c.Request.Context(), &courrier, packages, startTime))
+}
+
+// @Summary Optimize delivery run
+// @Description Reorders the pending stops of a delivery run along the optimized route.
+// @Description Stops that already have an outcome stay first, stops that could not be located go last.
+// @Tags DeliveryRun
+// @Accept json
+// @Produce json
+// @Param id path string true "Delivery run ID"
+// @Param start query string false "Start time (RFC3339), defaults to now"
+// @Success 200 {object} model.DeliveryRun
+// @Failure 400 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/run/{id}/optimize [post]
+// @Security BearerAuth
+func (r *Router) OptimizeDeliveryRun(c *gin.Context) {
+	id := c.Param(config.Id)
+	if !r.authorizeDeliveryRun(c, id) {
+		return
+	}
+
+	startTime, err := routeStartTime(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var run model.DeliveryRun
+	err = r.repository.DeliveryRunRepository.GetDeliveryRunByID(c.Request.Context(), &run, id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	stopIDs := []string{}
+	stopsByPackage := map[string]string{}
+	packages := []model.Package{}
+	for _, stop := range run.Stops {
+		if stop.Outcome != config.StopOutcomePending || stop.Package == nil {
+			stopIDs = append(stopIDs, stop.ID)
+			continue
+		}
+		stopsByPackage[stop.PackageID] = stop.ID
+		packages = append(packages, *stop.Package)
+	}
+
+	courrier := model.Employee{ID: run.CourrierID}
+	if run.Courrier != nil {
+		courrier = *run.Courrier
+	}
+	courrier.Office = run.Office
+
+	courrierRoute := r.planCourrierRoute(c.Request.Context(), &courrier, packages, startTime)
+	for _, stop := range courrierRoute.Stops {
+		stopIDs = append(stopIDs, stopsByPackage[stop.PackageID])
+	}
+	for _, packageID := range courrierRoute.UnlocatedPackageIDs {
+		stopIDs = append(stopIDs, stopsByPackage[packageID])
+	}
+
+	err = r.repository.DeliveryRunRepository.ReorderDeliveryRun(c.Request.Context(), &run, id, stopIDs)
+	if err != nil {
+		deliveryRunError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, run)
+}
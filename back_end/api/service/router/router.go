@@ -3,6 +3,10 @@ package router
 import (
 	_ "logistic_company/api/docs"
 	"logistic_company/api/service/auth"
+	"logistic_company/api/service/geo"
+	"logistic_company/api/service/locker"
+	"logistic_company/api/service/notify"
+	"logistic_company/api/service/storage"
 	"logistic_company/config"
 	"logistic_company/repository"
 
@@ -18,6 +22,10 @@ type Router struct {
 	cfg        *config.Config
 	ginEngine  *gin.Engine
 	secretKey  []byte
+	geocoder   geo.Geocoder
+	storage    storage.BlobStorage
+	lockers    locker.LockerProvider
+	notifier   map[string]notify.Provider
 }
 
 func NewRouter(repository *repository.Repository, cfg *config.Config) (r *Router, err error) {
@@ -25,16 +33,26 @@ func NewRouter(repository *repository.Repository, cfg *config.Config) (r *Router
 		cfg:       cfg,
 		ginEngine: gin.Default()}
 	r.secretKey = []byte(cfg.JWTSecretKey)
+	r.geocoder = geo.NewStaticGeocoder(nil)
+	if cfg.GeocoderFile != "" {
+		if r.geocoder, err = geo.LoadStaticGeocoder(cfg.GeocoderFile); err != nil {
+			return nil, err
+		}
+	}
+	r.storage = storage.NewLocalStorage(cfg.BlobStorageDir)
+	r.lockers = locker.NewSimulatedProvider()
+	r.notifier = notify.NewProviders(cfg)
+	r.ginEngine.MaxMultipartMemory = cfg.MaxUploadBytes
 	r.InitializeRoutes()
 	return r, nil
 }
 
 func (r *Router) InitializeRoutes() {
 	c := cors.New(cors.Config{
-		AllowOrigins:     []string{"http://localhost:3000"},                            // Or your frontend URL(s)
-		AllowMethods:     []string{"POST", "GET", "PUT", "PATCH", "DELETE", "OPTIONS"}, // Allowed methods
-		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"},          // Allowed headers
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowOrigins:     []string{"http://localhost:3000"},                                           // Or your frontend URL(s)
+		AllowMethods:     []string{"POST", "GET", "PUT", "PATCH", "DELETE", "OPTIONS"},                // Allowed methods
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", config.RequestIDHeader}, // Allowed headers
+		ExposeHeaders:    []string{"Content-Length", config.RequestIDHeader},
 		AllowCredentials: true, // If you need credentials (cookies, authorization headers)
 		AllowOriginFunc: func(origin string) bool {
 			// Add custom logic here if you need it
@@ -43,6 +61,7 @@ func (r *Router) InitializeRoutes() {
 		// MaxAge: 12 * time.Hour, // Optional
 	})
 	r.ginEngine.Use(c)
+	r.ginEngine.Use(requestIDMiddleware())
 
 	r.ginEngine.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 	api := r.ginEngine.Group("/api")
@@ -59,6 +78,16 @@ func (r *Router) InitializeRoutes() {
 				companyApi.GET("/:id", r.GetCompanyByID)
 				companyApi.GET("/search/:name", r.GetCompaniesByName)
 				companyApi.POST("/:id/revenue", r.GetCompanyRevenue)
+				companyApi.GET("/:id/deletion-preview", r.GetCompanyDeletionPreview)
+				companyApi.GET("/:id/returns", r.GetCompanyReturnReport)
+				companyApi.GET("/:id/revenue-report", r.GetCompanyRevenueReport)
+				companyApi.GET("/:id/courrier-zones", r.GetCourrierZones)
+				companyApi.POST("/:id/courrier-zones", r.CreateCourrierZone)
+				companyApi.DELETE("/:id/courrier-zones/:zoneId", r.DeleteCourrierZone)
+				companyApi.GET("/:id/package-types", r.GetPackageTypes)
+				companyApi.POST("/:id/package-types", r.CreatePackageType)
+				companyApi.PATCH("/:id/package-types/:typeId", r.UpdatePackageType)
+				companyApi.DELETE("/:id/package-types/:typeId", r.DeletePackageType)
 				companyApi.POST("", r.CreateCompany)
 				companyApi.PATCH(":id", r.UpdateCompany)
 				companyApi.DELETE(":id", r.DeleteCompany)
@@ -72,6 +101,7 @@ func (r *Router) InitializeRoutes() {
 				employeeApi.GET("/:id", r.GetEmployeeByID)
 				employeeApi.POST("", r.CreateEmployee)
 				employeeApi.PATCH("/:id", r.UpdateEmployee)
+				employeeApi.POST("/:id/reassign", r.ReassignEmployeePackages)
 				employeeApi.DELETE("/:id", r.DeleteEmployee)
 
 			}
@@ -79,9 +109,13 @@ func (r *Router) InitializeRoutes() {
 			officeApi := v1.Group("/office")
 			{
 				officeApi.GET("", r.GetAllOffices)
+				officeApi.GET("/nearest", r.GetNearestOffices)
 				officeApi.GET("/location/:location", r.GetOfficesByLocation)
 				officeApi.GET("/company/:id", r.GetOfficesByCompanyID)
 				officeApi.GET("/:id", r.GetOfficeByID)
+				officeApi.GET("/:id/holidays", r.GetOfficeHolidays)
+				officeApi.POST("/:id/holidays", r.CreateOfficeHoliday)
+				officeApi.DELETE("/:id/holidays/:holidayId", r.DeleteOfficeHoliday)
 				officeApi.POST("", r.CreateOffice)
 				officeApi.PATCH("/:id", r.UpdateOffice)
 				officeApi.DELETE("/:id", r.DeleteOffice)
@@ -95,7 +129,26 @@ func (r *Router) InitializeRoutes() {
 				packageApi.GET("/employee/:id", r.GetPackagesByEmployeeID)
 				packageApi.GET("/not_delivered", r.GetNotDeliveredPackages)
 				packageApi.GET("/:id", r.GetPackageByID)
+				packageApi.GET("/:id/label", r.GetPackageLabel)
+				packageApi.GET("/:id/events", r.GetPackageEvents)
+				packageApi.GET("/:id/hops", r.GetPackageHops)
+				packageApi.POST("/:id/hops/plan", r.PlanPackageHops)
+				packageApi.GET("/:id/proof", r.GetProofOfDelivery)
+				packageApi.GET("/:id/proof/signature", r.GetProofSignature)
+				packageApi.GET("/:id/proof/photo", r.GetProofPhoto)
+				packageApi.POST("/:id/proof", r.CreateProofOfDelivery)
+				packageApi.GET("/:id/attempts", r.GetDeliveryAttempts)
+				packageApi.POST("/:id/attempt", r.CreateDeliveryAttempt)
+				packageApi.POST("/:id/redelivery", r.ScheduleRedelivery)
+				packageApi.POST("/:id/return", r.CreateReturn)
+				packageApi.GET("/:id/pickup", r.GetPickupInfo)
+				packageApi.POST("/:id/pickup", r.PickUpPackage)
+				packageApi.GET("/:id/locker", r.GetLockerAccess)
+				packageApi.GET("/:id/notifications", r.GetPackageNotifications)
 				packageApi.POST("", r.CreatePackage)
+				packageApi.POST("/request", r.RequestShipment)
+				packageApi.GET("/requested/company/:id", r.GetRequestedPackages)
+				packageApi.POST("/:id/accept", r.AcceptShipment)
 				packageApi.PATCH("/:id", r.UpdatePackage)
 				packageApi.DELETE("/:id", r.DeletePackage)
 			}
@@ -106,9 +159,121 @@ func (r *Router) InitializeRoutes() {
 				clientApi.GET("/company/:id", r.GetClientsByCompanyID)
 				clientApi.GET("/search/:name", r.GetClientsByName)
 				clientApi.GET("/:id", r.GetClientByID)
+				clientApi.GET("/:id/addresses", r.GetClientAddresses)
+				clientApi.POST("/:id/addresses", r.CreateClientAddress)
+				clientApi.PUT("/:id/addresses/:addressId", r.UpdateClientAddress)
+				clientApi.POST("/:id/addresses/:addressId/default", r.SetDefaultClientAddress)
+				clientApi.DELETE("/:id/addresses/:addressId", r.DeleteClientAddress)
+				clientApi.GET("/:id/notifications", r.GetClientNotifications)
+				clientApi.GET("/:id/notification-preferences", r.GetNotificationPreference)
+				clientApi.PUT("/:id/notification-preferences", r.SetNotificationPreference)
+				clientApi.POST("/:id/verification", r.RequestVerification)
+				clientApi.POST("/:id/verification/confirm", r.ConfirmVerification)
 				clientApi.PATCH("/:id", r.UpdateClient)
 				clientApi.DELETE("/:id", r.DeleteClient)
 			}
+
+			runApi := v1.Group("/run")
+			{
+				runApi.POST("", r.CreateDeliveryRun)
+				runApi.GET("/courrier/:id", r.GetDeliveryRunsByCourrierID)
+				runApi.GET("/:id", r.GetDeliveryRunByID)
+				runApi.GET("/:id/manifest", r.GetDeliveryRunManifest)
+				runApi.PUT("/:id/order", r.ReorderDeliveryRun)
+				runApi.POST("/:id/start", r.StartDeliveryRun)
+				runApi.POST("/:id/finish", r.FinishDeliveryRun)
+				runApi.PATCH("/:id/stop/:stopId", r.RecordStopOutcome)
+				runApi.POST("/:id/optimize", r.OptimizeDeliveryRun)
+			}
+
+			claimApi := v1.Group("/claim")
+			{
+				claimApi.POST("", r.CreateClaim)
+				claimApi.GET("/package/:id", r.GetClaimsByPackageID)
+				claimApi.GET("/company/:id", r.GetClaimsByCompanyID)
+				claimApi.GET("/:id", r.GetClaimByID)
+				claimApi.PATCH("/:id/status", r.UpdateClaimStatus)
+				claimApi.POST("/:id/evidence", r.AddClaimEvidence)
+				claimApi.GET("/:id/evidence/:evidenceId", r.GetClaimEvidence)
+			}
+
+			codApi := v1.Group("/cod")
+			{
+				codApi.GET("/courrier/:id/pending", r.GetPendingCollections)
+				codApi.POST("/handover", r.CreateCashHandover)
+				codApi.GET("/handover/company/:id", r.GetCashHandovers)
+				codApi.GET("/handover/:id", r.GetCashHandoverByID)
+				codApi.POST("/payout", r.CreatePayouts)
+				codApi.GET("/payout", r.GetPayouts)
+				codApi.POST("/payout/:id/paid", r.MarkPayoutPaid)
+			}
+
+			scanApi := v1.Group("/scan")
+			{
+				scanApi.POST("", r.CreateScan)
+				scanApi.POST("/batch", r.CreateBatchScan)
+				scanApi.GET("/misrouted/company/:id", r.GetMisroutedScans)
+			}
+
+			networkApi := v1.Group("/network")
+			{
+				networkApi.GET("/company/:id", r.GetLinehauls)
+				networkApi.GET("/company/:id/path", r.FindNetworkPath)
+				networkApi.POST("/linehaul", r.CreateLinehaul)
+				networkApi.PATCH("/linehaul/:id", r.UpdateLinehaul)
+				networkApi.DELETE("/linehaul/:id", r.DeleteLinehaul)
+			}
+
+			transferApi := v1.Group("/transfer")
+			{
+				transferApi.POST("", r.CreateTransferBatch)
+				transferApi.GET("/office/:id", r.GetTransferBatchesByOfficeID)
+				transferApi.GET("/:id", r.GetTransferBatchByID)
+				transferApi.POST("/:id/packages", r.AddTransferPackages)
+				transferApi.POST("/:id/depart", r.DepartTransferBatch)
+				transferApi.POST("/:id/arrive", r.ArriveTransferBatch)
+			}
+
+			lockerApi := v1.Group("/locker")
+			{
+				lockerApi.GET("/company/:id", r.GetLockersByCompanyID)
+				lockerApi.GET("/:id", r.GetLockerByID)
+				lockerApi.POST("", r.CreateLocker)
+				lockerApi.DELETE("/:id", r.DeleteLocker)
+				lockerApi.POST("/:id/open", r.OpenLockerCompartment)
+			}
+
+			shipmentApi := v1.Group("/shipment")
+			{
+				shipmentApi.POST("", r.CreateShipment)
+				shipmentApi.GET("/tracking/:trackingNumber", r.GetShipmentByTrackingNumber)
+				shipmentApi.GET("/sender/:id", r.GetShipmentsBySenderID)
+				shipmentApi.GET("/receiver/:id", r.GetShipmentsByReceiverID)
+				shipmentApi.GET("/:id", r.GetShipmentByID)
+			}
+
+			notificationsApi := v1.Group("/notifications")
+			{
+				notificationsApi.GET("", r.GetInbox)
+				notificationsApi.POST("/read-all", r.MarkAllNotificationsRead)
+				notificationsApi.POST("/:id/read", r.MarkNotificationRead)
+			}
+
+			collectionApi := v1.Group("/collection")
+			{
+				collectionApi.POST("", r.CreateCollection)
+				collectionApi.GET("/company/:id", r.GetCollectionsByCompanyID)
+				collectionApi.GET("/sender/:id", r.GetCollectionsBySenderID)
+				collectionApi.GET("/:id", r.GetCollectionByID)
+				collectionApi.POST("/:id/assign", r.AssignCollection)
+				collectionApi.POST("/:id/cancel", r.CancelCollection)
+				collectionApi.POST("/:id/complete", r.CompleteCollection)
+			}
+
+			v1.GET("/route/courrier/:id", r.GetCourrierRoute)
+			v1.GET("/route/courrier/:id/today", r.GetCourrierDay)
+
+			v1.GET("/audit", r.GetAuditLogs)
 		}
 	}
 }
@@ -0,0 +1,181 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"logistic_company/config"
+	"logistic_company/model"
+	"logistic_company/repository"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// scanningEmployee loads the office employee recording a scan. It writes the
+// error response itself.
+func (r *Router) scanningEmployee(c *gin.Context, employee *model.Employee) bool {
+	role, _ := c.Get(config.Role)
+	contextID, _ := c.Get(config.Id)
+	if role != config.RoleEmployee {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return false
+	}
+
+	err := r.repository.EmployeeRepository.GetEmployeeById(c.Request.Context(), employee, fmt.Sprint(contextID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return false
+	}
+	if employee.OfficeID == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Employee is not assigned to an office"})
+		return false
+	}
+	return true
+}
+
+// @Summary Record scan
+// @Description Records a scan of a package label (received_at_office, loaded_to_vehicle or
+// @Description handed_to_courrier) at the office of the employee. Scans at an office the
+// @Description package is not expected at are recorded and flagged as misrouted.
+// @Tags Scan
+// @Accept json
+// @Produce json
+// @Param scan body model.ScanRequest true "Scan"
+// @Success 201 {object} model.PackageEvent
+// @Failure 400 {object} gin.H
+// @Failure 404 {object} gin.H
+// @Failure 409 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/scan [post]
+// @Security BearerAuth
+func (r *Router) CreateScan(c *gin.Context) {
+	var employee model.Employee
+	if !r.scanningEmployee(c, &employee) {
+		return
+	}
+
+	var request model.ScanRequest
+	if err := c.ShouldBindJSON(&request); err != nil || !repository.IsScanType(request.Type) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	var event model.PackageEvent
+	err := r.repository.ScanRepository.RecordScan(c.Request.Context(), &event, &employee, request)
+	switch {
+	case errors.Is(err, repository.ErrorNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Package not found"})
+	case errors.Is(err, repository.ErrorInvalidScan):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, repository.ErrorPackageDelivered):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusCreated, event)
+	}
+}
+
+// @Summary Record batch scan
+// @Description Records the same scan for every package of a cage or bag. Every code gets its own
+// @Description result, codes that cannot be scanned do not stop the rest of the batch.
+// @Tags Scan
+// @Accept json
+// @Produce json
+// @Param scan body model.BatchScanRequest true "Batch scan"
+// @Success 201 {object} []model.ScanResult
+// @Failure 400 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/scan/batch [post]
+// @Security BearerAuth
+func (r *Router) CreateBatchScan(c *gin.Context) {
+	var employee model.Employee
+	if !r.scanningEmployee(c, &employee) {
+		return
+	}
+
+	var request model.BatchScanRequest
+	if err := c.ShouldBindJSON(&request); err != nil || !repository.IsScanType(request.Type) ||
+		len(request.TrackingCodes) > config.MaxBatchScanSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	results := []model.ScanResult{}
+	err := r.repository.ScanRepository.RecordBatchScan(c.Request.Context(), &results, &employee, request)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, results)
+}
+
+// @Summary Get misrouted scans
+// @Description Get the scans of the company's packages that were flagged as misrouted
+// @Tags Scan
+// @Accept json
+// @Produce json
+// @Param id path string true "Company ID"
+// @Param limit query int false "limit"
+// @Param offset query int false "offset"
+// @Success 200 {object} []model.PackageEvent
+// @Failure 400 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/scan/misrouted/company/{id} [get]
+// @Security BearerAuth
+func (r *Router) GetMisroutedScans(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	if role != config.RoleAdmin && role != config.RoleEmployee {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+	limit, offset, err := extractPagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var events []model.PackageEvent
+	err = r.repository.ScanRepository.GetMisroutedScans(c.Request.Context(), &events, c.Param(config.Id), limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, events)
+}
+
+// @Summary Get package events
+// @Description Get the tracking history of a package, its status changes and scans in order
+// @Tags Package
+// @Accept json
+// @Produce json
+// @Param id path string true "Package ID"
+// @Success 200 {object} []model.PackageEvent
+// @Failure 500 {object} gin.H
+// @Router /api/v1/package/{id}/events [get]
+// @Security BearerAuth
+func (r *Router) GetPackageEvents(c *gin.Context) {
+	id := c.Param(config.Id)
+
+	var packageModel model.Package
+	err := r.repository.PackageRepository.GetPackageById(c.Request.Context(), &packageModel, id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !r.canViewPackage(c, &packageModel) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var events []model.PackageEvent
+	err = r.repository.ScanRepository.GetPackageEvents(c.Request.Context(), &events, id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, events)
+}
@@ -0,0 +1,232 @@
+package router
+
+import (
+	"errors"
+	"logistic_company/config"
+	"logistic_company/model"
+	"logistic_company/repository"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+// canViewShipment applies the package visibility rules to a shipment.
+func (r *Router) canViewShipment(c *gin.Context, shipment *model.Shipment) bool {
+	return r.canViewPackage(c, &model.Package{
+		SenderID:   shipment.SenderID,
+		ReceiverID: &shipment.ReceiverID,
+		CompanyID:  shipment.CompanyID,
+	})
+}
+
+// @Summary Create shipment
+// @Description Registers a shipment of several parcels sent together to one receiver. The parcels
+// @Description share a tracking number and a courrier and are priced one by one.
+// @Tags Shipment
+// @Accept json
+// @Produce json
+// @Param shipment body model.ShipmentRequest true "Shipment"
+// @Success 201 {object} model.Shipment
+// @Failure 400 {object} gin.H
+// @Failure 409 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/shipment [post]
+// @Security BearerAuth
+func (r *Router) CreateShipment(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	contextID, _ := c.Get(config.Id)
+
+	if role != config.RoleAdmin && role != config.RoleEmployee {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var request model.ShipmentRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.Error(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+	if request.IsDeliveredToOffice && request.OfficeDeliveredAtID == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Office ID is required"})
+		return
+	}
+
+	registeredByID := contextID.(string)
+	template := model.Package{
+		SenderID:            request.SenderID,
+		ReceiverID:          &request.ReceiverID,
+		CompanyID:           request.CompanyID,
+		CourrierID:          request.CourrierID,
+		RegisteredByID:      &registeredByID,
+		OfficeAcceptedAtID:  &request.OfficeAcceptedAtID,
+		IsDeliveredToOffice: request.IsDeliveredToOffice,
+		OfficeDeliveredAtID: request.OfficeDeliveredAtID,
+		DeliveryLocation:    request.DeliveryLocation,
+		DeliveryAddressID:   request.DeliveryAddressID,
+	}
+	if !template.IsDeliveredToOffice && template.DeliveryLocation != nil {
+		template.DeliveryLatitude, template.DeliveryLongitude = r.geocode(c.Request.Context(), *template.DeliveryLocation)
+	}
+
+	parcels := make([]model.Package, 0, len(request.Parcels))
+	for _, parcel := range request.Parcels {
+		if parcel.Insured && parcel.DeclaredValue == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Insured packages need a declared value"})
+			return
+		}
+		parcels = append(parcels, model.Package{
+			Weight:        parcel.Weight,
+			Length:        parcel.Length,
+			Width:         parcel.Width,
+			Height:        parcel.Height,
+			PackageTypeID: parcel.PackageTypeID,
+			DeclaredValue: parcel.DeclaredValue,
+			Insured:       parcel.Insured,
+		})
+	}
+
+	var shipment model.Shipment
+	err := r.repository.ShipmentRepository.CreateShipment(c.Request.Context(), &shipment, template, parcels)
+	if errors.Is(err, repository.ErrorNoCourriersAvailable) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, repository.ErrorPackageRejected) || errors.Is(err, repository.ErrorInvalidPackageType) ||
+		errors.Is(err, repository.ErrorInvalidShipment) || errors.Is(err, repository.ErrorInvalidAddress) ||
+		errors.Is(err, repository.ErrorInvalidCourrier) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, shipment)
+}
+
+// @Summary Get shipment by id
+// @Description Get a shipment with its parcels and the status derived from them
+// @Tags Shipment
+// @Accept json
+// @Produce json
+// @Param id path string true "Shipment ID"
+// @Success 200 {object} model.Shipment
+// @Failure 404 {object} gin.H
+// @Router /api/v1/shipment/{id} [get]
+// @Security BearerAuth
+func (r *Router) GetShipmentByID(c *gin.Context) {
+	var shipment model.Shipment
+	err := r.repository.ShipmentRepository.GetShipmentById(c.Request.Context(), &shipment, c.Param(config.Id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if !r.canViewShipment(c, &shipment) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	c.JSON(http.StatusOK, shipment)
+}
+
+// @Summary Get shipment by tracking number
+// @Description Get a shipment with its parcels by its tracking number
+// @Tags Shipment
+// @Accept json
+// @Produce json
+// @Param trackingNumber path string true "Tracking number"
+// @Success 200 {object} model.Shipment
+// @Failure 404 {object} gin.H
+// @Router /api/v1/shipment/tracking/{trackingNumber} [get]
+// @Security BearerAuth
+func (r *Router) GetShipmentByTrackingNumber(c *gin.Context) {
+	var shipment model.Shipment
+	err := r.repository.ShipmentRepository.GetShipmentByTrackingNumber(c.Request.Context(), &shipment, c.Param("trackingNumber"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if !r.canViewShipment(c, &shipment) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	c.JSON(http.StatusOK, shipment)
+}
+
+// @Summary Get shipments by sender id
+// @Description Get shipments by sender id
+// @Tags Shipment
+// @Accept json
+// @Produce json
+// @Param id path string true "Sender ID"
+// @Param limit query int false "limit"
+// @Param offset query int false "offset"
+// @Success 200 {object} []model.Shipment
+// @Failure 400 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/shipment/sender/{id} [get]
+// @Security BearerAuth
+func (r *Router) GetShipmentsBySenderID(c *gin.Context) {
+	contextID, _ := c.Get(config.Id)
+	role, _ := c.Get(config.Role)
+	id := c.Param(config.Id)
+	if role != config.RoleAdmin && role != config.RoleEmployee && contextID != id {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+	limit, offset, err := extractPagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var shipments []model.Shipment
+	err = r.repository.ShipmentRepository.GetShipmentsBySenderID(c.Request.Context(), &shipments, id, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, shipments)
+}
+
+// @Summary Get shipments by receiver id
+// @Description Get shipments by receiver id
+// @Tags Shipment
+// @Accept json
+// @Produce json
+// @Param id path string true "Receiver ID"
+// @Param limit query int false "limit"
+// @Param offset query int false "offset"
+// @Success 200 {object} []model.Shipment
+// @Failure 400 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/shipment/receiver/{id} [get]
+// @Security BearerAuth
+func (r *Router) GetShipmentsByReceiverID(c *gin.Context) {
+	contextID, _ := c.Get(config.Id)
+	role, _ := c.Get(config.Role)
+	id := c.Param(config.Id)
+	if role != config.RoleAdmin && role != config.RoleEmployee && contextID != id {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+	limit, offset, err := extractPagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var shipments []model.Shipment
+	err = r.repository.ShipmentRepository.GetShipmentsByReceiverID(c.Request.Context(), &shipments, id, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, shipments)
+}
@@ -0,0 +1,215 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"logistic_company/config"
+	"logistic_company/model"
+	"logistic_company/repository"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+// @Summary Request shipment
+// @Description Lets a client request a shipment online. The parcel is dropped off at officeAcceptedAtID or
+// @Description collected from pickupAddressID, an address of the client. The package waits in the Requested
+// @Description status with a price quoted from the estimated weight until an employee accepts it. Receivers
+// @Description without an account are given by receiverName and receiverPhone or receiverEmail.
+// @Tags Package
+// @Accept json
+// @Produce json
+// @Param request body model.ClientShipmentRequest true "Shipment request"
+// @Success 201 {object} model.Package
+// @Failure 400 {object} gin.H
+// @Failure 403 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/package/request [post]
+// @Security BearerAuth
+func (r *Router) RequestShipment(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	contextID, _ := c.Get(config.Id)
+	if role != config.RoleClient {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var request model.ClientShipmentRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.Error(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+	if (request.OfficeAcceptedAtID == nil) == (request.PickupAddressID == nil) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Either a drop-off office or a pickup address is required"})
+		return
+	}
+	if request.ReceiverID == nil && (request.ReceiverName == "" || (request.ReceiverPhone == "" && request.ReceiverEmail == "")) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A receiver ID or the receiver's name and phone or email is required"})
+		return
+	}
+	if request.IsDeliveredToOffice && request.OfficeDeliveredAtID == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Office ID is required"})
+		return
+	}
+	if request.CODCurrency != "" && len(request.CODCurrency) != 3 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid cash on delivery amount"})
+		return
+	}
+	if request.Insured && request.DeclaredValue == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Insured packages need a declared value"})
+		return
+	}
+
+	packageModel := model.Package{
+		SenderID:            contextID.(string),
+		ReceiverID:          request.ReceiverID,
+		ReceiverName:        request.ReceiverName,
+		ReceiverPhone:       request.ReceiverPhone,
+		ReceiverEmail:       request.ReceiverEmail,
+		CompanyID:           request.CompanyID,
+		EstimatedWeight:     request.EstimatedWeight,
+		Length:              request.Length,
+		Width:               request.Width,
+		Height:              request.Height,
+		PackageTypeID:       request.PackageTypeID,
+		OfficeAcceptedAtID:  request.OfficeAcceptedAtID,
+		PickupAddressID:     request.PickupAddressID,
+		IsDeliveredToOffice: request.IsDeliveredToOffice,
+		OfficeDeliveredAtID: request.OfficeDeliveredAtID,
+		DeliveryLocation:    request.DeliveryLocation,
+		DeliveryAddressID:   request.DeliveryAddressID,
+		LockerID:            request.LockerID,
+		CODAmount:           request.CODAmount,
+		CODCurrency:         request.CODCurrency,
+		DeclaredValue:       request.DeclaredValue,
+		Insured:             request.Insured,
+	}
+	if !packageModel.IsDeliveredToOffice && packageModel.LockerID == nil && packageModel.DeliveryLocation != nil {
+		packageModel.DeliveryLatitude, packageModel.DeliveryLongitude = r.geocode(c.Request.Context(), *packageModel.DeliveryLocation)
+	}
+
+	err := r.repository.PackageRepository.RequestPackage(c.Request.Context(), &packageModel)
+	if errors.Is(err, repository.ErrorPackageRejected) || errors.Is(err, repository.ErrorInvalidPackageType) ||
+		errors.Is(err, repository.ErrorInvalidLocker) || errors.Is(err, repository.ErrorDoesNotFitLocker) ||
+		errors.Is(err, repository.ErrorInvalidAddress) || errors.Is(err, repository.ErrorInvalidReceiver) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, packageModel)
+}
+
+// @Summary Get requested shipments by company id
+// @Description Get the shipments clients requested from a company that were not accepted yet, oldest first
+// @Tags Package
+// @Accept json
+// @Produce json
+// @Param id path string true "Company ID"
+// @Param limit query int false "limit"
+// @Param offset query int false "offset"
+// @Success 200 {object} []model.Package
+// @Failure 400 {object} gin.H
+// @Failure 403 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/package/requested/company/{id} [get]
+// @Security BearerAuth
+func (r *Router) GetRequestedPackages(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	if role != config.RoleAdmin && role != config.RoleEmployee {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	limit, offset, err := extractPagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var packages []model.Package
+	err = r.repository.PackageRepository.GetRequestedPackages(c.Request.Context(), &packages, c.Param(config.Id), limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, packages)
+}
+
+// @Summary Accept requested shipment
+// @Description Accepts a requested shipment once the parcel is at the office. The package is priced again
+// @Description by its measured weight and size, gets a courier and is registered. The sender is notified
+// @Description when the price differs from the quote.
+// @Tags Package
+// @Accept json
+// @Produce json
+// @Param id path string true "Package ID"
+// @Param confirmation body model.ShipmentConfirmation true "Measured parcel"
+// @Success 200 {object} model.Package
+// @Failure 400 {object} gin.H
+// @Failure 403 {object} gin.H
+// @Failure 404 {object} gin.H
+// @Failure 409 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/package/{id}/accept [post]
+// @Security BearerAuth
+func (r *Router) AcceptShipment(c *gin.Context) {
+	role, _ := c.Get(config.Role)
+	contextID, _ := c.Get(config.Id)
+	if role != config.RoleAdmin && role != config.RoleEmployee {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var confirmation model.ShipmentConfirmation
+	if err := c.ShouldBindJSON(&confirmation); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+	if confirmation.OfficeAcceptedAtID == "" {
+		var employee model.Employee
+		err := r.repository.EmployeeRepository.GetEmployeeById(c.Request.Context(), &employee, fmt.Sprint(contextID))
+		if err != nil || employee.OfficeID == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Office ID is required"})
+			return
+		}
+		confirmation.OfficeAcceptedAtID = *employee.OfficeID
+	}
+
+	var packageModel model.Package
+	err := r.repository.PackageRepository.GetPackageById(c.Request.Context(), &packageModel, c.Param(config.Id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if !r.canViewPackage(c, &packageModel) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var accepted model.Package
+	err = r.repository.PackageRepository.AcceptPackage(c.Request.Context(), &accepted, packageModel.ID, fmt.Sprint(contextID), confirmation)
+	if errors.Is(err, repository.ErrorNotRequested) || errors.Is(err, repository.ErrorNoCourriersAvailable) ||
+		errors.Is(err, repository.ErrorNoCompartmentAvailable) || errors.Is(err, repository.ErrorLockerCodesTaken) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, repository.ErrorPackageRejected) || errors.Is(err, repository.ErrorInvalidPackageType) ||
+		errors.Is(err, repository.ErrorDoesNotFitLocker) || errors.Is(err, repository.ErrorInvalidLocker) ||
+		errors.Is(err, repository.ErrorInvalidCourrier) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, accepted)
+}
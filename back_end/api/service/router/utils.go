@@ -1,7 +1,10 @@
 package router
 
 import (
+	"encoding/json"
+	"reflect"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -15,3 +18,24 @@ func extractPagination(c *gin.Context) (limit, offset int, err error) {
 
 	return
 }
+
+// suppliedFields returns the names of the fields of the struct v points to
+// whose JSON keys are present in body, so partial updates only write what
+// the request contains.
+func suppliedFields(body []byte, v interface{}) ([]string, error) {
+	keys := map[string]json.RawMessage{}
+	if err := json.Unmarshal(body, &keys); err != nil {
+		return nil, err
+	}
+
+	fields := []string{}
+	structType := reflect.TypeOf(v).Elem()
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if _, ok := keys[name]; ok && name != "" && name != "-" {
+			fields = append(fields, field.Name)
+		}
+	}
+	return fields, nil
+}
@@ -0,0 +1,132 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"logistic_company/api/service/notify"
+	"logistic_company/config"
+	"logistic_company/model"
+	"logistic_company/repository"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+// ownsClientAccount is true when the request is made by the client itself.
+// Verifications prove an email or phone belongs to the client, so not even
+// admins can make them on the client's behalf.
+func ownsClientAccount(c *gin.Context) bool {
+	role, _ := c.Get(config.Role)
+	contextID, _ := c.Get(config.Id)
+	return role == config.RoleClient && contextID == c.Param(config.Id)
+}
+
+// @Summary Request verification code
+// @Description Sends a one-time code to the email (channel "email") or the phone (channel "sms") of the client.
+// @Description The code expires after a few minutes, codes requested before for the same channel stop working.
+// @Description Only a few codes can be requested for a client or an email or phone per hour.
+// @Tags Client
+// @Accept json
+// @Produce json
+// @Param id path string true "Client ID"
+// @Param request body model.VerificationRequest true "Channel to verify"
+// @Success 202 {object} model.ClientVerification
+// @Failure 400 {object} gin.H
+// @Failure 403 {object} gin.H
+// @Failure 404 {object} gin.H
+// @Failure 429 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/client/{id}/verification [post]
+// @Security BearerAuth
+func (r *Router) RequestVerification(c *gin.Context) {
+	if !ownsClientAccount(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var request model.VerificationRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+	provider, ok := r.notifier[request.Channel]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid channel"})
+		return
+	}
+
+	var verification model.ClientVerification
+	err := r.repository.ClientRepository.RequestVerification(c.Request.Context(), &verification, c.Param(config.Id), request.Channel)
+	if err != nil {
+		if errors.Is(err, repository.ErrorNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, repository.ErrorInvalidVerification) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, repository.ErrorTooManyVerifications) {
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	err = provider.Send(c.Request.Context(), notify.Message{
+		Channel: verification.Channel,
+		To:      verification.Target,
+		Subject: "Your verification code",
+		Body: fmt.Sprintf("Your verification code is %s. It expires in %d minutes.",
+			verification.Code, config.VerificationCodeMinutes),
+	})
+	if err != nil {
+		log.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not send the verification code"})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, verification)
+}
+
+// @Summary Confirm verification code
+// @Description Confirms the code sent to the email or the phone of the client. Packages sent to that email or
+// @Description phone before the client had an account are claimed by the client and returned.
+// @Tags Client
+// @Accept json
+// @Produce json
+// @Param id path string true "Client ID"
+// @Param request body model.VerificationRequest true "Channel and code"
+// @Success 200 {object} []model.Package
+// @Failure 400 {object} gin.H
+// @Failure 403 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/v1/client/{id}/verification/confirm [post]
+// @Security BearerAuth
+func (r *Router) ConfirmVerification(c *gin.Context) {
+	if !ownsClientAccount(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var request model.VerificationRequest
+	if err := c.ShouldBindJSON(&request); err != nil || request.Code == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	var claimed []model.Package
+	err := r.repository.ClientRepository.ConfirmVerification(c.Request.Context(), &claimed, c.Param(config.Id), request.Channel, request.Code)
+	if err != nil {
+		if errors.Is(err, repository.ErrorInvalidVerification) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, claimed)
+}
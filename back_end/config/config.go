@@ -1,8 +1,6 @@
 package config
 
 import (
-	"fmt"
-
 	"github.com/joho/godotenv"
 	"github.com/kelseyhightower/envconfig"
 )
@@ -17,6 +15,30 @@ type Config struct {
 	APIhost      string `envconfig:"API_HOST"`
 	APIport      string `envconfig:"API_PORT"`
 	JWTSecretKey string `envconfig:"JWT_SECRET_KEY"`
+
+	GeocoderFile       string  `envconfig:"GEOCODER_FILE"`
+	CourrierSpeedKmh   float64 `envconfig:"COURRIER_SPEED_KMH" default:"30"`
+	StopServiceMinutes int     `envconfig:"STOP_SERVICE_MINUTES" default:"5"`
+
+	BlobStorageDir string `envconfig:"BLOB_STORAGE_DIR" default:"./data/blobs"`
+	MaxUploadBytes int64  `envconfig:"MAX_UPLOAD_BYTES" default:"10485760"`
+
+	StorageJobMinutes int `envconfig:"STORAGE_JOB_MINUTES" default:"60"`
+	LockerJobMinutes  int `envconfig:"LOCKER_JOB_MINUTES" default:"15"`
+
+	// Without an SMTP host or an SMS gateway the messages of that channel
+	// are written to NotificationFile instead
+	SMTPHost        string `envconfig:"SMTP_HOST"`
+	SMTPPort        string `envconfig:"SMTP_PORT" default:"587"`
+	SMTPUser        string `envconfig:"SMTP_USER"`
+	SMTPPassword    string `envconfig:"SMTP_PASSWORD"`
+	SMTPFrom        string `envconfig:"SMTP_FROM"`
+	SMSGatewayURL   string `envconfig:"SMS_GATEWAY_URL"`
+	SMSGatewayToken string `envconfig:"SMS_GATEWAY_TOKEN"`
+	SMSSender       string `envconfig:"SMS_SENDER"`
+
+	NotificationFile       string `envconfig:"NOTIFICATION_FILE" default:"./data/notifications.log"`
+	NotificationJobMinutes int    `envconfig:"NOTIFICATION_JOB_MINUTES" default:"1"`
 }
 
 func LoadConfig() (*Config, error) {
@@ -25,7 +47,6 @@ func LoadConfig() (*Config, error) {
 		return nil, err
 	}
 	err := envconfig.Process("", &cfg)
-	fmt.Printf("Loaded config: %+v", cfg)
 	if err != nil {
 		return nil, err
 	}
@@ -12,5 +12,224 @@ const (
 	DeliveryToOfficePricePerKillogram  = 4.99
 	DeliveryToAddressPricePerKillogram = 9.99
 
-	StatusDelivired = "Delivered"
+	DefaultCurrency = "EUR"
+
+	DefaultVolumetricDivisor = 5000
+
+	TrackingNumberPrefix = "LC"
+	// TrackingNumberAttempts is how many random tracking numbers are tried
+	// before giving up when they are all taken
+	TrackingNumberAttempts = 5
+
+	LabelLayoutA6  = "a6"
+	LabelLayoutA4  = "a4"
+	MaxLabelCopies = 4
+
+	PackageTypeDocument = "document"
+	PackageTypeParcel   = "parcel"
+	PackageTypePallet   = "pallet"
+	PackageTypeFragile  = "fragile"
+
+	StatusDelivired      = "Delivered"
+	StatusRegistered     = "Registered"
+	StatusOutForDelivery = "Out for delivery"
+	StatusDeliveryFailed = "Delivery failed"
+	StatusRefused        = "Refused"
+
+	StatusRedeliveryScheduled = "Redelivery scheduled"
+	StatusHeldAtOffice        = "Held at office"
+	StatusReturnToSender      = "Return to sender"
+	StatusReturned            = "Returned to sender"
+	StatusInTransit           = "In transit"
+	StatusArrivedAtOffice     = "Arrived at office"
+	StatusInLocker            = "In locker"
+	StatusLockerExpired       = "Locker pickup expired"
+	StatusRequested           = "Requested"
+
+	EventCreated         = "created"
+	EventStatusChanged   = "status_changed"
+	ScanReceivedAtOffice = "received_at_office"
+	ScanLoadedToVehicle  = "loaded_to_vehicle"
+	ScanHandedToCourrier = "handed_to_courrier"
+	EventDeparted        = "departed"
+	EventArrived         = "arrived"
+	EventPickedUp        = "picked_up"
+	EventLockerDropOff   = "locker_drop_off"
+	EventLockerPickUp    = "locker_pick_up"
+	EventLockerRetrieved = "locker_retrieved"
+
+	PickupPINLength  = 6
+	LockerCodeLength = 8
+	// LockerCodeAttempts is how many random locker codes are tried before
+	// giving up when they are all taken at the locker
+	LockerCodeAttempts = 5
+	// A locker takes no codes for LockerLockoutMinutes once
+	// MaxLockerCodeFailures wrong codes were entered in a row
+	MaxLockerCodeFailures = 5
+	LockerLockoutMinutes  = 15
+
+	VerificationCodeLength  = 6
+	VerificationCodeMinutes = 15
+	MaxVerificationAttempts = 5
+	// Codes a client or a target get per VerificationWindowMinutes
+	MaxVerificationRequests   = 5
+	VerificationWindowMinutes = 60
+
+	LockerSizeSmall  = "small"
+	LockerSizeMedium = "medium"
+	LockerSizeLarge  = "large"
+
+	HopPlanned   = "planned"
+	HopInTransit = "in_transit"
+	HopCompleted = "completed"
+
+	TransferOpen     = "open"
+	TransferDeparted = "departed"
+	TransferArrived  = "arrived"
+
+	MaxBatchScanSize = 500
+
+	ChannelEmail = "email"
+	ChannelSMS   = "sms"
+
+	NotificationPending = "pending"
+	NotificationSent    = "sent"
+	NotificationFailed  = "failed"
+
+	TemplatePackageRegistered = "package_registered"
+	TemplateOutForDelivery    = "out_for_delivery"
+	TemplateReadyForPickup    = "ready_for_pickup"
+	TemplateDelivered         = "delivered"
+	TemplatePriceAdjusted     = "price_adjusted"
+
+	InboxPackageEvent  = "package_event"
+	InboxReassignment  = "reassignment"
+	InboxOfficeChanged = "office_changed"
+	InboxCollection    = "collection"
+
+	MaxNotificationAttempts = 5
+	NotificationBatchSize   = 100
+
+	ShipmentStatusPartiallyDelivered = "Partially delivered"
+	ShipmentStatusInTransit          = StatusInTransit
+)
+
+const (
+	RequestID       = "requestID"
+	RequestIDHeader = "X-Request-ID"
+
+	AuditActionCreate   = "create"
+	AuditActionUpdate   = "update"
+	AuditActionDelete   = "delete"
+	AuditActionReassign = "reassign"
+	AuditActionArchive  = "archive"
+	AuditActionTransfer = "transfer"
+	AuditActionReturn   = "return"
+	AuditActionClaim    = "claim"
+
+	AuditEntityCompany  = "company"
+	AuditEntityEmployee = "employee"
+	AuditEntityOffice   = "office"
+	AuditEntityPackage  = "package"
+	AuditEntityClient   = "client"
+
+	AuditEntityCourrierZone = "courrier_zone"
+	AuditEntityDeliveryRun  = "delivery_run"
+	AuditEntityProof        = "proof_of_delivery"
+	AuditEntityAttempt      = "delivery_attempt"
+	AuditEntityCOD          = "cod_collection"
+	AuditEntityHandover     = "cash_handover"
+	AuditEntityPayout       = "payout_batch"
+	AuditEntityClaim        = "insurance_claim"
+	AuditEntityPackageType  = "package_type"
+	AuditEntityShipment     = "shipment"
+	AuditEntityScan         = "scan"
+	AuditEntityLinehaul     = "linehaul"
+	AuditEntityTransfer     = "transfer_batch"
+	AuditEntityHoliday      = "office_holiday"
+	AuditEntityLocker       = "locker"
+	AuditEntityAddress      = "client_address"
+	AuditEntityCollection   = "collection"
+	AuditEntityVerification = "client_verification"
+)
+
+const (
+	CompanyDeletionRefuse   = "refuse"
+	CompanyDeletionArchive  = "archive"
+	CompanyDeletionTransfer = "transfer"
+)
+
+const (
+	AssignmentRoundRobin  = "round_robin"
+	AssignmentLeastLoaded = "least_loaded"
+	AssignmentZone        = "zone"
+
+	DefaultAssignmentStrategy = AssignmentLeastLoaded
+)
+
+const (
+	DateFormat      = "2006-01-02"
+	TimeOfDayFormat = "15:04"
+
+	DefaultNearestOffices = 5
+	MaxNearestOffices     = 50
+
+	RunStatusPlanned    = "planned"
+	RunStatusInProgress = "in_progress"
+	RunStatusFinished   = "finished"
+
+	CollectionScheduled = "scheduled"
+	CollectionAssigned  = "assigned"
+	CollectionCompleted = "completed"
+	CollectionCancelled = "cancelled"
+
+	StopOutcomePending   = "pending"
+	StopOutcomeDelivered = "delivered"
+	StopOutcomeFailed    = "failed"
+	StopOutcomeRefused   = "refused"
+	StopOutcomeSkipped   = "skipped"
+)
+
+const (
+	DefaultMaxDeliveryAttempts = 3
+
+	FailedDeliveryHoldAtOffice   = "hold_at_office"
+	FailedDeliveryReturnToSender = "return_to_sender"
+
+	DefaultFailedDeliveryAction = FailedDeliveryHoldAtOffice
+
+	AttemptReasonNotHome         = "recipient_not_home"
+	AttemptReasonAddressNotFound = "address_not_found"
+	AttemptReasonNoAccess        = "no_access"
+	AttemptReasonBusinessClosed  = "business_closed"
+	AttemptReasonOther           = "other"
+
+	RedeliveryDate         = "date"
+	RedeliveryOfficePickup = "office_pickup"
+)
+
+const (
+	ReturnPricingFree       = "free"
+	ReturnPricingOriginal   = "original"
+	ReturnPricingPercentage = "percentage"
+	ReturnPricingFlat       = "flat"
+
+	DefaultReturnPricing = ReturnPricingOriginal
+
+	ReturnReasonRefused        = "refused"
+	ReturnReasonUndeliverable  = "undeliverable"
+	ReturnReasonSenderRecalled = "sender_recalled"
+	ReturnReasonOther          = "other"
+	ReturnReasonStorageExpired = "storage_expired"
+)
+
+const (
+	ClaimTypeLost    = "lost"
+	ClaimTypeDamaged = "damaged"
+
+	ClaimStatusSubmitted   = "submitted"
+	ClaimStatusUnderReview = "under_review"
+	ClaimStatusApproved    = "approved"
+	ClaimStatusRejected    = "rejected"
+	ClaimStatusPaid        = "paid"
 )
@@ -0,0 +1,50 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Address is a structured delivery address in the address book of a client.
+// Country is the ISO 3166-1 alpha-2 code, the postal code is checked against
+// its format. Notes are for the courier, such as a door code.
+type Address struct {
+	ID         string     `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	ClientID   string     `gorm:"column:client_id;not null;index;type:varchar(255)" json:"clientID"`
+	Label      string     `gorm:"column:label;type:varchar(255)" json:"label"`
+	Street     string     `gorm:"column:street;not null;type:varchar(255)" json:"street" binding:"required"`
+	Number     string     `gorm:"column:number;type:varchar(255)" json:"number"`
+	City       string     `gorm:"column:city;not null;type:varchar(255)" json:"city" binding:"required"`
+	PostalCode string     `gorm:"column:postal_code;not null;type:varchar(255)" json:"postalCode" binding:"required"`
+	Country    string     `gorm:"column:country;not null;type:varchar(2)" json:"country" binding:"required"`
+	Notes      string     `gorm:"column:notes;type:varchar(255)" json:"notes"`
+	IsDefault  bool       `gorm:"column:is_default;not null;default:false" json:"isDefault"`
+	Latitude   *float64   `gorm:"column:latitude;type:double" json:"latitude"`
+	Longitude  *float64   `gorm:"column:longitude;type:double" json:"longitude"`
+	CreatedAt  time.Time  `gorm:"column:created_at;type:DATETIME" json:"createdAt"`
+	ArchivedAt *time.Time `gorm:"column:archived_at;type:DATETIME" json:"archivedAt"`
+}
+
+func (Address) TableName() string {
+	return "client_address"
+}
+
+func (a *Address) BeforeCreate(tx *gorm.DB) (err error) {
+	a.ID = uuid.New().String()
+	return nil
+}
+
+// String is the address on one line, the way it is printed on labels and
+// geocoded.
+func (a *Address) String() string {
+	parts := []string{strings.TrimSpace(a.Street + " " + a.Number)}
+	for _, part := range []string{strings.TrimSpace(a.PostalCode + " " + a.City), a.Country} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,37 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CourrierZone binds a courier to the deliveries whose location contains
+// Pattern. It is used by the zone based assignment strategy.
+type CourrierZone struct {
+	ID         string    `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	CompanyID  string    `gorm:"column:company_id;not null;type:varchar(255)" json:"companyID"`
+	Company    *Company  `gorm:"foreignKey:CompanyID" json:"company"`
+	CourrierID string    `gorm:"column:courrier_id;not null;type:varchar(255)" json:"courrierID" binding:"required"`
+	Courrier   *Employee `gorm:"foreignKey:CourrierID" json:"courrier"`
+	Pattern    string    `gorm:"column:pattern;not null;type:varchar(255)" json:"pattern" binding:"required"`
+}
+
+func (CourrierZone) TableName() string {
+	return "courrier_zone"
+}
+
+func (z *CourrierZone) BeforeCreate(tx *gorm.DB) (err error) {
+	z.ID = uuid.New().String()
+	return nil
+}
+
+// AssignmentCursor remembers the last courier picked by the round-robin
+// strategy for an office, or for a whole company when it has no office.
+type AssignmentCursor struct {
+	ScopeID        string `gorm:"primaryKey;column:scope_id;type:varchar(255)"`
+	LastCourrierID string `gorm:"column:last_courrier_id;not null;type:varchar(255)"`
+}
+
+func (AssignmentCursor) TableName() string {
+	return "assignment_cursor"
+}
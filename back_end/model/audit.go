@@ -0,0 +1,31 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AuditLog struct {
+	ID         string          `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	ActorID    string          `gorm:"column:actor_id;index;type:varchar(255)" json:"actorID"`
+	ActorRole  string          `gorm:"column:actor_role;type:varchar(255)" json:"actorRole"`
+	Action     string          `gorm:"column:action;not null;type:varchar(255)" json:"action"`
+	EntityType string          `gorm:"column:entity_type;not null;index;type:varchar(255)" json:"entityType"`
+	EntityID   string          `gorm:"column:entity_id;not null;index;type:varchar(255)" json:"entityID"`
+	Changes    json.RawMessage `gorm:"column:changes;type:text" json:"changes" swaggertype:"object"`
+	IP         string          `gorm:"column:ip;type:varchar(255)" json:"ip"`
+	RequestID  string          `gorm:"column:request_id;type:varchar(255)" json:"requestID"`
+	CreatedAt  time.Time       `gorm:"column:created_at;not null;index;type:DATETIME" json:"createdAt"`
+}
+
+func (AuditLog) TableName() string {
+	return "audit_log"
+}
+
+func (a *AuditLog) BeforeCreate(tx *gorm.DB) (err error) {
+	a.ID = uuid.New().String()
+	return nil
+}
@@ -16,6 +16,8 @@ type Client struct {
 	Name  string `gorm:"column:client_name;not null;unique;type:varchar(255)" json:"name" binding:"required"`
 	Email string `gorm:"column:email;not null;unique;type:varchar(255)" json:"email" binding:"required"`
 	Phone string `gorm:"column:phone;not null;unique;type:varchar(255)" json:"phone" binding:"required"`
+
+	Addresses []Address `gorm:"foreignKey:ClientID" json:"addresses,omitempty"`
 }
 
 func (Client) TableName() string {
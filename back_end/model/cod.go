@@ -0,0 +1,105 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CODCollection is the cash a courier collected from the receiver of a cash
+// on delivery package. It is handed over to the company at the end of the
+// day and later paid out to the sender.
+type CODCollection struct {
+	ID          string    `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	PackageID   string    `gorm:"column:package_id;not null;unique;type:varchar(255)" json:"packageID"`
+	CourrierID  string    `gorm:"column:courrier_id;not null;index;type:varchar(255)" json:"courrierID"`
+	CompanyID   string    `gorm:"column:company_id;not null;index;type:varchar(255)" json:"companyID"`
+	SenderID    string    `gorm:"column:sender_id;not null;type:varchar(255)" json:"senderID"`
+	Amount      float64   `gorm:"column:amount;not null;type:decimal(12,2)" json:"amount"`
+	Fee         float64   `gorm:"column:fee;not null;type:decimal(12,2)" json:"fee"`
+	Currency    string    `gorm:"column:currency;not null;type:varchar(3)" json:"currency"`
+	CollectedAt time.Time `gorm:"column:collected_at;not null;type:DATETIME" json:"collectedAt"`
+	HandoverID  *string   `gorm:"column:handover_id;index;type:varchar(255)" json:"handoverID"`
+	PayoutID    *string   `gorm:"column:payout_id;index;type:varchar(255)" json:"payoutID"`
+}
+
+func (CODCollection) TableName() string {
+	return "cod_collection"
+}
+
+func (c *CODCollection) BeforeCreate(tx *gorm.DB) (err error) {
+	c.ID = uuid.New().String()
+	return nil
+}
+
+// CashHandover is a courier handing the cash collected in one currency on a
+// day over to the company. Handovers where the received amount does not
+// match the collections are flagged.
+type CashHandover struct {
+	ID             string          `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	CourrierID     string          `gorm:"column:courrier_id;not null;uniqueIndex:idx_handover_courrier_date;type:varchar(255)" json:"courrierID"`
+	Courrier       *Employee       `gorm:"foreignKey:CourrierID" json:"courrier"`
+	Date           time.Time       `gorm:"column:handover_date;not null;uniqueIndex:idx_handover_courrier_date;type:DATE" json:"date"`
+	Currency       string          `gorm:"column:currency;not null;uniqueIndex:idx_handover_courrier_date;type:varchar(3)" json:"currency"`
+	CompanyID      string          `gorm:"column:company_id;not null;index;type:varchar(255)" json:"companyID"`
+	ReceivedByID   string          `gorm:"column:received_by;type:varchar(255)" json:"receivedByID"`
+	ExpectedAmount float64         `gorm:"column:expected_amount;not null;type:decimal(12,2)" json:"expectedAmount"`
+	ReceivedAmount float64         `gorm:"column:received_amount;not null;type:decimal(12,2)" json:"receivedAmount"`
+	Discrepancy    float64         `gorm:"column:discrepancy;not null;type:decimal(12,2)" json:"discrepancy"`
+	Flagged        bool            `gorm:"column:flagged;not null" json:"flagged"`
+	Note           string          `gorm:"column:note;type:varchar(255)" json:"note"`
+	CreatedAt      time.Time       `gorm:"column:created_at;type:DATETIME" json:"createdAt"`
+	Collections    []CODCollection `gorm:"foreignKey:HandoverID" json:"collections"`
+}
+
+func (CashHandover) TableName() string {
+	return "cash_handover"
+}
+
+func (h *CashHandover) BeforeCreate(tx *gorm.DB) (err error) {
+	h.ID = uuid.New().String()
+	return nil
+}
+
+// PayoutBatch pays the cash collected for a sender, minus the COD fees, out
+// to the sender.
+type PayoutBatch struct {
+	ID          string          `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	CompanyID   string          `gorm:"column:company_id;not null;index;type:varchar(255)" json:"companyID"`
+	SenderID    string          `gorm:"column:sender_id;not null;index;type:varchar(255)" json:"senderID"`
+	Sender      *Client         `gorm:"foreignKey:SenderID" json:"sender"`
+	Currency    string          `gorm:"column:currency;not null;type:varchar(3)" json:"currency"`
+	Collected   float64         `gorm:"column:collected;not null;type:decimal(12,2)" json:"collected"`
+	Fees        float64         `gorm:"column:fees;not null;type:decimal(12,2)" json:"fees"`
+	Amount      float64         `gorm:"column:amount;not null;type:decimal(12,2)" json:"amount"`
+	CreatedAt   time.Time       `gorm:"column:created_at;type:DATETIME" json:"createdAt"`
+	PaidAt      *time.Time      `gorm:"column:paid_at;type:DATETIME" json:"paidAt"`
+	Collections []CODCollection `gorm:"foreignKey:PayoutID" json:"collections"`
+}
+
+func (PayoutBatch) TableName() string {
+	return "payout_batch"
+}
+
+func (p *PayoutBatch) BeforeCreate(tx *gorm.DB) (err error) {
+	p.ID = uuid.New().String()
+	return nil
+}
+
+// RevenueReport splits what a company earned in a period by source.
+type RevenueReport struct {
+	CompanyID         string  `json:"companyID"`
+	StartDate         string  `json:"startDate"`
+	EndDate           string  `json:"endDate"`
+	DeliveryRevenue   float64 `json:"deliveryRevenue"`
+	CODFees           float64 `json:"codFees"`
+	InsurancePremiums float64 `json:"insurancePremiums"`
+	StorageFees       float64 `json:"storageFees"`
+	// ClaimPayouts are the insurance claims paid in the period, as a
+	// negative amount
+	ClaimPayouts float64 `json:"claimPayouts"`
+	Total        float64 `json:"total"`
+	CODCollected float64 `json:"codCollected"`
+	CODPaidOut   float64 `json:"codPaidOut"`
+}
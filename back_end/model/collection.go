@@ -0,0 +1,47 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Collection is a visit of a courier to a sender's address to collect
+// parcels within a time window. The requested packages linked to it are
+// accepted when the courier completes it.
+type Collection struct {
+	ID          string     `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	CompanyID   string     `gorm:"column:company_id;not null;index;type:varchar(255)" json:"companyID"`
+	SenderID    string     `gorm:"column:sender_id;not null;index;type:varchar(255)" json:"senderID"`
+	Sender      *Client    `gorm:"foreignKey:SenderID" json:"sender"`
+	AddressID   string     `gorm:"column:address_id;not null;type:varchar(255)" json:"addressID"`
+	Address     *Address   `gorm:"foreignKey:AddressID" json:"address"`
+	WindowStart time.Time  `gorm:"column:window_start;not null;index;type:DATETIME" json:"windowStart"`
+	WindowEnd   time.Time  `gorm:"column:window_end;not null;type:DATETIME" json:"windowEnd"`
+	Parcels     int        `gorm:"column:parcels;not null" json:"parcels"`
+	Note        string     `gorm:"column:note;type:varchar(255)" json:"note"`
+	CourrierID  *string    `gorm:"column:courrier_id;index;type:varchar(255)" json:"courrierID"`
+	Courrier    *Employee  `gorm:"foreignKey:CourrierID" json:"courrier"`
+	Status      string     `gorm:"column:status;not null;type:varchar(255)" json:"status"`
+	CompletedAt *time.Time `gorm:"column:completed_at;type:DATETIME" json:"completedAt"`
+	CreatedAt   time.Time  `gorm:"column:created_at;type:DATETIME" json:"createdAt"`
+	Packages    []Package  `gorm:"foreignKey:CollectionID" json:"packages"`
+}
+
+func (Collection) TableName() string {
+	return "collection"
+}
+
+func (c *Collection) BeforeCreate(tx *gorm.DB) (err error) {
+	c.ID = uuid.New().String()
+	return nil
+}
+
+// CourrierDay is what a courier does on a day: the collections assigned to
+// them and the packages they deliver.
+type CourrierDay struct {
+	Date        string       `json:"date"`
+	Collections []Collection `json:"collections"`
+	Deliveries  []Package    `json:"deliveries"`
+}
@@ -1,6 +1,8 @@
 package model
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
@@ -9,6 +11,40 @@ type Company struct {
 	ID      string  `gorm:"primaryKey;type:varchar(255)" json:"id"`
 	Name    string  `gorm:"column:company_name;not null;unique;type:varchar(255)" json:"name" binding:"required"`
 	Revenue float64 `gorm:"column:revenue;not null;type:float(8)" json:"revenue"`
+
+	AssignmentStrategy string `gorm:"column:assignment_strategy;type:varchar(255)" json:"assignmentStrategy"`
+
+	MaxDeliveryAttempts  int    `gorm:"column:max_delivery_attempts;not null;default:3" json:"maxDeliveryAttempts"`
+	FailedDeliveryAction string `gorm:"column:failed_delivery_action;type:varchar(255)" json:"failedDeliveryAction"`
+
+	// ReturnPricing decides what a return shipment costs, ReturnPriceValue is
+	// the percentage of the original price or the flat price
+	ReturnPricing    string  `gorm:"column:return_pricing;type:varchar(255)" json:"returnPricing"`
+	ReturnPriceValue float64 `gorm:"column:return_price_value;not null;default:0;type:float(8)" json:"returnPriceValue"`
+
+	// CODFeePercentage of the collected amount is charged for cash on delivery
+	CODFeePercentage float64 `gorm:"column:cod_fee_percentage;not null;default:0;type:float(8)" json:"codFeePercentage"`
+
+	// Insured packages pay InsuranceRatePercentage of their declared value,
+	// but at least InsuranceMinPremium
+	InsuranceRatePercentage float64 `gorm:"column:insurance_rate_percentage;not null;default:0;type:float(8)" json:"insuranceRatePercentage"`
+	InsuranceMinPremium     float64 `gorm:"column:insurance_min_premium;not null;default:0;type:float(8)" json:"insuranceMinPremium"`
+
+	// Office deliveries are stored for StorageDays before they are returned
+	// to the sender, zero keeps them until they are picked up. Every day past
+	// StorageFreeDays costs StorageFeePerDay.
+	StorageDays      int     `gorm:"column:storage_days;not null;default:14" json:"storageDays"`
+	StorageFreeDays  int     `gorm:"column:storage_free_days;not null;default:3" json:"storageFreeDays"`
+	StorageFeePerDay float64 `gorm:"column:storage_fee_per_day;not null;default:0;type:float(8)" json:"storageFeePerDay"`
+
+	// Locker deliveries wait LockerPickupDays in their compartment before
+	// they are taken back to the locker's office, zero never expires them
+	LockerPickupDays int `gorm:"column:locker_pickup_days;not null;default:3" json:"lockerPickupDays"`
+
+	// VolumetricDivisor turns the volume of a package in cm3 into kg
+	VolumetricDivisor float64 `gorm:"column:volumetric_divisor;not null;default:5000;type:float(8)" json:"volumetricDivisor"`
+
+	ArchivedAt *time.Time `gorm:"column:archived_at;type:DATETIME" json:"archivedAt"`
 }
 
 func (Company) TableName() string {
@@ -19,3 +55,14 @@ func (c *Company) BeforeCreate(tx *gorm.DB) (err error) {
 	c.ID = uuid.New().String()
 	return nil
 }
+
+// CompanyDeletionPreview lists everything that still depends on a company
+// and would be affected by deleting it.
+type CompanyDeletionPreview struct {
+	Company             Company    `json:"company"`
+	Offices             []Office   `json:"offices"`
+	Employees           []Employee `json:"employees"`
+	Packages            []Package  `json:"packages"`
+	UndeliveredPackages []Package  `json:"undeliveredPackages"`
+	CanDeleteOutright   bool       `json:"canDeleteOutright"`
+}
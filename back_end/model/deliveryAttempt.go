@@ -0,0 +1,28 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DeliveryAttempt is a failed try of a courier to hand a package over.
+type DeliveryAttempt struct {
+	ID          string    `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	PackageID   string    `gorm:"column:package_id;not null;index;type:varchar(255)" json:"packageID"`
+	CourrierID  string    `gorm:"column:courrier_id;not null;type:varchar(255)" json:"courrierID"`
+	Number      int       `gorm:"column:attempt_number;not null" json:"number"`
+	Reason      string    `gorm:"column:reason;not null;type:varchar(255)" json:"reason"`
+	Note        string    `gorm:"column:note;type:varchar(255)" json:"note"`
+	AttemptedAt time.Time `gorm:"column:attempted_at;not null;type:DATETIME" json:"attemptedAt"`
+}
+
+func (DeliveryAttempt) TableName() string {
+	return "delivery_attempt"
+}
+
+func (a *DeliveryAttempt) BeforeCreate(tx *gorm.DB) (err error) {
+	a.ID = uuid.New().String()
+	return nil
+}
@@ -0,0 +1,55 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DeliveryRun is the manifest of the packages a courier delivers on a given
+// day, in the order the stops are visited.
+type DeliveryRun struct {
+	ID         string            `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	CourrierID string            `gorm:"column:courrier_id;not null;index;type:varchar(255)" json:"courrierID"`
+	Courrier   *Employee         `gorm:"foreignKey:CourrierID" json:"courrier"`
+	CompanyID  string            `gorm:"column:company_id;not null;type:varchar(255)" json:"companyID"`
+	Company    *Company          `gorm:"foreignKey:CompanyID" json:"company"`
+	OfficeID   *string           `gorm:"column:office_id;type:varchar(255)" json:"officeID"`
+	Office     *Office           `gorm:"foreignKey:OfficeID" json:"office"`
+	Date       time.Time         `gorm:"column:run_date;not null;type:DATE" json:"date"`
+	Status     string            `gorm:"column:status;not null;type:varchar(255)" json:"status"`
+	StartedAt  *time.Time        `gorm:"column:started_at;type:DATETIME" json:"startedAt"`
+	FinishedAt *time.Time        `gorm:"column:finished_at;type:DATETIME" json:"finishedAt"`
+	Stops      []DeliveryRunStop `gorm:"foreignKey:RunID" json:"stops"`
+}
+
+func (DeliveryRun) TableName() string {
+	return "delivery_run"
+}
+
+func (r *DeliveryRun) BeforeCreate(tx *gorm.DB) (err error) {
+	r.ID = uuid.New().String()
+	return nil
+}
+
+type DeliveryRunStop struct {
+	ID             string     `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	RunID          string     `gorm:"column:run_id;not null;index;type:varchar(255)" json:"runID"`
+	PackageID      string     `gorm:"column:package_id;not null;index;type:varchar(255)" json:"packageID"`
+	Package        *Package   `gorm:"foreignKey:PackageID" json:"package"`
+	Sequence       int        `gorm:"column:sequence;not null" json:"sequence"`
+	Outcome        string     `gorm:"column:outcome;not null;type:varchar(255)" json:"outcome"`
+	Note           string     `gorm:"column:note;type:varchar(255)" json:"note"`
+	PreviousStatus string     `gorm:"column:previous_status;type:varchar(255)" json:"-"`
+	CompletedAt    *time.Time `gorm:"column:completed_at;type:DATETIME" json:"completedAt"`
+}
+
+func (DeliveryRunStop) TableName() string {
+	return "delivery_run_stop"
+}
+
+func (s *DeliveryRunStop) BeforeCreate(tx *gorm.DB) (err error) {
+	s.ID = uuid.New().String()
+	return nil
+}
@@ -1,6 +1,8 @@
 package model
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
@@ -21,6 +23,8 @@ type Employee struct {
 	Company   *Company `gorm:"foreignKey:CompanyID" json:"company"`
 	OfficeID  *string  `gorm:"column:office_id;type:varchar(255)" json:"officeId"`
 	Office    *Office  `gorm:"foreignKey:OfficeID" json:"office"`
+
+	ArchivedAt *time.Time `gorm:"column:archived_at;type:DATETIME" json:"archivedAt"`
 }
 
 func (Employee) TableName() string {
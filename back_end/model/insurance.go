@@ -0,0 +1,58 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// InsuranceClaim asks for compensation of an insured package that was lost
+// or damaged.
+type InsuranceClaim struct {
+	ID             string          `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	PackageID      string          `gorm:"column:package_id;not null;index;type:varchar(255)" json:"packageID"`
+	Package        *Package        `gorm:"foreignKey:PackageID" json:"package"`
+	CompanyID      string          `gorm:"column:company_id;not null;index;type:varchar(255)" json:"companyID"`
+	ClaimantID     string          `gorm:"column:claimant_id;not null;type:varchar(255)" json:"claimantID"`
+	Type           string          `gorm:"column:claim_type;not null;type:varchar(255)" json:"type"`
+	Description    string          `gorm:"column:description;type:text" json:"description"`
+	ClaimedAmount  float64         `gorm:"column:claimed_amount;not null;type:decimal(12,2)" json:"claimedAmount"`
+	ApprovedAmount float64         `gorm:"column:approved_amount;not null;default:0;type:decimal(12,2)" json:"approvedAmount"`
+	Status         string          `gorm:"column:status;not null;type:varchar(255)" json:"status"`
+	ResolutionNote string          `gorm:"column:resolution_note;type:varchar(255)" json:"resolutionNote"`
+	ReviewedByID   *string         `gorm:"column:reviewed_by;type:varchar(255)" json:"reviewedByID"`
+	CreatedAt      time.Time       `gorm:"column:created_at;type:DATETIME" json:"createdAt"`
+	UpdatedAt      time.Time       `gorm:"column:updated_at;type:DATETIME" json:"updatedAt"`
+	PaidAt         *time.Time      `gorm:"column:paid_at;type:DATETIME" json:"paidAt"`
+	Evidence       []ClaimEvidence `gorm:"foreignKey:ClaimID" json:"evidence"`
+}
+
+func (InsuranceClaim) TableName() string {
+	return "insurance_claim"
+}
+
+func (c *InsuranceClaim) BeforeCreate(tx *gorm.DB) (err error) {
+	c.ID = uuid.New().String()
+	return nil
+}
+
+// ClaimEvidence is a file attached to a claim, kept in blob storage.
+type ClaimEvidence struct {
+	ID          string    `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	ClaimID     string    `gorm:"column:claim_id;not null;index;type:varchar(255)" json:"claimID"`
+	Key         string    `gorm:"column:blob_key;not null;type:varchar(255)" json:"-"`
+	FileName    string    `gorm:"column:file_name;type:varchar(255)" json:"fileName"`
+	ContentType string    `gorm:"column:content_type;type:varchar(255)" json:"contentType"`
+	UploadedBy  string    `gorm:"column:uploaded_by;type:varchar(255)" json:"uploadedBy"`
+	CreatedAt   time.Time `gorm:"column:created_at;type:DATETIME" json:"createdAt"`
+}
+
+func (ClaimEvidence) TableName() string {
+	return "claim_evidence"
+}
+
+func (e *ClaimEvidence) BeforeCreate(tx *gorm.DB) (err error) {
+	e.ID = uuid.New().String()
+	return nil
+}
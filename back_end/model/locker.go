@@ -0,0 +1,75 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Locker is an automated parcel locker packages can be delivered to instead
+// of an office or an address. Parcels that are not picked up in time are
+// taken back to the locker's office.
+type Locker struct {
+	ID           string              `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	CompanyID    string              `gorm:"column:company_id;not null;index;type:varchar(255)" json:"companyID" binding:"required"`
+	Name         string              `gorm:"column:name;not null;type:varchar(255)" json:"name" binding:"required"`
+	Location     string              `gorm:"column:location;not null;type:varchar(255)" json:"location" binding:"required"`
+	Latitude     *float64            `gorm:"column:latitude;type:double" json:"latitude"`
+	Longitude    *float64            `gorm:"column:longitude;type:double" json:"longitude"`
+	OfficeID     string              `gorm:"column:office_id;not null;type:varchar(255)" json:"officeID" binding:"required"`
+	Office       *Office             `gorm:"foreignKey:OfficeID" json:"office"`
+	Compartments []LockerCompartment `gorm:"foreignKey:LockerID" json:"compartments"`
+	CreatedAt    time.Time           `gorm:"column:created_at;type:DATETIME" json:"createdAt"`
+	// CodeFailures counts the wrong codes entered since the last code that
+	// worked
+	CodeFailures int        `gorm:"column:code_failures;not null;default:0" json:"-"`
+	LockedUntil  *time.Time `gorm:"column:locked_until;type:DATETIME" json:"lockedUntil"`
+}
+
+func (Locker) TableName() string {
+	return "locker"
+}
+
+func (l *Locker) BeforeCreate(tx *gorm.DB) (err error) {
+	l.ID = uuid.New().String()
+	return nil
+}
+
+// LockerCompartment is one door of a locker, it holds at most one package
+// from its reservation until the package is taken out.
+type LockerCompartment struct {
+	ID        string  `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	LockerID  string  `gorm:"column:locker_id;not null;index;type:varchar(255)" json:"lockerID"`
+	Number    int     `gorm:"column:number;not null" json:"number"`
+	Size      string  `gorm:"column:size;not null;type:varchar(255)" json:"size"`
+	PackageID *string `gorm:"column:package_id;index;type:varchar(255)" json:"packageID"`
+}
+
+func (LockerCompartment) TableName() string {
+	return "locker_compartment"
+}
+
+func (c *LockerCompartment) BeforeCreate(tx *gorm.DB) (err error) {
+	c.ID = uuid.New().String()
+	return nil
+}
+
+// LockerOpening is the compartment a code opened at a locker and the status
+// of the package in it afterwards.
+type LockerOpening struct {
+	LockerID       string `json:"lockerID"`
+	Compartment    int    `json:"compartment"`
+	TrackingNumber string `json:"trackingNumber"`
+	Status         string `json:"status"`
+}
+
+// LockerAccess is what the receiver or the courier needs to open the
+// compartment of a package.
+type LockerAccess struct {
+	PackageID   string     `json:"packageID"`
+	LockerID    string     `json:"lockerID"`
+	Compartment int        `json:"compartment"`
+	Code        string     `json:"code"`
+	Deadline    *time.Time `json:"deadline"`
+}
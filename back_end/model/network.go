@@ -0,0 +1,82 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Linehaul is a scheduled connection from one office or hub to another,
+// packages between offices travel along a path of linehauls.
+type Linehaul struct {
+	ID           string  `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	CompanyID    string  `gorm:"column:company_id;not null;index;type:varchar(255)" json:"companyID" binding:"required"`
+	FromOfficeID string  `gorm:"column:from_office_id;not null;type:varchar(255)" json:"fromOfficeID" binding:"required"`
+	FromOffice   *Office `gorm:"foreignKey:FromOfficeID" json:"fromOffice"`
+	ToOfficeID   string  `gorm:"column:to_office_id;not null;type:varchar(255)" json:"toOfficeID" binding:"required"`
+	ToOffice     *Office `gorm:"foreignKey:ToOfficeID" json:"toOffice"`
+	TransitHours float64 `gorm:"column:transit_hours;not null;type:float(8)" json:"transitHours" binding:"required,gt=0"`
+	Active       bool    `gorm:"column:active;not null;default:true" json:"active"`
+}
+
+func (Linehaul) TableName() string {
+	return "linehaul"
+}
+
+func (l *Linehaul) BeforeCreate(tx *gorm.DB) (err error) {
+	l.ID = uuid.New().String()
+	return nil
+}
+
+// PackageHop is one leg of the planned path of a package from the office it
+// was accepted at to the office it leaves for the receiver from.
+type PackageHop struct {
+	ID              string  `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	PackageID       string  `gorm:"column:package_id;not null;index;type:varchar(255)" json:"packageID"`
+	Sequence        int     `gorm:"column:sequence;not null" json:"sequence"`
+	LinehaulID      string  `gorm:"column:linehaul_id;not null;type:varchar(255)" json:"linehaulID"`
+	FromOfficeID    string  `gorm:"column:from_office_id;not null;type:varchar(255)" json:"fromOfficeID"`
+	ToOfficeID      string  `gorm:"column:to_office_id;not null;type:varchar(255)" json:"toOfficeID"`
+	Status          string  `gorm:"column:status;not null;type:varchar(255)" json:"status"`
+	TransferBatchID *string `gorm:"column:transfer_batch_id;index;type:varchar(255)" json:"transferBatchID"`
+}
+
+func (PackageHop) TableName() string {
+	return "package_hop"
+}
+
+func (h *PackageHop) BeforeCreate(tx *gorm.DB) (err error) {
+	h.ID = uuid.New().String()
+	return nil
+}
+
+// TransferBatch is a manifested group of packages moving together from one
+// office to another, it is scanned on departure and on arrival.
+type TransferBatch struct {
+	ID           string       `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	CompanyID    string       `gorm:"column:company_id;not null;index;type:varchar(255)" json:"companyID"`
+	FromOfficeID string       `gorm:"column:from_office_id;not null;index;type:varchar(255)" json:"fromOfficeID"`
+	ToOfficeID   string       `gorm:"column:to_office_id;not null;index;type:varchar(255)" json:"toOfficeID"`
+	Status       string       `gorm:"column:status;not null;type:varchar(255)" json:"status"`
+	CreatedByID  string       `gorm:"column:created_by;type:varchar(255)" json:"createdByID"`
+	CreatedAt    time.Time    `gorm:"column:created_at;type:DATETIME" json:"createdAt"`
+	DepartedAt   *time.Time   `gorm:"column:departed_at;type:DATETIME" json:"departedAt"`
+	ArrivedAt    *time.Time   `gorm:"column:arrived_at;type:DATETIME" json:"arrivedAt"`
+	Hops         []PackageHop `gorm:"foreignKey:TransferBatchID" json:"hops"`
+}
+
+func (TransferBatch) TableName() string {
+	return "transfer_batch"
+}
+
+func (b *TransferBatch) BeforeCreate(tx *gorm.DB) (err error) {
+	b.ID = uuid.New().String()
+	return nil
+}
+
+// NetworkPath is the fastest path between two offices.
+type NetworkPath struct {
+	Linehauls    []Linehaul `json:"linehauls"`
+	TransitHours float64    `json:"transitHours"`
+}
@@ -0,0 +1,79 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Notification is a message to a client about one of their packages. It is
+// queued with the package change that caused it and sent later, the sent
+// ones are kept as the notification log.
+type Notification struct {
+	ID        string     `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	ClientID  string     `gorm:"column:client_id;not null;index;type:varchar(255)" json:"clientID"`
+	Client    *Client    `gorm:"foreignKey:ClientID" json:"-"`
+	PackageID string     `gorm:"column:package_id;not null;index;type:varchar(255)" json:"packageID"`
+	Package   *Package   `gorm:"foreignKey:PackageID" json:"-"`
+	Template  string     `gorm:"column:template;not null;type:varchar(255)" json:"template"`
+	Channel   string     `gorm:"column:channel;not null;type:varchar(255)" json:"channel"`
+	Recipient string     `gorm:"column:recipient;type:varchar(255)" json:"recipient"`
+	Subject   string     `gorm:"column:subject;type:varchar(255)" json:"subject"`
+	Body      string     `gorm:"column:body;type:text" json:"body"`
+	Status    string     `gorm:"column:status;not null;index;type:varchar(255)" json:"status"`
+	Attempts  int        `gorm:"column:attempts;not null;default:0" json:"attempts"`
+	Error     string     `gorm:"column:error;type:text" json:"error"`
+	CreatedAt time.Time  `gorm:"column:created_at;not null;type:DATETIME(3)" json:"createdAt"`
+	SentAt    *time.Time `gorm:"column:sent_at;type:DATETIME(3)" json:"sentAt"`
+}
+
+func (Notification) TableName() string {
+	return "notification_log"
+}
+
+func (n *Notification) BeforeCreate(tx *gorm.DB) (err error) {
+	n.ID = uuid.New().String()
+	return nil
+}
+
+// NotificationPreference is how a client wants to hear about their
+// packages. Clients without one get emails only.
+type NotificationPreference struct {
+	ClientID string `gorm:"primaryKey;type:varchar(255)" json:"clientID"`
+	Email    bool   `gorm:"column:email;not null;default:true" json:"email"`
+	SMS      bool   `gorm:"column:sms;not null;default:false" json:"sms"`
+}
+
+func (NotificationPreference) TableName() string {
+	return "notification_preference"
+}
+
+// InboxNotification is an entry in the in-app inbox of a client or an
+// employee, about one of their packages or a change made by the staff.
+type InboxNotification struct {
+	ID        string     `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	UserID    string     `gorm:"column:user_id;not null;index;type:varchar(255)" json:"userID"`
+	Type      string     `gorm:"column:notification_type;not null;type:varchar(255)" json:"type"`
+	Title     string     `gorm:"column:title;not null;type:varchar(255)" json:"title"`
+	Body      string     `gorm:"column:body;type:text" json:"body"`
+	PackageID *string    `gorm:"column:package_id;index;type:varchar(255)" json:"packageID"`
+	ReadAt    *time.Time `gorm:"column:read_at;type:DATETIME(3)" json:"readAt"`
+	CreatedAt time.Time  `gorm:"column:created_at;not null;type:DATETIME(3)" json:"createdAt"`
+}
+
+func (InboxNotification) TableName() string {
+	return "notification"
+}
+
+func (n *InboxNotification) BeforeCreate(tx *gorm.DB) (err error) {
+	n.ID = uuid.New().String()
+	return nil
+}
+
+// Inbox is a page of the inbox of a user with how many entries are unread
+// in total.
+type Inbox struct {
+	Unread        int64               `json:"unread"`
+	Notifications []InboxNotification `json:"notifications"`
+}
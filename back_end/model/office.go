@@ -1,6 +1,8 @@
 package model
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
@@ -10,6 +12,38 @@ type Office struct {
 	Location  string   `gorm:"column:location;not null;type:varchar(255)" json:"location" binding:"required"`
 	CompanyID string   `gorm:"column:company_id;not null;type:varchar(255)" json:"companyID" binding:"required"`
 	Company   *Company `gorm:"foreignKey:CompanyID" json:"company"`
+	Latitude  *float64 `gorm:"column:latitude;type:double" json:"latitude"`
+	Longitude *float64 `gorm:"column:longitude;type:double" json:"longitude"`
+
+	// Location is the display name, the address is kept in its parts
+	Street     string `gorm:"column:street;type:varchar(255)" json:"street"`
+	City       string `gorm:"column:city;type:varchar(255)" json:"city"`
+	PostalCode string `gorm:"column:postal_code;type:varchar(255)" json:"postalCode"`
+	Country    string `gorm:"column:country;type:varchar(255)" json:"country"`
+
+	// OpeningHours lists when the office is open during the week, offices
+	// without opening hours are always open. OpenNow also takes the office's
+	// holidays into account.
+	OpeningHours      []OpeningHours `gorm:"column:opening_hours;serializer:json;type:text" json:"openingHours"`
+	OpenNow           bool           `gorm:"-" json:"openNow"`
+	PermanentlyClosed bool           `gorm:"column:permanently_closed;not null;default:false" json:"permanentlyClosed"`
+
+	// Capacity is how many parcels the office can store, zero means no limit.
+	// StoredParcels counts the undelivered packages to be picked up there.
+	Capacity      int `gorm:"column:capacity;not null;default:0" json:"capacity"`
+	StoredParcels int `gorm:"-" json:"storedParcels"`
+
+	// Hubs sort packages between offices, they are not open to clients
+	IsHub bool `gorm:"column:is_hub;not null;default:false" json:"isHub"`
+
+	// MaxWeight (kg) and MaxDimension (cm, longest side) limit the packages
+	// the office can handle, zero means no limit. RejectedPackageTypes lists
+	// the package type codes the office does not accept.
+	MaxWeight            float64  `gorm:"column:max_weight;not null;default:0;type:float(8)" json:"maxWeight"`
+	MaxDimension         float64  `gorm:"column:max_dimension;not null;default:0;type:float(8)" json:"maxDimension"`
+	RejectedPackageTypes []string `gorm:"column:rejected_package_types;serializer:json;type:text" json:"rejectedPackageTypes"`
+
+	ArchivedAt *time.Time `gorm:"column:archived_at;type:DATETIME" json:"archivedAt"`
 }
 
 func (Office) TableName() string {
@@ -20,3 +54,34 @@ func (o *Office) BeforeCreate(tx *gorm.DB) (err error) {
 	o.ID = uuid.New().String()
 	return nil
 }
+
+// OpeningHours is when an office is open on a weekday, 0 is Sunday. Open and
+// Close are times of day like 08:30.
+type OpeningHours struct {
+	Weekday int    `json:"weekday"`
+	Open    string `json:"open"`
+	Close   string `json:"close"`
+}
+
+// OfficeHoliday is a day the office is closed.
+type OfficeHoliday struct {
+	ID       string    `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	OfficeID string    `gorm:"column:office_id;not null;index;type:varchar(255)" json:"officeID"`
+	Date     time.Time `gorm:"column:holiday_date;not null;type:DATE" json:"date"`
+	Name     string    `gorm:"column:name;type:varchar(255)" json:"name"`
+}
+
+func (OfficeHoliday) TableName() string {
+	return "office_holiday"
+}
+
+func (h *OfficeHoliday) BeforeCreate(tx *gorm.DB) (err error) {
+	h.ID = uuid.New().String()
+	return nil
+}
+
+// NearbyOffice is an office with its distance from the searched point.
+type NearbyOffice struct {
+	Office
+	DistanceKm float64 `json:"distanceKm"`
+}
@@ -3,38 +3,128 @@ package model
 import (
 	"time"
 
-	"logistic_company/config"
-
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
 type Package struct {
-	ID                  string     `gorm:"primaryKey;type:varchar(255)" json:"id"`
-	SenderID            string     `gorm:"column:sender_id;not null;type:varchar(255)" json:"senderID" binding:"required"`
-	Sender              *Client    `gorm:"foreignKey:SenderID" json:"sender"`
-	ReceiverID          string     `gorm:"column:receiver_id;not null;type:varchar(255)" json:"receiverID" binding:"required"`
-	Receiver            *Client    `gorm:"foreignKey:ReceiverID" json:"receiver"`
-	Weight              float64    `gorm:"column:weight;not null;type:float(8)" json:"weight" binding:"required"`
-	Price               float64    `gorm:"column:price;not null;type:float(8)" json:"price"`
+	ID         string  `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	SenderID   string  `gorm:"column:sender_id;not null;type:varchar(255)" json:"senderID" binding:"required"`
+	Sender     *Client `gorm:"foreignKey:SenderID" json:"sender"`
+	ReceiverID *string `gorm:"column:receiver_id;type:varchar(255)" json:"receiverID"`
+	Receiver   *Client `gorm:"foreignKey:ReceiverID" json:"receiver"`
+
+	// Receivers without an account are addressed by their name and phone or
+	// email. The package is linked to their account once they registered and
+	// verified that phone or email.
+	ReceiverName  string `gorm:"column:receiver_name;type:varchar(255)" json:"receiverName"`
+	ReceiverPhone string `gorm:"column:receiver_phone;index;type:varchar(255)" json:"receiverPhone"`
+	ReceiverEmail string `gorm:"column:receiver_email;index;type:varchar(255)" json:"receiverEmail"`
+
+	Weight float64 `gorm:"column:weight;not null;type:float(8)" json:"weight" binding:"required"`
+
+	// Dimensions are in cm, the package is priced by its chargeable weight,
+	// the larger of its actual and volumetric weight
+	Length           float64      `gorm:"column:length;not null;default:0;type:float(8)" json:"length"`
+	Width            float64      `gorm:"column:width;not null;default:0;type:float(8)" json:"width"`
+	Height           float64      `gorm:"column:height;not null;default:0;type:float(8)" json:"height"`
+	VolumetricWeight float64      `gorm:"column:volumetric_weight;not null;default:0;type:float(8)" json:"volumetricWeight"`
+	ChargeableWeight float64      `gorm:"column:chargeable_weight;not null;default:0;type:float(8)" json:"chargeableWeight"`
+	PackageTypeID    *string      `gorm:"column:package_type_id;type:varchar(255)" json:"packageTypeID"`
+	PackageType      *PackageType `gorm:"foreignKey:PackageTypeID" json:"packageType"`
+
+	// Parcels of a shipment share its tracking number followed by their
+	// parcel number
+	TrackingNumber string  `gorm:"column:tracking_number;uniqueIndex;type:varchar(255)" json:"trackingNumber"`
+	ShipmentID     *string `gorm:"column:shipment_id;index;type:varchar(255)" json:"shipmentID"`
+	ParcelNumber   int     `gorm:"column:parcel_number;not null;default:0" json:"parcelNumber"`
+
+	Price float64 `gorm:"column:price;not null;type:float(8)" json:"price"`
+
+	// Packages requested by their sender online are priced from the
+	// sender's estimate until an employee weighs them at acceptance.
+	// PickupAddressID is the sender's address a courier collects them from,
+	// packages without one are dropped off at OfficeAcceptedAtID.
+	EstimatedWeight float64  `gorm:"column:estimated_weight;not null;default:0;type:float(8)" json:"estimatedWeight"`
+	QuotedPrice     float64  `gorm:"column:quoted_price;not null;default:0;type:decimal(12,2)" json:"quotedPrice"`
+	PickupAddressID *string  `gorm:"column:pickup_address_id;type:varchar(255)" json:"pickupAddressID"`
+	PickupAddress   *Address `gorm:"foreignKey:PickupAddressID" json:"pickupAddress"`
+	CollectionID    *string  `gorm:"column:collection_id;index;type:varchar(255)" json:"collectionID"`
+
+	// CODAmount is the cash the courier collects from the receiver, CODFee
+	// is what the company charges the sender for collecting it
+	CODAmount   float64 `gorm:"column:cod_amount;not null;default:0;type:decimal(12,2)" json:"codAmount"`
+	CODCurrency string  `gorm:"column:cod_currency;type:varchar(3)" json:"codCurrency"`
+	CODFee      float64 `gorm:"column:cod_fee;not null;default:0;type:decimal(12,2)" json:"codFee"`
+
+	DeclaredValue    float64 `gorm:"column:declared_value;not null;default:0;type:decimal(12,2)" json:"declaredValue"`
+	Insured          bool    `gorm:"column:insured;not null;default:false" json:"insured"`
+	InsurancePremium float64 `gorm:"column:insurance_premium;not null;default:0;type:decimal(12,2)" json:"insurancePremium"`
+
 	IsDeliveredToOffice bool       `gorm:"column:is_delivered_to_office;not null;type:bool" json:"isDeliveredToOffice" binding:"required"`
 	DeliveryStatus      string     `gorm:"column:delivery_status;not null;type:varchar(255)" json:"deliveryStatus"`
 	DeliveryDate        *time.Time `gorm:"column:delivery_date;type:DATETIME" json:"deliveryDate"`
 
-	RegisteredByID string    `gorm:"column:registered_by;type:varchar(255)" json:"registeredByID" binding:"required"`
+	// Requested shipments have no registering employee, courrier and
+	// accepting office until they are accepted
+	RegisteredByID *string   `gorm:"column:registered_by;type:varchar(255)" json:"registeredByID"`
 	RegisteredBy   *Employee `gorm:"foreignKey:RegisteredByID" json:"registeredBy"`
-	CourrierID     string    `gorm:"column:courrier_id;not null;type:varchar(255)" json:"courrierID" binding:"required"`
+	CourrierID     *string   `gorm:"column:courrier_id;type:varchar(255)" json:"courrierID"`
 	Courrier       *Employee `gorm:"foreignKey:CourrierID" json:"courrier"`
 
-	OfficeAcceptedAtID string  `gorm:"column:office_accepted_at;not null;type:varchar(255)" json:"officeAcceptedAtID" binding:"required"`
+	AssignmentStrategy string `gorm:"column:assignment_strategy;type:varchar(255)" json:"assignmentStrategy"`
+	AssignmentReason   string `gorm:"column:assignment_reason;type:varchar(255)" json:"assignmentReason"`
+
+	OfficeAcceptedAtID *string `gorm:"column:office_accepted_at;type:varchar(255)" json:"officeAcceptedAtID" binding:"required"`
 	OfficeAcceptedAt   *Office `gorm:"foreignKey:OfficeAcceptedAtID" json:"officeAcceptedAt"`
 
-	DeliveryLocation *string `gorm:"column:delivery_location;type:varchar(255)" json:"deliveryLocation" binding:"required"`
+	DeliveryLocation    *string    `gorm:"column:delivery_location;type:varchar(255)" json:"deliveryLocation"`
+	DeliveryLatitude    *float64   `gorm:"column:delivery_latitude;type:double" json:"deliveryLatitude"`
+	DeliveryLongitude   *float64   `gorm:"column:delivery_longitude;type:double" json:"deliveryLongitude"`
+	DeliveryWindowStart *time.Time `gorm:"column:delivery_window_start;type:DATETIME" json:"deliveryWindowStart"`
+	DeliveryWindowEnd   *time.Time `gorm:"column:delivery_window_end;type:DATETIME" json:"deliveryWindowEnd"`
+	RedeliveryDate      *time.Time `gorm:"column:redelivery_date;type:DATE" json:"redeliveryDate"`
+
+	// Address deliveries go to an address from the receiver's address book,
+	// DeliveryLocation then holds it on one line. Without one the receiver's
+	// default address is used.
+	DeliveryAddressID *string  `gorm:"column:delivery_address_id;index;type:varchar(255)" json:"deliveryAddressID"`
+	DeliveryAddress   *Address `gorm:"foreignKey:DeliveryAddressID" json:"deliveryAddress"`
 
-	OfficeDeliveredAtID string   `gorm:"column:office_delivered_at;type:varchar(255)" json:"officeDeliveredAtID" binding:"required"`
+	// Office deliveries are picked up with PickupPIN once they arrived at the
+	// office. They are kept until StorageDeadline and pay StorageFee for the
+	// days they are stored past the company's free storage days.
+	PickupPIN         string     `gorm:"column:pickup_pin;type:varchar(255)" json:"-"`
+	ArrivedAtOfficeAt *time.Time `gorm:"column:arrived_at_office_at;type:DATETIME" json:"arrivedAtOfficeAt"`
+	StorageDeadline   *time.Time `gorm:"column:storage_deadline;type:DATETIME" json:"storageDeadline"`
+	StorageFee        float64    `gorm:"column:storage_fee;not null;default:0;type:decimal(12,2)" json:"storageFee"`
+
+	// Locker deliveries reserve a compartment when they are created. The
+	// courier opens it with LockerCourierCode to drop the package off, and
+	// again to take it back to the locker's office once LockerDeadline
+	// passed. The receiver opens it with LockerPickupCode. Both codes work
+	// once.
+	LockerID            *string    `gorm:"column:locker_id;index;type:varchar(255)" json:"lockerID"`
+	Locker              *Locker    `gorm:"foreignKey:LockerID" json:"locker"`
+	LockerCompartmentID *string    `gorm:"column:locker_compartment_id;type:varchar(255)" json:"lockerCompartmentID"`
+	LockerCourierCode   string     `gorm:"column:locker_courier_code;type:varchar(255)" json:"-"`
+	LockerPickupCode    string     `gorm:"column:locker_pickup_code;type:varchar(255)" json:"-"`
+	LockerDroppedAt     *time.Time `gorm:"column:locker_dropped_at;type:DATETIME" json:"lockerDroppedAt"`
+	LockerDeadline      *time.Time `gorm:"column:locker_deadline;type:DATETIME" json:"lockerDeadline"`
+
+	OfficeDeliveredAtID *string  `gorm:"column:office_delivered_at;type:varchar(255)" json:"officeDeliveredAtID"`
 	OfficeDeliveredAt   *Office  `gorm:"foreignKey:OfficeDeliveredAtID" json:"officeDeliveredAt"`
 	CompanyID           string   `gorm:"column:company_id;not null;type:varchar(255)" json:"companyID" binding:"required"`
 	Company             *Company `gorm:"foreignKey:CompanyID" json:"company"`
+
+	// ReturnOfID links a return shipment to the package it sends back,
+	// ReturnPackageID links the original package to its return
+	ReturnOfID      *string `gorm:"column:return_of_id;index;type:varchar(255)" json:"returnOfID"`
+	ReturnPackageID *string `gorm:"column:return_package_id;type:varchar(255)" json:"returnPackageID"`
+	ReturnReason    string  `gorm:"column:return_reason;type:varchar(255)" json:"returnReason"`
+
+	CreatedAt  time.Time  `gorm:"column:created_at;type:DATETIME" json:"createdAt"`
+	ArchivedAt *time.Time `gorm:"column:archived_at;type:DATETIME" json:"archivedAt"`
 }
 
 func (Package) TableName() string {
@@ -46,21 +136,11 @@ func (p *Package) BeforeCreate(tx *gorm.DB) (err error) {
 	return nil
 }
 
-func (p *Package) BeforeUpdate(tx *gorm.DB) (err error) {
-	if p.DeliveryStatus == config.StatusDelivired {
-		t := time.Now()
-		p.DeliveryDate = &t
-		company := Company{}
-		err = tx.Model(&Company{}).
-			Joins("LEFT JOIN package ON package.company_id = company.id").Where("package.id = ?", p.ID).
-			First(&company).Error
-		if err != nil {
-			return
-		}
-		company.Revenue += p.Price
-
-		err = tx.Model(&Company{}).Where("id = ?", company.ID).Update("revenue", company.Revenue).Error
-	}
-
-	return
+// PickupInfo is what the receiver needs to pick an office delivery up.
+type PickupInfo struct {
+	PackageID       string     `json:"packageID"`
+	PIN             string     `json:"pin"`
+	OfficeID        string     `json:"officeID"`
+	StorageDeadline *time.Time `json:"storageDeadline"`
+	StorageFee      float64    `json:"storageFee"`
 }
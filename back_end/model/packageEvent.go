@@ -0,0 +1,47 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PackageEvent is an entry in the tracking history of a package, either a
+// change of its delivery status or a scan at an office.
+type PackageEvent struct {
+	ID         string  `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	PackageID  string  `gorm:"column:package_id;not null;index;type:varchar(255)" json:"packageID"`
+	Type       string  `gorm:"column:event_type;not null;type:varchar(255)" json:"type"`
+	Status     string  `gorm:"column:delivery_status;not null;type:varchar(255)" json:"status"`
+	OfficeID   *string `gorm:"column:office_id;index;type:varchar(255)" json:"officeID"`
+	EmployeeID *string `gorm:"column:employee_id;type:varchar(255)" json:"employeeID"`
+	CourrierID *string `gorm:"column:courrier_id;type:varchar(255)" json:"courrierID"`
+
+	// Scans at an office the package is not expected at are misroutes
+	Misrouted        bool    `gorm:"column:misrouted;not null;default:false;index" json:"misrouted"`
+	ExpectedOfficeID *string `gorm:"column:expected_office_id;type:varchar(255)" json:"expectedOfficeID"`
+
+	// Packages scanned together in one cage or bag share a batch
+	BatchID   *string `gorm:"column:batch_id;index;type:varchar(255)" json:"batchID"`
+	Container string  `gorm:"column:container;type:varchar(255)" json:"container"`
+
+	Note      string    `gorm:"column:note;type:varchar(255)" json:"note"`
+	CreatedAt time.Time `gorm:"column:created_at;not null;type:DATETIME(3)" json:"createdAt"`
+}
+
+func (PackageEvent) TableName() string {
+	return "package_event"
+}
+
+func (e *PackageEvent) BeforeCreate(tx *gorm.DB) (err error) {
+	e.ID = uuid.New().String()
+	return nil
+}
+
+// ScanResult is the outcome of one scanned code of a batch scan.
+type ScanResult struct {
+	TrackingCode string        `json:"trackingCode"`
+	Event        *PackageEvent `json:"event,omitempty"`
+	Error        string        `json:"error,omitempty"`
+}
@@ -0,0 +1,30 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PackageType is an entry of a company's package catalogue with the limits
+// of the type and how it changes the price. Limits of zero are not checked.
+type PackageType struct {
+	ID              string  `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	CompanyID       string  `gorm:"column:company_id;not null;uniqueIndex:idx_package_type_company_code;type:varchar(255)" json:"companyID"`
+	Code            string  `gorm:"column:code;not null;uniqueIndex:idx_package_type_company_code;type:varchar(255)" json:"code" binding:"required"`
+	Name            string  `gorm:"column:name;not null;type:varchar(255)" json:"name" binding:"required"`
+	MaxWeight       float64 `gorm:"column:max_weight;not null;default:0;type:float(8)" json:"maxWeight"`
+	MaxLength       float64 `gorm:"column:max_length;not null;default:0;type:float(8)" json:"maxLength"`
+	MaxWidth        float64 `gorm:"column:max_width;not null;default:0;type:float(8)" json:"maxWidth"`
+	MaxHeight       float64 `gorm:"column:max_height;not null;default:0;type:float(8)" json:"maxHeight"`
+	PriceMultiplier float64 `gorm:"column:price_multiplier;not null;default:1;type:float(8)" json:"priceMultiplier"`
+	Surcharge       float64 `gorm:"column:surcharge;not null;default:0;type:float(8)" json:"surcharge"`
+}
+
+func (PackageType) TableName() string {
+	return "package_type"
+}
+
+func (t *PackageType) BeforeCreate(tx *gorm.DB) (err error) {
+	t.ID = uuid.New().String()
+	return nil
+}
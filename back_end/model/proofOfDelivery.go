@@ -0,0 +1,39 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ProofOfDelivery is what a courier collects when handing a package over.
+// The signature and photo are kept in blob storage under the stored keys.
+type ProofOfDelivery struct {
+	ID                   string    `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	PackageID            string    `gorm:"column:package_id;not null;unique;type:varchar(255)" json:"packageID"`
+	CourrierID           string    `gorm:"column:courrier_id;not null;type:varchar(255)" json:"courrierID"`
+	RecipientName        string    `gorm:"column:recipient_name;not null;type:varchar(255)" json:"recipientName"`
+	SignatureKey         string    `gorm:"column:signature_key;not null;type:varchar(255)" json:"-"`
+	SignatureContentType string    `gorm:"column:signature_content_type;type:varchar(255)" json:"-"`
+	PhotoKey             *string   `gorm:"column:photo_key;type:varchar(255)" json:"-"`
+	PhotoContentType     string    `gorm:"column:photo_content_type;type:varchar(255)" json:"-"`
+	HasPhoto             bool      `gorm:"-" json:"hasPhoto"`
+	Latitude             *float64  `gorm:"column:latitude;type:double" json:"latitude"`
+	Longitude            *float64  `gorm:"column:longitude;type:double" json:"longitude"`
+	CreatedAt            time.Time `gorm:"column:created_at;not null;type:DATETIME" json:"createdAt"`
+}
+
+func (ProofOfDelivery) TableName() string {
+	return "proof_of_delivery"
+}
+
+func (p *ProofOfDelivery) BeforeCreate(tx *gorm.DB) (err error) {
+	p.ID = uuid.New().String()
+	return nil
+}
+
+func (p *ProofOfDelivery) AfterFind(tx *gorm.DB) (err error) {
+	p.HasPhoto = p.PhotoKey != nil
+	return nil
+}
@@ -0,0 +1,20 @@
+package model
+
+// PackageReassignment is a single package moved from one employee to another.
+type PackageReassignment struct {
+	PackageID      string  `json:"packageID"`
+	FromEmployeeID string  `json:"fromEmployeeID"`
+	ToEmployeeID   string  `json:"toEmployeeID"`
+	OfficeID       *string `json:"officeID"`
+	Reason         string  `json:"reason"`
+}
+
+// ReassignmentPlan is the proposed distribution of an employee's undelivered
+// packages over their colleagues, together with the open workload each
+// colleague would end up with.
+type ReassignmentPlan struct {
+	EmployeeID  string                `json:"employeeID"`
+	Column      string                `json:"column"`
+	Assignments []PackageReassignment `json:"assignments"`
+	Workloads   map[string]int64      `json:"workloads"`
+}
@@ -9,3 +9,230 @@ type RevenueRequest struct {
 	StartDate string `json:"start_date" binding:"required"`
 	EndDate   string `json:"end_date" binding:"required"`
 }
+
+type AuditFilter struct {
+	ActorID    string `form:"actorId"`
+	ActorRole  string `form:"actorRole"`
+	Action     string `form:"action"`
+	EntityType string `form:"entityType"`
+	EntityID   string `form:"entityId"`
+	From       string `form:"from"`
+	To         string `form:"to"`
+}
+
+type DeliveryRunRequest struct {
+	CourrierID string   `json:"courrierID" binding:"required"`
+	Date       string   `json:"date" binding:"required"`
+	PackageIDs []string `json:"packageIDs"`
+}
+
+type DeliveryRunOrderRequest struct {
+	StopIDs []string `json:"stopIDs" binding:"required"`
+}
+
+type DeliveryRunStopOutcomeRequest struct {
+	Outcome string `json:"outcome" binding:"required"`
+	// Reason is the attempt reason code of a failed stop
+	Reason string `json:"reason"`
+	Note   string `json:"note"`
+}
+
+type DeliveryAttemptRequest struct {
+	Reason string `json:"reason" binding:"required"`
+	Note   string `json:"note"`
+}
+
+type CashHandoverRequest struct {
+	CourrierID     string  `json:"courrierID" binding:"required"`
+	Date           string  `json:"date" binding:"required"`
+	Currency       string  `json:"currency"`
+	ReceivedAmount float64 `json:"receivedAmount"`
+	Note           string  `json:"note"`
+}
+
+type PayoutRequest struct {
+	CompanyID string `json:"companyID" binding:"required"`
+}
+
+type ClaimRequest struct {
+	PackageID     string  `json:"packageID" binding:"required"`
+	Type          string  `json:"type" binding:"required"`
+	Description   string  `json:"description"`
+	ClaimedAmount float64 `json:"claimedAmount" binding:"required"`
+}
+
+// ClaimStatusRequest moves a claim along its workflow. ApprovedAmount is
+// required when approving.
+type ClaimStatusRequest struct {
+	Status         string  `json:"status" binding:"required"`
+	ApprovedAmount float64 `json:"approvedAmount"`
+	Note           string  `json:"note"`
+}
+
+type ReturnRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// RedeliveryRequest is how a receiver wants a failed delivery to be retried,
+// either on Date or by picking the package up at OfficeID.
+type RedeliveryRequest struct {
+	Option   string `json:"option" binding:"required"`
+	Date     string `json:"date"`
+	OfficeID string `json:"officeID"`
+}
+
+// ShipmentRequest registers a shipment. The delivery details are shared by
+// all parcels, the parcels only bring their own size, type and value.
+type ShipmentRequest struct {
+	SenderID            string          `json:"senderID" binding:"required"`
+	ReceiverID          string          `json:"receiverID" binding:"required"`
+	CompanyID           string          `json:"companyID" binding:"required"`
+	CourrierID          *string         `json:"courrierID"`
+	OfficeAcceptedAtID  string          `json:"officeAcceptedAtID" binding:"required"`
+	IsDeliveredToOffice bool            `json:"isDeliveredToOffice"`
+	OfficeDeliveredAtID *string         `json:"officeDeliveredAtID"`
+	DeliveryLocation    *string         `json:"deliveryLocation"`
+	DeliveryAddressID   *string         `json:"deliveryAddressID"`
+	Parcels             []ParcelRequest `json:"parcels" binding:"required,min=1,dive"`
+}
+
+// ClientShipmentRequest is a shipment a client requests online. The parcel
+// is either dropped off at OfficeAcceptedAtID or collected from the sender's
+// address PickupAddressID. Receivers without an account are given by name
+// and phone or email instead of ReceiverID.
+type ClientShipmentRequest struct {
+	ReceiverID          *string `json:"receiverID"`
+	ReceiverName        string  `json:"receiverName"`
+	ReceiverPhone       string  `json:"receiverPhone"`
+	ReceiverEmail       string  `json:"receiverEmail"`
+	CompanyID           string  `json:"companyID" binding:"required"`
+	EstimatedWeight     float64 `json:"estimatedWeight" binding:"required,gt=0"`
+	Length              float64 `json:"length" binding:"gte=0"`
+	Width               float64 `json:"width" binding:"gte=0"`
+	Height              float64 `json:"height" binding:"gte=0"`
+	PackageTypeID       *string `json:"packageTypeID"`
+	OfficeAcceptedAtID  *string `json:"officeAcceptedAtID"`
+	PickupAddressID     *string `json:"pickupAddressID"`
+	IsDeliveredToOffice bool    `json:"isDeliveredToOffice"`
+	OfficeDeliveredAtID *string `json:"officeDeliveredAtID"`
+	DeliveryLocation    *string `json:"deliveryLocation"`
+	DeliveryAddressID   *string `json:"deliveryAddressID"`
+	LockerID            *string `json:"lockerID"`
+	CODAmount           float64 `json:"codAmount" binding:"gte=0"`
+	CODCurrency         string  `json:"codCurrency"`
+	DeclaredValue       float64 `json:"declaredValue" binding:"gte=0"`
+	Insured             bool    `json:"insured"`
+}
+
+// ShipmentConfirmation is what an employee measures when accepting a
+// requested shipment. OfficeAcceptedAtID defaults to the employee's office.
+type ShipmentConfirmation struct {
+	Weight             float64 `json:"weight" binding:"required,gt=0"`
+	Length             float64 `json:"length" binding:"gte=0"`
+	Width              float64 `json:"width" binding:"gte=0"`
+	Height             float64 `json:"height" binding:"gte=0"`
+	OfficeAcceptedAtID string  `json:"officeAcceptedAtID"`
+}
+
+// CollectionRequest schedules a courier to collect parcels from an address
+// of the sender. WindowStart and WindowEnd are RFC3339 times on the same
+// day. PackageIDs are requested packages to be collected from that address.
+type CollectionRequest struct {
+	SenderID    string   `json:"senderID"`
+	CompanyID   string   `json:"companyID" binding:"required"`
+	AddressID   string   `json:"addressID" binding:"required"`
+	WindowStart string   `json:"windowStart" binding:"required"`
+	WindowEnd   string   `json:"windowEnd" binding:"required"`
+	Parcels     int      `json:"parcels" binding:"gte=0"`
+	Note        string   `json:"note"`
+	PackageIDs  []string `json:"packageIDs"`
+}
+
+// CollectionAssignment assigns a collection to a courier, to the courier
+// with the fewest collections that day when CourrierID is empty.
+type CollectionAssignment struct {
+	CourrierID string `json:"courrierID"`
+}
+
+// CollectionCompletion records what the courier measured for the packages
+// they collected, keyed by package ID. Packages without measurements keep
+// the sender's estimate.
+type CollectionCompletion struct {
+	Parcels map[string]ShipmentConfirmation `json:"parcels"`
+}
+
+// VerificationRequest asks for a code to verify the email or the phone of a
+// client, Code confirms it.
+type VerificationRequest struct {
+	Channel string `json:"channel" binding:"required"`
+	Code    string `json:"code"`
+}
+
+type ParcelRequest struct {
+	Weight        float64 `json:"weight" binding:"required,gt=0"`
+	Length        float64 `json:"length" binding:"gte=0"`
+	Width         float64 `json:"width" binding:"gte=0"`
+	Height        float64 `json:"height" binding:"gte=0"`
+	PackageTypeID *string `json:"packageTypeID"`
+	DeclaredValue float64 `json:"declaredValue" binding:"gte=0"`
+	Insured       bool    `json:"insured"`
+}
+
+// ScanRequest records a scan of a package label at the employee's office.
+// TrackingCode is the tracking number or the ID of the package.
+type ScanRequest struct {
+	TrackingCode string `json:"trackingCode" binding:"required"`
+	Type         string `json:"type" binding:"required"`
+	CourrierID   string `json:"courrierID"`
+	Note         string `json:"note"`
+}
+
+// BatchScanRequest records the same scan for every package of a cage or bag.
+type BatchScanRequest struct {
+	TrackingCodes []string `json:"trackingCodes" binding:"required,min=1"`
+	Type          string   `json:"type" binding:"required"`
+	Container     string   `json:"container"`
+	CourrierID    string   `json:"courrierID"`
+	Note          string   `json:"note"`
+}
+
+type TransferBatchRequest struct {
+	FromOfficeID string `json:"fromOfficeID" binding:"required"`
+	ToOfficeID   string `json:"toOfficeID" binding:"required"`
+}
+
+// TransferPackagesRequest adds packages to a transfer batch by their
+// tracking numbers or IDs.
+type TransferPackagesRequest struct {
+	TrackingCodes []string `json:"trackingCodes" binding:"required,min=1"`
+}
+
+type OfficeHolidayRequest struct {
+	Date string `json:"date" binding:"required"`
+	Name string `json:"name"`
+}
+
+// PickupRequest verifies the receiver at an office pickup, either by the
+// pickup PIN or by the staff checking the receiver's identity document.
+type PickupRequest struct {
+	PIN             string `json:"pin"`
+	IdentityChecked bool   `json:"identityChecked"`
+	RecipientName   string `json:"recipientName"`
+}
+
+// LockerRequest creates a locker with the given number of compartments per
+// size (small, medium, large).
+type LockerRequest struct {
+	CompanyID    string         `json:"companyID" binding:"required"`
+	Name         string         `json:"name" binding:"required"`
+	Location     string         `json:"location" binding:"required"`
+	Latitude     *float64       `json:"latitude"`
+	Longitude    *float64       `json:"longitude"`
+	OfficeID     string         `json:"officeID" binding:"required"`
+	Compartments map[string]int `json:"compartments" binding:"required"`
+}
+
+// LockerCodeRequest is a code entered at a locker.
+type LockerCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
@@ -0,0 +1,19 @@
+package model
+
+// ReturnReport shows how many of the packages a company registered in a
+// period were sent back to their sender.
+type ReturnReport struct {
+	CompanyID        string              `json:"companyID"`
+	From             string              `json:"from"`
+	To               string              `json:"to"`
+	TotalPackages    int64               `json:"totalPackages"`
+	ReturnedPackages int64               `json:"returnedPackages"`
+	ReturnRate       float64             `json:"returnRate"`
+	ReturnRevenue    float64             `json:"returnRevenue"`
+	ByReason         []ReturnReasonCount `json:"byReason"`
+}
+
+type ReturnReasonCount struct {
+	Reason string `json:"reason"`
+	Count  int64  `json:"count"`
+}
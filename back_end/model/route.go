@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+// RouteStop is a package delivery on an optimized courier route.
+type RouteStop struct {
+	Sequence     int        `json:"sequence"`
+	PackageID    string     `json:"packageID"`
+	Address      string     `json:"address"`
+	Latitude     float64    `json:"latitude"`
+	Longitude    float64    `json:"longitude"`
+	DistanceKm   float64    `json:"distanceKm"`
+	CumulativeKm float64    `json:"cumulativeKm"`
+	ETA          time.Time  `json:"eta"`
+	WindowStart  *time.Time `json:"windowStart"`
+	WindowEnd    *time.Time `json:"windowEnd"`
+	Late         bool       `json:"late"`
+}
+
+// CourrierRoute is the optimized order of a courier's open deliveries.
+// Packages whose destination could not be geocoded are listed separately.
+type CourrierRoute struct {
+	CourrierID          string      `json:"courrierID"`
+	StartLatitude       float64     `json:"startLatitude"`
+	StartLongitude      float64     `json:"startLongitude"`
+	StartTime           time.Time   `json:"startTime"`
+	TotalDistanceKm     float64     `json:"totalDistanceKm"`
+	LateStops           int         `json:"lateStops"`
+	Stops               []RouteStop `json:"stops"`
+	UnlocatedPackageIDs []string    `json:"unlocatedPackageIDs"`
+}
@@ -0,0 +1,37 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Shipment groups the parcels a sender sends to one receiver at once under
+// a shared tracking number. Its total price includes the cash on delivery
+// fees and insurance premiums of the parcels.
+type Shipment struct {
+	ID                    string    `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	TrackingNumber        string    `gorm:"column:tracking_number;not null;unique;type:varchar(255)" json:"trackingNumber"`
+	SenderID              string    `gorm:"column:sender_id;not null;index;type:varchar(255)" json:"senderID"`
+	Sender                *Client   `gorm:"foreignKey:SenderID" json:"sender"`
+	ReceiverID            string    `gorm:"column:receiver_id;not null;index;type:varchar(255)" json:"receiverID"`
+	Receiver              *Client   `gorm:"foreignKey:ReceiverID" json:"receiver"`
+	CompanyID             string    `gorm:"column:company_id;not null;type:varchar(255)" json:"companyID"`
+	ParcelCount           int       `gorm:"column:parcel_count;not null" json:"parcelCount"`
+	TotalWeight           float64   `gorm:"column:total_weight;not null;type:float(8)" json:"totalWeight"`
+	TotalChargeableWeight float64   `gorm:"column:total_chargeable_weight;not null;type:float(8)" json:"totalChargeableWeight"`
+	TotalPrice            float64   `gorm:"column:total_price;not null;type:decimal(12,2)" json:"totalPrice"`
+	Status                string    `gorm:"-" json:"status"`
+	CreatedAt             time.Time `gorm:"column:created_at;type:DATETIME" json:"createdAt"`
+	Parcels               []Package `gorm:"foreignKey:ShipmentID" json:"parcels"`
+}
+
+func (Shipment) TableName() string {
+	return "shipment"
+}
+
+func (s *Shipment) BeforeCreate(tx *gorm.DB) (err error) {
+	s.ID = uuid.New().String()
+	return nil
+}
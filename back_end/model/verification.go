@@ -0,0 +1,31 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ClientVerification is a one-time code sent to the email or phone of a
+// client to prove it is theirs. Target is the verified email or phone.
+type ClientVerification struct {
+	ID         string     `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	ClientID   string     `gorm:"column:client_id;not null;index;type:varchar(255)" json:"clientID"`
+	Channel    string     `gorm:"column:channel;not null;type:varchar(255)" json:"channel"`
+	Target     string     `gorm:"column:target;not null;index;type:varchar(255)" json:"target"`
+	Code       string     `gorm:"column:code;not null;type:varchar(255)" json:"-"`
+	Attempts   int        `gorm:"column:attempts;not null;default:0" json:"attempts"`
+	ExpiresAt  time.Time  `gorm:"column:expires_at;not null;type:DATETIME" json:"expiresAt"`
+	VerifiedAt *time.Time `gorm:"column:verified_at;type:DATETIME" json:"verifiedAt"`
+	CreatedAt  time.Time  `gorm:"column:created_at;not null;type:DATETIME" json:"createdAt"`
+}
+
+func (ClientVerification) TableName() string {
+	return "client_verification"
+}
+
+func (v *ClientVerification) BeforeCreate(tx *gorm.DB) (err error) {
+	v.ID = uuid.New().String()
+	return nil
+}
@@ -0,0 +1,269 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"logistic_company/config"
+	"logistic_company/model"
+	"regexp"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var countryCode = regexp.MustCompile(`^[A-Z]{2}$`)
+
+// postalCodes are the postal code formats of the countries we deliver to
+// most, codes of other countries only have to look like a postal code.
+var postalCodes = map[string]*regexp.Regexp{
+	"AT": regexp.MustCompile(`^\d{4}$`),
+	"BE": regexp.MustCompile(`^\d{4}$`),
+	"BG": regexp.MustCompile(`^\d{4}$`),
+	"CA": regexp.MustCompile(`^[A-Z]\d[A-Z] ?\d[A-Z]\d$`),
+	"CH": regexp.MustCompile(`^\d{4}$`),
+	"CY": regexp.MustCompile(`^\d{4}$`),
+	"CZ": regexp.MustCompile(`^\d{3} ?\d{2}$`),
+	"DE": regexp.MustCompile(`^\d{5}$`),
+	"DK": regexp.MustCompile(`^\d{4}$`),
+	"ES": regexp.MustCompile(`^\d{5}$`),
+	"FI": regexp.MustCompile(`^\d{5}$`),
+	"FR": regexp.MustCompile(`^\d{5}$`),
+	"GB": regexp.MustCompile(`^[A-Z]{1,2}\d[A-Z\d]? ?\d[A-Z]{2}$`),
+	"GR": regexp.MustCompile(`^\d{3} ?\d{2}$`),
+	"HR": regexp.MustCompile(`^\d{5}$`),
+	"HU": regexp.MustCompile(`^\d{4}$`),
+	"IE": regexp.MustCompile(`^[A-Z]\d[\dW] ?[A-Z\d]{4}$`),
+	"IT": regexp.MustCompile(`^\d{5}$`),
+	"MK": regexp.MustCompile(`^\d{4}$`),
+	"NL": regexp.MustCompile(`^\d{4} ?[A-Z]{2}$`),
+	"NO": regexp.MustCompile(`^\d{4}$`),
+	"PL": regexp.MustCompile(`^\d{2}-\d{3}$`),
+	"PT": regexp.MustCompile(`^\d{4}-\d{3}$`),
+	"RO": regexp.MustCompile(`^\d{6}$`),
+	"RS": regexp.MustCompile(`^\d{5}$`),
+	"SE": regexp.MustCompile(`^\d{3} ?\d{2}$`),
+	"SI": regexp.MustCompile(`^\d{4}$`),
+	"SK": regexp.MustCompile(`^\d{3} ?\d{2}$`),
+	"TR": regexp.MustCompile(`^\d{5}$`),
+	"US": regexp.MustCompile(`^\d{5}(-\d{4})?$`),
+}
+
+var anyPostalCode = regexp.MustCompile(`^[A-Z\d][A-Z\d -]{1,8}[A-Z\d]$`)
+
+// ValidPostalCode reports whether a postal code has the format used in the
+// country with the given ISO 3166-1 alpha-2 code.
+func ValidPostalCode(country, postalCode string) bool {
+	if !countryCode.MatchString(country) {
+		return false
+	}
+	postalCode = strings.ToUpper(strings.TrimSpace(postalCode))
+	if format, ok := postalCodes[country]; ok {
+		return format.MatchString(postalCode)
+	}
+	return anyPostalCode.MatchString(postalCode)
+}
+
+func (c *ClientRepository) GetAddresses(ctx context.Context, addresses *[]model.Address, clientID string) error {
+	return c.db.WithContext(ctx).Scopes(notArchived).Where("client_id = ?", clientID).
+		Order("is_default DESC, created_at").Find(addresses).Error
+}
+
+func (c *ClientRepository) GetAddressById(ctx context.Context, address *model.Address, clientID, id string) error {
+	return c.db.WithContext(ctx).Where("id = ? AND client_id = ?", id, clientID).First(address).Error
+}
+
+// CreateAddress adds an address to the address book of a client. The first
+// address of a client becomes the default one.
+func (c *ClientRepository) CreateAddress(ctx context.Context, address *model.Address) error {
+	if !ValidPostalCode(address.Country, address.PostalCode) {
+		return ErrorInvalidAddress
+	}
+	tx := c.db.WithContext(ctx).Begin()
+
+	if err := tx.Where("id = ?", address.ClientID).First(&model.Client{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	var count int64
+	if err := tx.Model(&model.Address{}).Scopes(notArchived).Where("client_id = ?", address.ClientID).Count(&count).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	address.IsDefault = address.IsDefault || count == 0
+	if address.IsDefault {
+		if err := clearDefaultAddress(tx, address.ClientID); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	address.CreatedAt = time.Now()
+	if err := tx.Create(address).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionCreate, config.AuditEntityAddress, address.ID, nil, address); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// UpdateAddress replaces an address of a client. Packages sent to it before
+// keep the address they were sent to.
+func (c *ClientRepository) UpdateAddress(ctx context.Context, address *model.Address) error {
+	if !ValidPostalCode(address.Country, address.PostalCode) {
+		return ErrorInvalidAddress
+	}
+	tx := c.db.WithContext(ctx).Begin()
+
+	before := model.Address{}
+	if err := tx.Scopes(notArchived).Where("id = ? AND client_id = ?", address.ID, address.ClientID).First(&before).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	// Only SetDefaultAddress and DeleteAddress move the default
+	address.IsDefault = before.IsDefault
+	address.CreatedAt = before.CreatedAt
+	if err := tx.Save(address).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionUpdate, config.AuditEntityAddress, address.ID, &before, address); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// SetDefaultAddress makes an address the default address of its client.
+func (c *ClientRepository) SetDefaultAddress(ctx context.Context, address *model.Address, clientID, id string) error {
+	tx := c.db.WithContext(ctx).Begin()
+
+	if err := tx.Scopes(notArchived).Where("id = ? AND client_id = ?", id, clientID).First(address).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	before := *address
+	if err := clearDefaultAddress(tx, clientID); err != nil {
+		tx.Rollback()
+		return err
+	}
+	address.IsDefault = true
+	if err := tx.Model(&model.Address{}).Where("id = ?", id).Update("is_default", true).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionUpdate, config.AuditEntityAddress, id, &before, address); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// DeleteAddress removes an address from the address book of a client.
+// Addresses packages were sent to are archived so the packages keep them.
+// When the default address goes, the newest remaining one takes its place.
+func (c *ClientRepository) DeleteAddress(ctx context.Context, clientID, id string) error {
+	tx := c.db.WithContext(ctx).Begin()
+
+	before := model.Address{}
+	if err := tx.Scopes(notArchived).Where("id = ? AND client_id = ?", id, clientID).First(&before).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	var packages int64
+	if err := tx.Model(&model.Package{}).Where("delivery_address_id = ?", id).Count(&packages).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if packages > 0 {
+		now := time.Now()
+		if err := tx.Model(&model.Address{}).Where("id = ?", id).
+			Updates(map[string]interface{}{"archived_at": &now, "is_default": false}).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := recordAudit(ctx, tx, config.AuditActionArchive, config.AuditEntityAddress, id, &before, nil); err != nil {
+			tx.Rollback()
+			return err
+		}
+	} else {
+		if err := tx.Where("id = ?", id).Delete(&model.Address{}).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := recordAudit(ctx, tx, config.AuditActionDelete, config.AuditEntityAddress, id, &before, nil); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if before.IsDefault {
+		next := model.Address{}
+		err := tx.Scopes(notArchived).Where("client_id = ?", clientID).Order("created_at DESC").First(&next).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			tx.Rollback()
+			return err
+		}
+		if err == nil {
+			if err := tx.Model(&model.Address{}).Where("id = ?", next.ID).Update("is_default", true).Error; err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+func clearDefaultAddress(tx *gorm.DB, clientID string) error {
+	return tx.Model(&model.Address{}).Where("client_id = ? AND is_default = ?", clientID, true).
+		Update("is_default", false).Error
+}
+
+// deliveryAddress points an address delivery at the address it names from
+// the receiver's address book. Packages with neither an address nor a free
+// text location go to the receiver's default address. Receivers without an
+// account have no address book, their address is given as free text.
+func deliveryAddress(tx *gorm.DB, packageModel *model.Package) error {
+	if packageModel.IsDeliveredToOffice || packageModel.LockerID != nil {
+		if packageModel.DeliveryAddressID != nil {
+			return ErrorInvalidAddress
+		}
+		return nil
+	}
+	if packageModel.ReceiverID == nil {
+		if packageModel.DeliveryAddressID != nil || packageModel.DeliveryLocation == nil || *packageModel.DeliveryLocation == "" {
+			return ErrorInvalidAddress
+		}
+		return nil
+	}
+
+	query := tx.Scopes(notArchived).Where("client_id = ?", *packageModel.ReceiverID)
+	switch {
+	case packageModel.DeliveryAddressID != nil:
+		query = query.Where("id = ?", *packageModel.DeliveryAddressID)
+	case packageModel.DeliveryLocation == nil || *packageModel.DeliveryLocation == "":
+		query = query.Where("is_default = ?", true)
+	default:
+		return nil
+	}
+	address := model.Address{}
+	if err := query.First(&address).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrorInvalidAddress
+		}
+		return err
+	}
+
+	location := address.String()
+	packageModel.DeliveryAddressID = &address.ID
+	packageModel.DeliveryLocation = &location
+	packageModel.DeliveryLatitude = address.Latitude
+	packageModel.DeliveryLongitude = address.Longitude
+	return nil
+}
@@ -0,0 +1,251 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"logistic_company/config"
+	"logistic_company/model"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AssignmentStrategy picks the courier that delivers a newly created package
+// out of the couriers of the package's company.
+type AssignmentStrategy interface {
+	Assign(tx *gorm.DB, packageModel *model.Package, courriers []model.Employee) (courrier model.Employee, reason string, err error)
+}
+
+var assignmentStrategies = map[string]AssignmentStrategy{
+	config.AssignmentRoundRobin:  roundRobinStrategy{},
+	config.AssignmentLeastLoaded: leastLoadedStrategy{},
+	config.AssignmentZone:        zoneStrategy{},
+}
+
+func IsAssignmentStrategy(name string) bool {
+	_, ok := assignmentStrategies[name]
+	return ok
+}
+
+// assignCourrier fills in the courier of a package using the assignment
+// strategy configured for its company.
+func assignCourrier(tx *gorm.DB, packageModel *model.Package) error {
+	company := model.Company{}
+	if err := tx.Where("id = ?", packageModel.CompanyID).First(&company).Error; err != nil {
+		return err
+	}
+	name := company.AssignmentStrategy
+	if name == "" {
+		name = config.DefaultAssignmentStrategy
+	}
+	strategy, ok := assignmentStrategies[name]
+	if !ok {
+		return fmt.Errorf("unknown assignment strategy %q", name)
+	}
+
+	courriers := []model.Employee{}
+	if err := tx.Model(&model.Employee{}).Scopes(notArchived).
+		Where("role = ?", config.RoleCourrier).
+		Where("company_id = ?", packageModel.CompanyID).
+		Order("id").Find(&courriers).Error; err != nil {
+		return err
+	}
+	if len(courriers) == 0 {
+		return ErrorNoCourriersAvailable
+	}
+
+	courrier, reason, err := strategy.Assign(tx, packageModel, courriers)
+	if err != nil {
+		return err
+	}
+	packageModel.CourrierID = &courrier.ID
+	packageModel.AssignmentStrategy = name
+	packageModel.AssignmentReason = reason
+
+	return nil
+}
+
+// checkCourrier makes sure a courier that was chosen by hand delivers for
+// the company of the package.
+func checkCourrier(tx *gorm.DB, packageModel *model.Package) error {
+	err := tx.Scopes(notArchived).Where("id = ? AND company_id = ? AND role = ?",
+		packageModel.CourrierID, packageModel.CompanyID, config.RoleCourrier).First(&model.Employee{}).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrorInvalidCourrier
+	}
+	return err
+}
+
+type leastLoadedStrategy struct{}
+
+// Assign picks the courier with the fewest undelivered packages, preferring
+// the ones working at the office the package was accepted at.
+func (leastLoadedStrategy) Assign(tx *gorm.DB, packageModel *model.Package, courriers []model.Employee) (model.Employee, string, error) {
+	workloads, err := openWorkloads(tx, "courrier_id", courriers)
+	if err != nil {
+		return model.Employee{}, "", err
+	}
+
+	courrier, sameOffice := leastLoaded(courriers, workloads, packageModel.OfficeAcceptedAtID)
+	if sameOffice {
+		return courrier, fmt.Sprintf("least loaded courrier in office %s with %d open packages",
+			*packageModel.OfficeAcceptedAtID, workloads[courrier.ID]), nil
+	}
+	return courrier, fmt.Sprintf("least loaded courrier in the company with %d open packages", workloads[courrier.ID]), nil
+}
+
+type roundRobinStrategy struct{}
+
+// Assign rotates through the couriers of the office the package was accepted
+// at, or through all couriers of the company if the office has none.
+func (roundRobinStrategy) Assign(tx *gorm.DB, packageModel *model.Package, courriers []model.Employee) (model.Employee, string, error) {
+	scopeID := stringValue(packageModel.OfficeAcceptedAtID)
+	pool := []model.Employee{}
+	for i := range courriers {
+		if inOffice(&courriers[i], packageModel.OfficeAcceptedAtID) {
+			pool = append(pool, courriers[i])
+		}
+	}
+	if len(pool) == 0 {
+		scopeID = packageModel.CompanyID
+		pool = courriers
+	}
+
+	// The cursor is created before it is locked, so concurrent assignments
+	// in the same scope wait for each other instead of picking the same
+	// courier
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&model.AssignmentCursor{ScopeID: scopeID}).Error; err != nil {
+		return model.Employee{}, "", err
+	}
+	cursor := model.AssignmentCursor{}
+	if err := tx.Scopes(forUpdate).Where("scope_id = ?", scopeID).First(&cursor).Error; err != nil {
+		return model.Employee{}, "", err
+	}
+
+	// pool is ordered by ID, so the next courier is the first one after the
+	// last picked ID, wrapping around to the start
+	courrier := pool[0]
+	for _, candidate := range pool {
+		if candidate.ID > cursor.LastCourrierID {
+			courrier = candidate
+			break
+		}
+	}
+
+	if err := tx.Model(&model.AssignmentCursor{}).Where("scope_id = ?", scopeID).
+		Update("last_courrier_id", courrier.ID).Error; err != nil {
+		return model.Employee{}, "", err
+	}
+
+	if scopeID == stringValue(packageModel.OfficeAcceptedAtID) {
+		return courrier, fmt.Sprintf("next courrier in rotation for office %s", scopeID), nil
+	}
+	return courrier, "next courrier in rotation for the company", nil
+}
+
+type zoneStrategy struct{}
+
+// Assign picks the least loaded courier whose zone matches the delivery
+// location. Packages outside of every zone fall back to the least loaded
+// courier of the company.
+func (zoneStrategy) Assign(tx *gorm.DB, packageModel *model.Package, courriers []model.Employee) (model.Employee, string, error) {
+	location, err := deliveryLocation(tx, packageModel)
+	if err != nil {
+		return model.Employee{}, "", err
+	}
+
+	zones := []model.CourrierZone{}
+	if err := tx.Where("company_id = ?", packageModel.CompanyID).Find(&zones).Error; err != nil {
+		return model.Employee{}, "", err
+	}
+
+	matchingZones := map[string]string{}
+	for _, zone := range zones {
+		if location != "" && strings.Contains(strings.ToLower(location), strings.ToLower(zone.Pattern)) {
+			matchingZones[zone.CourrierID] = zone.Pattern
+		}
+	}
+
+	pool := []model.Employee{}
+	for _, courrier := range courriers {
+		if _, ok := matchingZones[courrier.ID]; ok {
+			pool = append(pool, courrier)
+		}
+	}
+	if len(pool) == 0 {
+		courrier, reason, err := leastLoadedStrategy{}.Assign(tx, packageModel, courriers)
+		return courrier, "no zone matched, " + reason, err
+	}
+
+	workloads, err := openWorkloads(tx, "courrier_id", pool)
+	if err != nil {
+		return model.Employee{}, "", err
+	}
+	courrier, _ := leastLoaded(pool, workloads, nil)
+
+	return courrier, fmt.Sprintf("delivery location matches zone %q", matchingZones[courrier.ID]), nil
+}
+
+// deliveryLocation is the address a package goes to, which for office
+// deliveries is the location of the office.
+func deliveryLocation(tx *gorm.DB, packageModel *model.Package) (string, error) {
+	if !packageModel.IsDeliveredToOffice {
+		if packageModel.DeliveryLocation == nil {
+			return "", nil
+		}
+		return *packageModel.DeliveryLocation, nil
+	}
+
+	office := model.Office{}
+	if err := tx.Where("id = ?", packageModel.OfficeDeliveredAtID).First(&office).Error; err != nil {
+		return "", err
+	}
+	return office.Location, nil
+}
+
+func (c *CompanyRepository) GetCourrierZones(ctx context.Context, zones *[]model.CourrierZone, companyID string) error {
+	return c.db.WithContext(ctx).Preload("Courrier").Where("company_id = ?", companyID).Find(zones).Error
+}
+
+func (c *CompanyRepository) CreateCourrierZone(ctx context.Context, zone *model.CourrierZone) error {
+	tx := c.db.WithContext(ctx).Begin()
+
+	courrier := model.Employee{}
+	if err := tx.Scopes(notArchived).Where("id = ? AND company_id = ? AND role = ?",
+		zone.CourrierID, zone.CompanyID, config.RoleCourrier).First(&courrier).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Create(zone).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionCreate, config.AuditEntityCourrierZone, zone.ID, nil, zone); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (c *CompanyRepository) DeleteCourrierZone(ctx context.Context, companyID, id string) error {
+	tx := c.db.WithContext(ctx).Begin()
+
+	before := model.CourrierZone{}
+	if err := tx.Where("id = ? AND company_id = ?", id, companyID).First(&before).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Where("id = ?", id).Delete(&model.CourrierZone{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionDelete, config.AuditEntityCourrierZone, id, &before, nil); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"logistic_company/config"
+	"logistic_company/model"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestRoundRobinStrategy(t *testing.T) {
+	r := newTestRepository(t)
+	company := createTestCompany(t, r, model.Company{AssignmentStrategy: config.AssignmentRoundRobin})
+	office := createTestOffice(t, r, company.ID)
+	emptyOffice := createTestOffice(t, r, company.ID)
+	otherOffice := createTestOffice(t, r, company.ID)
+	courriers := []string{}
+	for i := 0; i < 3; i++ {
+		courriers = append(courriers, createTestEmployee(t, r, config.RoleCourrier, company.ID, &office.ID).ID)
+	}
+	other := createTestEmployee(t, r, config.RoleCourrier, company.ID, &otherOffice.ID)
+	sort.Strings(courriers)
+
+	for i, want := range append(courriers, courriers[0]) {
+		packageModel := model.Package{CompanyID: company.ID, OfficeAcceptedAtID: &office.ID}
+		if err := assignCourrier(r.db, &packageModel); err != nil {
+			t.Fatal(err)
+		}
+		if stringValue(packageModel.CourrierID) != want {
+			t.Errorf("assignment %d went to %s, want %s", i, stringValue(packageModel.CourrierID), want)
+		}
+	}
+
+	// Offices without couriers rotate through the whole company
+	all := append([]string{other.ID}, courriers...)
+	sort.Strings(all)
+	packageModel := model.Package{CompanyID: company.ID, OfficeAcceptedAtID: &emptyOffice.ID}
+	if err := assignCourrier(r.db, &packageModel); err != nil {
+		t.Fatal(err)
+	}
+	if stringValue(packageModel.CourrierID) != all[0] || packageModel.AssignmentReason != "next courrier in rotation for the company" {
+		t.Errorf("assignment went to %s (%s), want %s in rotation for the company",
+			stringValue(packageModel.CourrierID), packageModel.AssignmentReason, all[0])
+	}
+}
+
+func TestLeastLoaded(t *testing.T) {
+	officeA, officeB, officeC := "office-a", "office-b", "office-c"
+	courriers := []model.Employee{
+		{ID: "a1", OfficeID: &officeA},
+		{ID: "a2", OfficeID: &officeA},
+		{ID: "b1", OfficeID: &officeB},
+		{ID: "c1"},
+	}
+
+	tests := []struct {
+		name           string
+		workloads      map[string]int64
+		officeID       *string
+		want           string
+		wantSameOffice bool
+	}{
+		{"fewest packages in the office", map[string]int64{"a1": 2, "a2": 1}, &officeA, "a2", true},
+		{"office before workload", map[string]int64{"a1": 5, "a2": 5}, &officeB, "b1", true},
+		{"ties broken by ID", map[string]int64{}, &officeA, "a1", true},
+		{"whole company without an office", map[string]int64{"a1": 1, "a2": 1, "b1": 3}, nil, "c1", false},
+		{"whole company when nobody works at the office", map[string]int64{"a1": 1, "c1": 1}, &officeC, "a2", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, sameOffice := leastLoaded(courriers, test.workloads, test.officeID)
+			if got.ID != test.want || sameOffice != test.wantSameOffice {
+				t.Errorf("leastLoaded() = %s, %v, want %s, %v", got.ID, sameOffice, test.want, test.wantSameOffice)
+			}
+		})
+	}
+}
+
+func TestZoneStrategy(t *testing.T) {
+	r := newTestRepository(t)
+	company := createTestCompany(t, r, model.Company{AssignmentStrategy: config.AssignmentZone})
+	office := createTestOffice(t, r, company.ID)
+	sofia := createTestEmployee(t, r, config.RoleCourrier, company.ID, &office.ID)
+	plovdiv := createTestEmployee(t, r, config.RoleCourrier, company.ID, &office.ID)
+	for courrierID, pattern := range map[string]string{sofia.ID: "sofia", plovdiv.ID: "Plovdiv"} {
+		zone := model.CourrierZone{CompanyID: company.ID, CourrierID: courrierID, Pattern: pattern}
+		if err := r.db.Create(&zone).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		location   string
+		want       string
+		wantReason string
+	}{
+		{"1 Vitosha Blvd, Sofia", sofia.ID, `delivery location matches zone "sofia"`},
+		{"5 Main St, PLOVDIV", plovdiv.ID, `delivery location matches zone "Plovdiv"`},
+		{"3 Sea Garden, Varna", "", "no zone matched, least loaded courrier in office"},
+	}
+	for _, test := range tests {
+		t.Run(test.location, func(t *testing.T) {
+			location := test.location
+			packageModel := model.Package{CompanyID: company.ID, OfficeAcceptedAtID: &office.ID, DeliveryLocation: &location}
+			if err := assignCourrier(r.db, &packageModel); err != nil {
+				t.Fatal(err)
+			}
+			if test.want != "" && stringValue(packageModel.CourrierID) != test.want {
+				t.Errorf("assignment went to %s, want %s", stringValue(packageModel.CourrierID), test.want)
+			}
+			if !strings.HasPrefix(packageModel.AssignmentReason, test.wantReason) {
+				t.Errorf("AssignmentReason = %q, want %q", packageModel.AssignmentReason, test.wantReason)
+			}
+		})
+	}
+}
+
+func TestCreatePackageChecksCourrier(t *testing.T) {
+	r := newTestRepository(t)
+	company := createTestCompany(t, r, model.Company{})
+	office := createTestOffice(t, r, company.ID)
+	courrier := createTestEmployee(t, r, config.RoleCourrier, company.ID, &office.ID)
+	clerk := createTestEmployee(t, r, config.RoleEmployee, company.ID, &office.ID)
+	other := createTestCompany(t, r, model.Company{})
+	otherCourrier := createTestEmployee(t, r, config.RoleCourrier, other.ID, nil)
+	sender := createTestClient(t, r, "", "")
+	receiver := createTestClient(t, r, "", "")
+
+	tests := []struct {
+		name       string
+		courrierID string
+		wantErr    error
+	}{
+		{"courrier of the company", courrier.ID, nil},
+		{"employee who is no courrier", clerk.ID, ErrorInvalidCourrier},
+		{"courrier of another company", otherCourrier.ID, ErrorInvalidCourrier},
+		{"unknown courrier", "unknown", ErrorInvalidCourrier},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			packageModel := model.Package{
+				SenderID: sender.ID, ReceiverID: &receiver.ID, CompanyID: company.ID, Weight: 1,
+				RegisteredByID: &clerk.ID, CourrierID: &test.courrierID,
+				IsDeliveredToOffice: true, OfficeAcceptedAtID: &office.ID, OfficeDeliveredAtID: &office.ID,
+			}
+			err := r.PackageRepository.CreatePackage(context.Background(), &packageModel)
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("CreatePackage() error = %v, want %v", err, test.wantErr)
+			}
+		})
+	}
+}
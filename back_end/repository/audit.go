@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"logistic_company/config"
+	"logistic_company/model"
+	"reflect"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AuditActor describes who performed a mutation. It is attached to the
+// request context by the API middleware and read back when the repositories
+// write audit log entries.
+type AuditActor struct {
+	ID        string
+	Role      string
+	IP        string
+	RequestID string
+}
+
+type auditActorKey struct{}
+
+func WithAuditActor(ctx context.Context, actor AuditActor) context.Context {
+	return context.WithValue(ctx, auditActorKey{}, actor)
+}
+
+func AuditActorFromContext(ctx context.Context) AuditActor {
+	actor, _ := ctx.Value(auditActorKey{}).(AuditActor)
+	return actor
+}
+
+type AuditRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditRepository(db *gorm.DB) *AuditRepository {
+	return &AuditRepository{
+		db: db,
+	}
+}
+
+func (a *AuditRepository) GetAuditLogs(ctx context.Context, logs *[]model.AuditLog, filter model.AuditFilter, limit, offset int) error {
+	query := a.db.WithContext(ctx).Model(&model.AuditLog{})
+	if filter.ActorID != "" {
+		query = query.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.ActorRole != "" {
+		query = query.Where("actor_role = ?", filter.ActorRole)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != "" {
+		query = query.Where("entity_id = ?", filter.EntityID)
+	}
+	if filter.From != "" {
+		from, _, err := auditFilterTime(filter.From)
+		if err != nil {
+			return err
+		}
+		query = query.Where("created_at >= ?", from)
+	}
+	if filter.To != "" {
+		to, wholeDay, err := auditFilterTime(filter.To)
+		if err != nil {
+			return err
+		}
+		if wholeDay {
+			query = query.Where("created_at < ?", to.AddDate(0, 0, 1))
+		} else {
+			query = query.Where("created_at <= ?", to)
+		}
+	}
+
+	return query.Order("created_at DESC").Limit(limit).Offset(offset).Find(logs).Error
+}
+
+// auditFilterTime parses a from or to filter of the audit trail, either an
+// RFC 3339 time or a date that stands for the whole day.
+func auditFilterTime(value string) (at time.Time, wholeDay bool, err error) {
+	if at, err = time.Parse(time.RFC3339, value); err == nil {
+		return at, false, nil
+	}
+	if at, err = time.ParseInLocation(config.DateFormat, value, time.Local); err == nil {
+		return at, true, nil
+	}
+	return time.Time{}, false, ErrorInvalidAuditFilter
+}
+
+// recordAudit stores an audit log entry for a mutation performed inside tx.
+// before and after are the states of the entity around the mutation, either
+// of them may be nil for creations and deletions. Only the fields that differ
+// between the two states are stored.
+func recordAudit(ctx context.Context, tx *gorm.DB, action, entityType, entityID string, before, after interface{}) error {
+	changes, err := auditDiff(before, after)
+	if err != nil {
+		return err
+	}
+
+	actor := AuditActorFromContext(ctx)
+	return tx.Create(&model.AuditLog{
+		ActorID:    actor.ID,
+		ActorRole:  actor.Role,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Changes:    changes,
+		IP:         actor.IP,
+		RequestID:  actor.RequestID,
+		CreatedAt:  time.Now(),
+	}).Error
+}
+
+type auditChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+func auditDiff(before, after interface{}) (json.RawMessage, error) {
+	beforeFields, err := auditFields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := auditFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := map[string]auditChange{}
+	for key, value := range beforeFields {
+		if !reflect.DeepEqual(value, afterFields[key]) {
+			changes[key] = auditChange{Before: value, After: afterFields[key]}
+		}
+	}
+	for key, value := range afterFields {
+		if _, ok := beforeFields[key]; !ok && value != nil {
+			changes[key] = auditChange{After: value}
+		}
+	}
+
+	return json.Marshal(changes)
+}
+
+// auditFields flattens an entity into its JSON fields, leaving out nested
+// objects such as preloaded associations.
+func auditFields(entity interface{}) (map[string]interface{}, error) {
+	fields := map[string]interface{}{}
+	if entity == nil {
+		return fields, nil
+	}
+	if value := reflect.ValueOf(entity); value.Kind() == reflect.Ptr && value.IsNil() {
+		return fields, nil
+	}
+
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for key, value := range fields {
+		if _, ok := value.(map[string]interface{}); ok {
+			delete(fields, key)
+		}
+	}
+
+	return fields, nil
+}
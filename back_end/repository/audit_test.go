@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"logistic_company/model"
+	"testing"
+	"time"
+)
+
+func TestGetAuditLogsDateFilter(t *testing.T) {
+	r := newTestRepository(t)
+	for _, at := range []time.Time{
+		time.Date(2024, 2, 29, 23, 59, 0, 0, time.Local),
+		time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local),
+		time.Date(2024, 3, 2, 23, 30, 0, 0, time.Local),
+		time.Date(2024, 3, 3, 0, 0, 0, 0, time.Local),
+	} {
+		if err := r.db.Create(&model.AuditLog{Action: "create", EntityType: "company", EntityID: at.String(), CreatedAt: at}).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		from, to string
+		want     int
+		wantErr  error
+	}{
+		{"no filter", "", "", 4, nil},
+		{"whole days", "2024-03-01", "2024-03-02", 2, nil},
+		{"from a time", time.Date(2024, 3, 2, 23, 30, 0, 0, time.Local).Format(time.RFC3339), "", 2, nil},
+		{"to a time", "", time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local).Format(time.RFC3339), 2, nil},
+		{"invalid from", "yesterday", "", 0, ErrorInvalidAuditFilter},
+		{"injected to", "", "2024-03-01' OR '1'='1", 0, ErrorInvalidAuditFilter},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			logs := []model.AuditLog{}
+			err := r.AuditRepository.GetAuditLogs(context.Background(), &logs, model.AuditFilter{From: test.from, To: test.to}, 10, 0)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("GetAuditLogs() error = %v, want %v", err, test.wantErr)
+			}
+			if len(logs) != test.want {
+				t.Errorf("GetAuditLogs() returned %d logs, want %d", len(logs), test.want)
+			}
+		})
+	}
+}
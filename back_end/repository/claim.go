@@ -0,0 +1,220 @@
+package repository
+
+import (
+	"context"
+	"logistic_company/config"
+	"logistic_company/model"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ClaimRepository struct {
+	db *gorm.DB
+}
+
+func NewClaimRepository(db *gorm.DB) *ClaimRepository {
+	return &ClaimRepository{
+		db: db,
+	}
+}
+
+// claimTransitions lists the statuses a claim can move to from each status.
+var claimTransitions = map[string][]string{
+	config.ClaimStatusSubmitted:   {config.ClaimStatusUnderReview, config.ClaimStatusRejected},
+	config.ClaimStatusUnderReview: {config.ClaimStatusApproved, config.ClaimStatusRejected},
+	config.ClaimStatusApproved:    {config.ClaimStatusPaid},
+}
+
+func claimTransitionAllowed(from, to string) bool {
+	for _, status := range claimTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// priceInsurance sets the premium of an insured package from the rates of
+// its company.
+func priceInsurance(tx *gorm.DB, packageModel *model.Package) error {
+	if !packageModel.Insured {
+		packageModel.InsurancePremium = 0
+		return nil
+	}
+	company := model.Company{}
+	if err := tx.Where("id = ?", packageModel.CompanyID).First(&company).Error; err != nil {
+		return err
+	}
+	premium := packageModel.DeclaredValue * company.InsuranceRatePercentage / 100
+	packageModel.InsurancePremium = roundCents(math.Max(premium, company.InsuranceMinPremium))
+	return nil
+}
+
+func preloadClaim(db *gorm.DB) *gorm.DB {
+	return db.Preload("Package").Preload("Evidence")
+}
+
+func (r *ClaimRepository) GetClaimByID(ctx context.Context, claim *model.InsuranceClaim, id string) error {
+	return r.db.WithContext(ctx).Scopes(preloadClaim).Where("id = ?", id).First(claim).Error
+}
+
+func (r *ClaimRepository) GetClaimsByPackageID(ctx context.Context, claims *[]model.InsuranceClaim, packageID string) error {
+	return r.db.WithContext(ctx).Preload("Evidence").Where("package_id = ?", packageID).Order("created_at").Find(claims).Error
+}
+
+func (r *ClaimRepository) GetClaimsByCompanyID(ctx context.Context, claims *[]model.InsuranceClaim, companyID, status string, limit, offset int) error {
+	query := r.db.WithContext(ctx).Scopes(preloadClaim).Where("company_id = ?", companyID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	return query.Order("created_at DESC").Limit(limit).Offset(offset).Find(claims).Error
+}
+
+// CreateClaim files a claim for an insured package. A package can only have
+// one claim that was not rejected.
+func (r *ClaimRepository) CreateClaim(ctx context.Context, claim *model.InsuranceClaim) error {
+	if claim.Type != config.ClaimTypeLost && claim.Type != config.ClaimTypeDamaged {
+		return ErrorInvalidClaim
+	}
+
+	tx := r.db.WithContext(ctx).Begin()
+
+	packageModel := model.Package{}
+	if err := tx.Where("id = ?", claim.PackageID).First(&packageModel).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if !packageModel.Insured {
+		tx.Rollback()
+		return ErrorNotInsured
+	}
+	if claim.ClaimedAmount <= 0 || claim.ClaimedAmount > packageModel.DeclaredValue {
+		tx.Rollback()
+		return ErrorInvalidClaim
+	}
+
+	var open int64
+	if err := tx.Model(&model.InsuranceClaim{}).Where("package_id = ? AND status <> ?", claim.PackageID, config.ClaimStatusRejected).
+		Count(&open).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if open > 0 {
+		tx.Rollback()
+		return ErrorClaimExists
+	}
+
+	claim.CompanyID = packageModel.CompanyID
+	claim.Status = config.ClaimStatusSubmitted
+	claim.ClaimedAmount = roundCents(claim.ClaimedAmount)
+	if err := tx.Create(claim).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionCreate, config.AuditEntityClaim, claim.ID, nil, claim); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// AddEvidence attaches an uploaded file to a claim that is still being
+// decided on.
+func (r *ClaimRepository) AddEvidence(ctx context.Context, evidence *model.ClaimEvidence) error {
+	tx := r.db.WithContext(ctx).Begin()
+
+	claim := model.InsuranceClaim{}
+	if err := tx.Where("id = ?", evidence.ClaimID).First(&claim).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if claim.Status != config.ClaimStatusSubmitted && claim.Status != config.ClaimStatusUnderReview {
+		tx.Rollback()
+		return ErrorInvalidClaim
+	}
+	if err := tx.Create(evidence).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionUpdate, config.AuditEntityClaim, claim.ID,
+		nil, map[string]string{"evidence": evidence.FileName}); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (r *ClaimRepository) GetEvidence(ctx context.Context, evidence *model.ClaimEvidence, claimID, id string) error {
+	return r.db.WithContext(ctx).Where("id = ? AND claim_id = ?", id, claimID).First(evidence).Error
+}
+
+// UpdateClaimStatus moves a claim to the next status of its workflow. Paying
+// an approved claim takes the approved amount off the company revenue.
+func (r *ClaimRepository) UpdateClaimStatus(ctx context.Context, claim *model.InsuranceClaim, id string, request model.ClaimStatusRequest, reviewerID string) error {
+	tx := r.db.WithContext(ctx).Begin()
+
+	if err := tx.Where("id = ?", id).First(claim).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if !claimTransitionAllowed(claim.Status, request.Status) {
+		tx.Rollback()
+		return ErrorInvalidClaimTransition
+	}
+
+	before := *claim
+	claim.Status = request.Status
+	claim.ReviewedByID = &reviewerID
+	if request.Note != "" {
+		claim.ResolutionNote = request.Note
+	}
+	switch request.Status {
+	case config.ClaimStatusApproved:
+		if request.ApprovedAmount <= 0 || request.ApprovedAmount > claim.ClaimedAmount {
+			tx.Rollback()
+			return ErrorInvalidClaim
+		}
+		claim.ApprovedAmount = roundCents(request.ApprovedAmount)
+	case config.ClaimStatusPaid:
+		now := time.Now()
+		claim.PaidAt = &now
+		if err := tx.Model(&model.Company{}).Where("id = ?", claim.CompanyID).
+			Update("revenue", gorm.Expr("revenue - ?", claim.ApprovedAmount)).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := tx.Model(&model.InsuranceClaim{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":          claim.Status,
+		"reviewed_by":     claim.ReviewedByID,
+		"resolution_note": claim.ResolutionNote,
+		"approved_amount": claim.ApprovedAmount,
+		"paid_at":         claim.PaidAt,
+	}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionUpdate, config.AuditEntityClaim, id, &before, claim); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+	return r.GetClaimByID(ctx, claim, id)
+}
+
+// paidClaims sums the claims of a company paid between startDate and endDate.
+func paidClaims(db *gorm.DB, companyID, startDate, endDate string) (float64, error) {
+	var total float64
+	err := db.Model(&model.InsuranceClaim{}).Select("COALESCE(SUM(approved_amount), 0)").
+		Where("company_id = ? AND status = ? AND paid_at BETWEEN ? AND ?", companyID, config.ClaimStatusPaid, startDate, endDate).
+		Scan(&total).Error
+	return total, err
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"logistic_company/config"
+	"testing"
+)
+
+func TestClaimTransitionAllowed(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     bool
+	}{
+		{config.ClaimStatusSubmitted, config.ClaimStatusUnderReview, true},
+		{config.ClaimStatusSubmitted, config.ClaimStatusRejected, true},
+		{config.ClaimStatusSubmitted, config.ClaimStatusApproved, false},
+		{config.ClaimStatusUnderReview, config.ClaimStatusApproved, true},
+		{config.ClaimStatusUnderReview, config.ClaimStatusRejected, true},
+		{config.ClaimStatusApproved, config.ClaimStatusPaid, true},
+		{config.ClaimStatusApproved, config.ClaimStatusRejected, false},
+		{config.ClaimStatusRejected, config.ClaimStatusUnderReview, false},
+		{config.ClaimStatusPaid, config.ClaimStatusApproved, false},
+	}
+	for _, test := range tests {
+		if got := claimTransitionAllowed(test.from, test.to); got != test.want {
+			t.Errorf("claimTransitionAllowed(%q, %q) = %v, want %v", test.from, test.to, got, test.want)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"logistic_company/config"
 	"logistic_company/model"
 
 	"gorm.io/gorm"
@@ -30,17 +31,82 @@ func (c *ClientRepository) GetClientsByName(ctx context.Context, clients *[]mode
 }
 
 func (c *ClientRepository) GetClientByID(ctx context.Context, client *model.Client, id string) error {
-	return c.db.WithContext(ctx).Where("id = ?", id).First(client).Error
+	return c.db.WithContext(ctx).Preload("Addresses", notArchived).Where("id = ?", id).First(client).Error
 }
 
 func (c *ClientRepository) CreateClient(ctx context.Context, client *model.ClientRegister) error {
-	return c.db.WithContext(ctx).Model(&client).Create(client).Error
+	tx := c.db.WithContext(ctx).Begin()
+
+	if err := tx.Model(&client).Omit("Addresses").Create(client).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionCreate, config.AuditEntityClient, client.ID, nil, &client.Client); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
 }
 
 func (c *ClientRepository) UpdateClient(ctx context.Context, client *model.ClientRegister) error {
-	return c.db.WithContext(ctx).Model(&client).Where("id = ?", client.ID).Updates(&client).Error
+	tx := c.db.WithContext(ctx).Begin()
+
+	before := model.Client{}
+	if err := tx.Where("id = ?", client.ID).First(&before).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Model(&client).Omit("Addresses").Where("id = ?", client.ID).Updates(&client).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	after := model.Client{}
+	if err := tx.Where("id = ?", client.ID).First(&after).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	// Verifications of an email or phone the client no longer has are void
+	changed := []string{}
+	for _, channel := range []string{config.ChannelEmail, config.ChannelSMS} {
+		if verificationTarget(&before, channel) != verificationTarget(&after, channel) {
+			changed = append(changed, channel)
+		}
+	}
+	if len(changed) > 0 {
+		if err := forgetVerifications(tx, client.ID, changed...); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionUpdate, config.AuditEntityClient, client.ID, &before, &after); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
 }
 
 func (c *ClientRepository) DeleteClient(ctx context.Context, id string) error {
-	return c.db.WithContext(ctx).Where("id = ?", id).Delete(&model.Client{}).Error
+	tx := c.db.WithContext(ctx).Begin()
+
+	before := model.Client{}
+	if err := tx.Where("id = ?", id).First(&before).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := forgetVerifications(tx, id); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Where("id = ?", id).Delete(&model.Client{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionDelete, config.AuditEntityClient, id, &before, nil); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
 }
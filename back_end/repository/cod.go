@@ -0,0 +1,319 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"logistic_company/config"
+	"logistic_company/model"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type CODRepository struct {
+	db *gorm.DB
+}
+
+func NewCODRepository(db *gorm.DB) *CODRepository {
+	return &CODRepository{
+		db: db,
+	}
+}
+
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+// priceCOD sets the currency and the fee the company charges for collecting
+// the COD amount of a new package.
+func priceCOD(tx *gorm.DB, packageModel *model.Package) error {
+	if packageModel.CODAmount <= 0 {
+		packageModel.CODAmount = 0
+		packageModel.CODFee = 0
+		return nil
+	}
+	company := model.Company{}
+	if err := tx.Where("id = ?", packageModel.CompanyID).First(&company).Error; err != nil {
+		return err
+	}
+	if packageModel.CODCurrency == "" {
+		packageModel.CODCurrency = config.DefaultCurrency
+	}
+	packageModel.CODFee = roundCents(packageModel.CODAmount * company.CODFeePercentage / 100)
+	return nil
+}
+
+// recordCODCollection records that the courier collected the COD amount of
+// a package when handing it over.
+func recordCODCollection(ctx context.Context, tx *gorm.DB, packageModel *model.Package) error {
+	if packageModel.CODAmount <= 0 {
+		return nil
+	}
+	var existing int64
+	if err := tx.Model(&model.CODCollection{}).Where("package_id = ?", packageModel.ID).Count(&existing).Error; err != nil {
+		return err
+	}
+	if existing > 0 {
+		return nil
+	}
+
+	collection := model.CODCollection{
+		PackageID:   packageModel.ID,
+		CourrierID:  stringValue(packageModel.CourrierID),
+		CompanyID:   packageModel.CompanyID,
+		SenderID:    packageModel.SenderID,
+		Amount:      packageModel.CODAmount,
+		Fee:         packageModel.CODFee,
+		Currency:    packageModel.CODCurrency,
+		CollectedAt: time.Now(),
+	}
+	if err := tx.Create(&collection).Error; err != nil {
+		return err
+	}
+	return recordAudit(ctx, tx, config.AuditActionCreate, config.AuditEntityCOD, collection.ID, nil, &collection)
+}
+
+// GetPendingCollections returns the cash a courier collected and has not
+// handed over yet.
+func (r *CODRepository) GetPendingCollections(ctx context.Context, collections *[]model.CODCollection, courrierID string) error {
+	return r.db.WithContext(ctx).Where("courrier_id = ? AND handover_id IS NULL", courrierID).
+		Order("collected_at").Find(collections).Error
+}
+
+// CreateHandover records the cash in one currency a courier handed over for
+// a day. Every collection in that currency up to the end of that day that
+// was not handed over yet is part of the handover, and a handover that does
+// not match them is flagged.
+func (r *CODRepository) CreateHandover(ctx context.Context, handover *model.CashHandover, request model.CashHandoverRequest, receivedByID string) error {
+	date, err := time.ParseInLocation(config.DateFormat, request.Date, time.Local)
+	if err != nil || request.ReceivedAmount < 0 {
+		return ErrorInvalidHandover
+	}
+	if request.Currency == "" {
+		request.Currency = config.DefaultCurrency
+	}
+	if len(request.Currency) != 3 {
+		return ErrorInvalidHandover
+	}
+
+	tx := r.db.WithContext(ctx).Begin()
+
+	courrier := model.Employee{}
+	if err := tx.Where("id = ? AND role = ?", request.CourrierID, config.RoleCourrier).First(&courrier).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	err = tx.Where("courrier_id = ? AND handover_date = ? AND currency = ?", courrier.ID, date, request.Currency).
+		First(&model.CashHandover{}).Error
+	if err == nil {
+		tx.Rollback()
+		return ErrorHandoverExists
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		tx.Rollback()
+		return err
+	}
+
+	collections := []model.CODCollection{}
+	if err := tx.Scopes(forUpdate).
+		Where("courrier_id = ? AND currency = ? AND handover_id IS NULL AND collected_at < ?", courrier.ID, request.Currency, date.AddDate(0, 0, 1)).
+		Find(&collections).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	*handover = model.CashHandover{
+		CourrierID:     courrier.ID,
+		Date:           date,
+		Currency:       request.Currency,
+		ReceivedByID:   receivedByID,
+		ReceivedAmount: roundCents(request.ReceivedAmount),
+		Note:           request.Note,
+	}
+	if courrier.CompanyID != nil {
+		handover.CompanyID = *courrier.CompanyID
+	}
+	collectionIDs := []string{}
+	for _, collection := range collections {
+		handover.ExpectedAmount += collection.Amount
+		collectionIDs = append(collectionIDs, collection.ID)
+	}
+	handover.ExpectedAmount = roundCents(handover.ExpectedAmount)
+	handover.Discrepancy = roundCents(handover.ReceivedAmount - handover.ExpectedAmount)
+	handover.Flagged = handover.Discrepancy != 0
+
+	if err := tx.Create(handover).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if len(collectionIDs) > 0 {
+		if err := tx.Model(&model.CODCollection{}).Where("id IN ?", collectionIDs).
+			Update("handover_id", handover.ID).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionCreate, config.AuditEntityHandover, handover.ID, nil, handover); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+	return r.GetHandoverByID(ctx, handover, handover.ID)
+}
+
+func (r *CODRepository) GetHandoverByID(ctx context.Context, handover *model.CashHandover, id string) error {
+	return r.db.WithContext(ctx).Preload("Courrier").Preload("Collections").Where("id = ?", id).First(handover).Error
+}
+
+func (r *CODRepository) GetHandovers(ctx context.Context, handovers *[]model.CashHandover, companyID string, flaggedOnly bool, limit, offset int) error {
+	query := r.db.WithContext(ctx).Preload("Courrier").Where("company_id = ?", companyID)
+	if flaggedOnly {
+		query = query.Where("flagged = ?", true)
+	}
+	return query.Order("handover_date DESC").Limit(limit).Offset(offset).Find(handovers).Error
+}
+
+// CreatePayouts batches the handed over cash of a company that was not paid
+// out yet into one payout per sender and currency.
+func (r *CODRepository) CreatePayouts(ctx context.Context, batches *[]model.PayoutBatch, companyID string) error {
+	tx := r.db.WithContext(ctx).Begin()
+
+	collections := []model.CODCollection{}
+	if err := tx.Scopes(forUpdate).Where("company_id = ? AND handover_id IS NOT NULL AND payout_id IS NULL", companyID).
+		Order("sender_id, currency, collected_at").Find(&collections).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	*batches = []model.PayoutBatch{}
+	for start := 0; start < len(collections); {
+		end := start
+		batch := model.PayoutBatch{
+			CompanyID: companyID,
+			SenderID:  collections[start].SenderID,
+			Currency:  collections[start].Currency,
+		}
+		collectionIDs := []string{}
+		for ; end < len(collections) && collections[end].SenderID == batch.SenderID &&
+			collections[end].Currency == batch.Currency; end++ {
+			batch.Collected += collections[end].Amount
+			batch.Fees += collections[end].Fee
+			collectionIDs = append(collectionIDs, collections[end].ID)
+		}
+		batch.Collected = roundCents(batch.Collected)
+		batch.Fees = roundCents(batch.Fees)
+		batch.Amount = roundCents(batch.Collected - batch.Fees)
+
+		if err := tx.Create(&batch).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := tx.Model(&model.CODCollection{}).Where("id IN ?", collectionIDs).
+			Update("payout_id", batch.ID).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := recordAudit(ctx, tx, config.AuditActionCreate, config.AuditEntityPayout, batch.ID, nil, &batch); err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		*batches = append(*batches, batch)
+		start = end
+	}
+
+	return tx.Commit().Error
+}
+
+func (r *CODRepository) GetPayouts(ctx context.Context, batches *[]model.PayoutBatch, companyID, senderID string, limit, offset int) error {
+	query := r.db.WithContext(ctx).Preload("Sender").Preload("Collections")
+	if companyID != "" {
+		query = query.Where("company_id = ?", companyID)
+	}
+	if senderID != "" {
+		query = query.Where("sender_id = ?", senderID)
+	}
+	return query.Order("created_at DESC").Limit(limit).Offset(offset).Find(batches).Error
+}
+
+func (r *CODRepository) MarkPayoutPaid(ctx context.Context, batch *model.PayoutBatch, id string) error {
+	tx := r.db.WithContext(ctx).Begin()
+
+	if err := tx.Where("id = ?", id).First(batch).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if batch.PaidAt != nil {
+		tx.Rollback()
+		return ErrorPayoutPaid
+	}
+
+	before := *batch
+	now := time.Now()
+	batch.PaidAt = &now
+	if err := tx.Model(&model.PayoutBatch{}).Where("id = ?", id).Update("paid_at", now).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionUpdate, config.AuditEntityPayout, id, &before, batch); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// GetRevenueReport splits the revenue of a company between startDate and
+// endDate into delivery prices, COD fees, insurance premiums, storage fees and
+// paid claims, next to the COD cash that went through the company.
+func (c *CompanyRepository) GetRevenueReport(ctx context.Context, report *model.RevenueReport, id, startDate, endDate string) error {
+	db := c.db.WithContext(ctx)
+	if err := db.Where("id = ?", id).First(&model.Company{}).Error; err != nil {
+		return err
+	}
+
+	*report = model.RevenueReport{CompanyID: id, StartDate: startDate, EndDate: endDate}
+	delivered := db.Model(&model.Package{}).
+		Where("company_id = ? AND delivery_date BETWEEN ? AND ?", id, startDate, endDate)
+	if err := delivered.Session(&gorm.Session{}).Select("COALESCE(SUM(price), 0)").Scan(&report.DeliveryRevenue).Error; err != nil {
+		return err
+	}
+	if err := delivered.Session(&gorm.Session{}).Select("COALESCE(SUM(cod_fee), 0)").Scan(&report.CODFees).Error; err != nil {
+		return err
+	}
+	if err := delivered.Session(&gorm.Session{}).Select("COALESCE(SUM(insurance_premium), 0)").
+		Scan(&report.InsurancePremiums).Error; err != nil {
+		return err
+	}
+	if err := delivered.Session(&gorm.Session{}).Select("COALESCE(SUM(storage_fee), 0)").
+		Scan(&report.StorageFees).Error; err != nil {
+		return err
+	}
+	claims, err := paidClaims(db, id, startDate, endDate)
+	if err != nil {
+		return err
+	}
+	if err := db.Model(&model.CODCollection{}).Select("COALESCE(SUM(amount), 0)").
+		Where("company_id = ? AND collected_at BETWEEN ? AND ?", id, startDate, endDate).
+		Scan(&report.CODCollected).Error; err != nil {
+		return err
+	}
+	if err := db.Model(&model.PayoutBatch{}).Select("COALESCE(SUM(amount), 0)").
+		Where("company_id = ? AND paid_at BETWEEN ? AND ?", id, startDate, endDate).
+		Scan(&report.CODPaidOut).Error; err != nil {
+		return err
+	}
+
+	report.DeliveryRevenue = roundCents(report.DeliveryRevenue)
+	report.CODFees = roundCents(report.CODFees)
+	report.InsurancePremiums = roundCents(report.InsurancePremiums)
+	report.StorageFees = roundCents(report.StorageFees)
+	report.ClaimPayouts = -roundCents(claims)
+	report.Total = roundCents(report.DeliveryRevenue + report.CODFees + report.InsurancePremiums + report.StorageFees +
+		report.ClaimPayouts)
+	return nil
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"logistic_company/config"
+	"logistic_company/model"
+	"testing"
+	"time"
+)
+
+func TestCreateHandoverPerCurrency(t *testing.T) {
+	r := newTestRepository(t)
+	ctx := context.Background()
+	company := createTestCompany(t, r, model.Company{})
+	courrier := createTestEmployee(t, r, config.RoleCourrier, company.ID, nil)
+	sender := createTestClient(t, r, "", "")
+	collectedAt := time.Now()
+	collections := []model.CODCollection{
+		{PackageID: "eur-1", Amount: 10, Currency: "EUR"},
+		{PackageID: "eur-2", Amount: 5.5, Currency: "EUR"},
+		{PackageID: "bgn-1", Amount: 20, Currency: "BGN"},
+	}
+	for i := range collections {
+		collections[i].CourrierID = courrier.ID
+		collections[i].CompanyID = company.ID
+		collections[i].SenderID = sender.ID
+		collections[i].CollectedAt = collectedAt
+	}
+	if err := r.db.Create(&collections).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	date := collectedAt.Format(config.DateFormat)
+	tests := []struct {
+		name     string
+		currency string
+		received float64
+		expected float64
+		flagged  bool
+		err      error
+	}{
+		{"default currency", "", 15.5, 15.5, false, nil},
+		{"same day and currency again", "EUR", 0, 0, false, ErrorHandoverExists},
+		{"other currency", "BGN", 19, 20, true, nil},
+		{"invalid currency", "LEVA", 20, 0, false, ErrorInvalidHandover},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handover := model.CashHandover{}
+			request := model.CashHandoverRequest{CourrierID: courrier.ID, Date: date, Currency: tt.currency, ReceivedAmount: tt.received}
+			err := r.CODRepository.CreateHandover(ctx, &handover, request, "")
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("CreateHandover() error = %v, want %v", err, tt.err)
+			}
+			if tt.err != nil {
+				return
+			}
+			if handover.ExpectedAmount != tt.expected || handover.Flagged != tt.flagged {
+				t.Errorf("CreateHandover() expected %v flagged %v, want %v flagged %v",
+					handover.ExpectedAmount, handover.Flagged, tt.expected, tt.flagged)
+			}
+			for _, collection := range handover.Collections {
+				if collection.Currency != handover.Currency {
+					t.Errorf("%s handover covers a %s collection", handover.Currency, collection.Currency)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,331 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"logistic_company/config"
+	"logistic_company/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type CollectionRepository struct {
+	db *gorm.DB
+}
+
+func NewCollectionRepository(db *gorm.DB) *CollectionRepository {
+	return &CollectionRepository{
+		db: db,
+	}
+}
+
+func preloadCollection(db *gorm.DB) *gorm.DB {
+	return db.Preload("Sender").Preload("Address").Preload("Courrier").Preload("Packages")
+}
+
+// onDay limits collections to the ones whose window starts on the day of t.
+func onDay(t time.Time) func(db *gorm.DB) *gorm.DB {
+	start := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("window_start >= ? AND window_start < ?", start, start.AddDate(0, 0, 1))
+	}
+}
+
+func (c *CollectionRepository) GetCollectionByID(ctx context.Context, collection *model.Collection, id string) error {
+	return c.db.WithContext(ctx).Scopes(preloadCollection).Where("id = ?", id).First(collection).Error
+}
+
+func (c *CollectionRepository) GetCollectionsByCompanyID(ctx context.Context, collections *[]model.Collection, companyID, date, status string, limit, offset int) error {
+	query := c.db.WithContext(ctx).Scopes(preloadCollection).Where("company_id = ?", companyID)
+	if date != "" {
+		day, err := time.Parse(config.DateFormat, date)
+		if err != nil {
+			return ErrorInvalidCollection
+		}
+		query = query.Scopes(onDay(day))
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	return query.Order("window_start").Limit(limit).Offset(offset).Find(collections).Error
+}
+
+func (c *CollectionRepository) GetCollectionsBySenderID(ctx context.Context, collections *[]model.Collection, senderID string, limit, offset int) error {
+	return c.db.WithContext(ctx).Scopes(preloadCollection).Where("sender_id = ?", senderID).
+		Order("window_start DESC").Limit(limit).Offset(offset).Find(collections).Error
+}
+
+// CreateCollection schedules a collection from an address of the sender.
+// The requested packages in packageIDs must be waiting to be collected from
+// that address.
+func (c *CollectionRepository) CreateCollection(ctx context.Context, collection *model.Collection, packageIDs []string) error {
+	if !collection.WindowEnd.After(collection.WindowStart) || collection.WindowEnd.Before(time.Now()) ||
+		collection.WindowStart.Format(config.DateFormat) != collection.WindowEnd.Format(config.DateFormat) {
+		return ErrorInvalidCollection
+	}
+	if collection.Parcels == 0 {
+		collection.Parcels = len(packageIDs)
+	}
+	if collection.Parcels == 0 {
+		return ErrorInvalidCollection
+	}
+	tx := c.db.WithContext(ctx).Begin()
+
+	if err := tx.Where("id = ?", collection.CompanyID).First(&model.Company{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Scopes(notArchived).Where("id = ? AND client_id = ?", collection.AddressID, collection.SenderID).
+		First(&model.Address{}).Error; err != nil {
+		tx.Rollback()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrorInvalidAddress
+		}
+		return err
+	}
+	if len(packageIDs) > 0 {
+		var count int64
+		if err := tx.Model(&model.Package{}).Where("id IN ?", packageIDs).
+			Where("sender_id = ? AND company_id = ?", collection.SenderID, collection.CompanyID).
+			Where("delivery_status = ? AND pickup_address_id = ?", config.StatusRequested, collection.AddressID).
+			Where("collection_id IS NULL").Count(&count).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+		if count != int64(len(packageIDs)) {
+			tx.Rollback()
+			return ErrorInvalidCollection
+		}
+	}
+
+	collection.Status = config.CollectionScheduled
+	if err := tx.Create(collection).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if len(packageIDs) > 0 {
+		if err := tx.Model(&model.Package{}).Where("id IN ?", packageIDs).Update("collection_id", collection.ID).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionCreate, config.AuditEntityCollection, collection.ID, nil, collection); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// AssignCollection assigns a collection to a courier of its company. Without
+// a courier the one with the fewest collections that day gets it.
+func (c *CollectionRepository) AssignCollection(ctx context.Context, collection *model.Collection, id, courrierID string) error {
+	tx := c.db.WithContext(ctx).Begin()
+
+	if err := tx.Where("id = ?", id).First(collection).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if collection.Status != config.CollectionScheduled && collection.Status != config.CollectionAssigned {
+		tx.Rollback()
+		return ErrorInvalidCollection
+	}
+
+	courriers := []model.Employee{}
+	query := tx.Model(&model.Employee{}).Scopes(notArchived).
+		Where("role = ? AND company_id = ?", config.RoleCourrier, collection.CompanyID)
+	if courrierID != "" {
+		query = query.Where("id = ?", courrierID)
+	}
+	if err := query.Order("id").Find(&courriers).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if len(courriers) == 0 {
+		tx.Rollback()
+		if courrierID != "" {
+			return ErrorInvalidCollection
+		}
+		return ErrorNoCourriersAvailable
+	}
+	courrier, err := leastCollections(tx, courriers, collection.WindowStart)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	before := *collection
+	collection.CourrierID = &courrier.ID
+	collection.Status = config.CollectionAssigned
+	if err := tx.Model(&model.Collection{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"courrier_id": collection.CourrierID,
+		"status":      collection.Status,
+	}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionUpdate, config.AuditEntityCollection, id, &before, collection); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := addToInbox(tx, courrier.ID, config.InboxCollection, "Collection assigned to you",
+		fmt.Sprintf("Collect %d parcels on %s between %s and %s.", collection.Parcels,
+			collection.WindowStart.Format(config.DateFormat), collection.WindowStart.Format(config.TimeOfDayFormat),
+			collection.WindowEnd.Format(config.TimeOfDayFormat)), nil); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// leastCollections picks the courier with the fewest collections on the day
+// of start, ties are broken by ID.
+func leastCollections(tx *gorm.DB, courriers []model.Employee, start time.Time) (model.Employee, error) {
+	ids := make([]string, 0, len(courriers))
+	for _, courrier := range courriers {
+		ids = append(ids, courrier.ID)
+	}
+	rows := []struct {
+		CourrierID  string
+		Collections int64
+	}{}
+	if err := tx.Model(&model.Collection{}).Select("courrier_id, COUNT(*) AS collections").
+		Where("courrier_id IN ? AND status <> ?", ids, config.CollectionCancelled).
+		Scopes(onDay(start)).
+		Group("courrier_id").Scan(&rows).Error; err != nil {
+		return model.Employee{}, err
+	}
+	counts := map[string]int64{}
+	for _, row := range rows {
+		counts[row.CourrierID] = row.Collections
+	}
+
+	best := courriers[0]
+	for _, courrier := range courriers[1:] {
+		if counts[courrier.ID] < counts[best.ID] {
+			best = courrier
+		}
+	}
+	return best, nil
+}
+
+// CancelCollection cancels a collection that was not completed. Its packages
+// stay requested and can be collected by another collection.
+func (c *CollectionRepository) CancelCollection(ctx context.Context, collection *model.Collection, id string) error {
+	tx := c.db.WithContext(ctx).Begin()
+
+	if err := tx.Where("id = ?", id).First(collection).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if collection.Status == config.CollectionCompleted || collection.Status == config.CollectionCancelled {
+		tx.Rollback()
+		return ErrorInvalidCollection
+	}
+
+	before := *collection
+	collection.Status = config.CollectionCancelled
+	if err := tx.Model(&model.Collection{}).Where("id = ?", id).Update("status", collection.Status).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Model(&model.Package{}).Where("collection_id = ?", id).Update("collection_id", nil).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionUpdate, config.AuditEntityCollection, id, &before, collection); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// CompleteCollection records that the courier collected the parcels. The
+// linked packages are accepted at the courier's office, weighed as measured
+// by the courier or as estimated by the sender.
+func (c *CollectionRepository) CompleteCollection(ctx context.Context, collection *model.Collection, id string, completion model.CollectionCompletion) error {
+	tx := c.db.WithContext(ctx).Begin()
+
+	if err := tx.Where("id = ?", id).First(collection).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if collection.Status != config.CollectionAssigned || collection.CourrierID == nil {
+		tx.Rollback()
+		return ErrorInvalidCollection
+	}
+	courrier := model.Employee{}
+	if err := tx.Where("id = ?", *collection.CourrierID).First(&courrier).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if courrier.OfficeID == nil {
+		tx.Rollback()
+		return fmt.Errorf("%w: courrier has no office", ErrorInvalidCollection)
+	}
+
+	packages := []model.Package{}
+	if err := tx.Where("collection_id = ? AND delivery_status = ?", id, config.StatusRequested).
+		Order("created_at").Find(&packages).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	for i := range packages {
+		confirmation, ok := completion.Parcels[packages[i].ID]
+		if !ok {
+			confirmation = model.ShipmentConfirmation{
+				Weight: packages[i].EstimatedWeight,
+				Length: packages[i].Length,
+				Width:  packages[i].Width,
+				Height: packages[i].Height,
+			}
+		}
+		confirmation.OfficeAcceptedAtID = *courrier.OfficeID
+		if err := acceptPackage(ctx, tx, &packages[i], courrier.ID, confirmation); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	before := *collection
+	now := time.Now()
+	collection.Status = config.CollectionCompleted
+	collection.CompletedAt = &now
+	if err := tx.Model(&model.Collection{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":       collection.Status,
+		"completed_at": collection.CompletedAt,
+	}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionUpdate, config.AuditEntityCollection, id, &before, collection); err != nil {
+		tx.Rollback()
+		return err
+	}
+	collection.Packages = packages
+
+	return tx.Commit().Error
+}
+
+// GetCourrierDay returns the collections of a courier on a day in the order
+// of their windows, and the packages they have to deliver that day.
+func (c *CollectionRepository) GetCourrierDay(ctx context.Context, day *model.CourrierDay, courrierID string, date time.Time) error {
+	db := c.db.WithContext(ctx)
+	day.Date = date.Format(config.DateFormat)
+	day.Collections = []model.Collection{}
+	if err := db.Preload("Sender").Preload("Address").
+		Where("courrier_id = ? AND status IN ?", courrierID, []string{config.CollectionAssigned, config.CollectionCompleted}).
+		Scopes(onDay(date)).
+		Order("window_start").Find(&day.Collections).Error; err != nil {
+		return err
+	}
+
+	day.Deliveries = []model.Package{}
+	return db.Preload("OfficeDeliveredAt").Preload("DeliveryAddress").
+		Scopes(deliverableOn(courrierID, date)).
+		Order("is_delivered_to_office, office_delivered_at, delivery_location").Find(&day.Deliveries).Error
+}
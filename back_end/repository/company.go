@@ -2,9 +2,10 @@ package repository
 
 import (
 	"context"
+	"fmt"
+	"logistic_company/config"
 	"logistic_company/model"
-
-	"gorm.io/gorm/clause"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -20,11 +21,11 @@ func NewCompanyRepository(db *gorm.DB) *CompanyRepository {
 }
 
 func (c *CompanyRepository) GetAllCompanies(ctx context.Context, companies *[]model.Company, limit, offset int) error {
-	return c.db.WithContext(ctx).Limit(limit).Offset(offset).Find(companies).Error
+	return c.db.WithContext(ctx).Scopes(notArchived).Limit(limit).Offset(offset).Find(companies).Error
 }
 
 func (c *CompanyRepository) GetCompaniesByName(ctx context.Context, companies *[]model.Company, name string, limit, offset int) error {
-	return c.db.WithContext(ctx).Limit(limit).Offset(offset).Where("name LIKE '%?%'", name).Find(companies).Error
+	return c.db.WithContext(ctx).Scopes(notArchived).Limit(limit).Offset(offset).Where("name LIKE '%?%'", name).Find(companies).Error
 }
 
 func (c *CompanyRepository) GetCompanyById(ctx context.Context, company *model.Company, id string) error {
@@ -40,22 +41,248 @@ func (c *CompanyRepository) GetCompanyWithRevenuePeriod(ctx context.Context, com
 
 	err = c.db.WithContext(ctx).Model(&model.Package{}).
 		Where("company_id = ? AND delivery_date BETWEEN ? AND ?", id, startDate, endDate).
-		Select("SUM(revenue) as revenue").
-		First(&company.Revenue).Error
+		Select("COALESCE(SUM(price + cod_fee + insurance_premium + storage_fee), 0)").
+		Scan(&company.Revenue).Error
+	if err != nil {
+		return err
+	}
+
+	claims, err := paidClaims(c.db.WithContext(ctx), id, startDate, endDate)
+	company.Revenue -= claims
 
 	return err
 }
 
 func (c *CompanyRepository) CreateCompany(ctx context.Context, company *model.Company) error {
-	return c.db.WithContext(ctx).Model(&company).Create(company).Error
+	tx := c.db.WithContext(ctx).Begin()
+
+	if err := tx.Model(&company).Create(company).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionCreate, config.AuditEntityCompany, company.ID, nil, company); err != nil {
+		tx.Rollback()
+		return err
+	}
+	packageTypes := defaultPackageTypes(company.ID)
+	if err := tx.Create(&packageTypes).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
 }
 
-func (c *CompanyRepository) UpdateCompany(ctx context.Context, company *model.Company) error {
-	return c.db.WithContext(ctx).Model(&company).Where("id = ?", company.ID).Save(company).Error
+// UpdateCompany writes the given fields of company. Fields that were not
+// given keep their stored values, as zero is a meaningful value for many
+// company settings. The archival time cannot be changed here.
+func (c *CompanyRepository) UpdateCompany(ctx context.Context, company *model.Company, fields []string) error {
+	tx := c.db.WithContext(ctx).Begin()
+
+	before := model.Company{}
+	if err := tx.Where("id = ?", company.ID).First(&before).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if len(fields) > 0 {
+		if err := tx.Model(company).Select(fields).Omit("ID", "ArchivedAt").Updates(company).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	if err := tx.Where("id = ?", company.ID).First(company).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionUpdate, config.AuditEntityCompany, company.ID, &before, company); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
 }
 
-func (c *CompanyRepository) DeleteCompany(ctx context.Context, id string) error {
-	return c.db.WithContext(ctx).
-		Model(&model.Company{}).Clauses(clause.OnConflict{UpdateAll: true}).
-		Where("id = ?", id).Delete(&model.Company{}).Error
+func (c *CompanyRepository) GetCompanyDeletionPreview(ctx context.Context, preview *model.CompanyDeletionPreview, id string) error {
+	return companyDeletionPreview(c.db.WithContext(ctx), preview, id, false)
+}
+
+// companyDeletionPreview lists what depends on a company. Deletions lock the
+// company and its dependents so nothing is added or changed while they run.
+func companyDeletionPreview(db *gorm.DB, preview *model.CompanyDeletionPreview, id string, lock bool) error {
+	scopes := []func(*gorm.DB) *gorm.DB{notArchived}
+	if lock {
+		scopes = append(scopes, forUpdate)
+	}
+
+	if err := db.Scopes(scopes...).Where("id = ?", id).First(&preview.Company).Error; err != nil {
+		return err
+	}
+	if err := db.Scopes(scopes...).Where("company_id = ?", id).Find(&preview.Offices).Error; err != nil {
+		return err
+	}
+	if err := db.Scopes(scopes...).Where("company_id = ?", id).Find(&preview.Employees).Error; err != nil {
+		return err
+	}
+	if err := db.Preload("Sender").Preload("Receiver").Scopes(scopes...).
+		Where("company_id = ?", id).Find(&preview.Packages).Error; err != nil {
+		return err
+	}
+
+	preview.UndeliveredPackages = []model.Package{}
+	for _, packageModel := range preview.Packages {
+		if !IsPackageClosed(&packageModel) {
+			preview.UndeliveredPackages = append(preview.UndeliveredPackages, packageModel)
+		}
+	}
+	preview.CanDeleteOutright = len(preview.Offices) == 0 && len(preview.Employees) == 0 && len(preview.Packages) == 0
+
+	return nil
+}
+
+// DeleteCompany removes a company according to mode. CompanyDeletionRefuse
+// only deletes companies nothing depends on, CompanyDeletionArchive archives
+// the company together with its offices, employees and packages, and
+// CompanyDeletionTransfer moves the staff and the undelivered packages to
+// targetCompanyID before archiving the rest.
+func (c *CompanyRepository) DeleteCompany(ctx context.Context, id, mode, targetCompanyID string) error {
+	tx := c.db.WithContext(ctx).Begin()
+
+	preview := model.CompanyDeletionPreview{}
+	if err := companyDeletionPreview(tx, &preview, id, true); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	var err error
+	switch mode {
+	case config.CompanyDeletionRefuse, "":
+		err = c.deleteUnusedCompany(ctx, tx, &preview)
+	case config.CompanyDeletionArchive:
+		err = c.archiveCompany(ctx, tx, &preview)
+	case config.CompanyDeletionTransfer:
+		err = c.transferCompany(ctx, tx, &preview, targetCompanyID)
+		if err == nil {
+			err = c.archiveCompany(ctx, tx, &preview)
+		}
+	default:
+		err = ErrorInvalidDeletionMode
+	}
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (c *CompanyRepository) deleteUnusedCompany(ctx context.Context, tx *gorm.DB, preview *model.CompanyDeletionPreview) error {
+	if !preview.CanDeleteOutright {
+		return ErrorCompanyHasDependents
+	}
+
+	if err := tx.Where("company_id = ?", preview.Company.ID).Delete(&model.PackageType{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("company_id = ?", preview.Company.ID).Delete(&model.Linehaul{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("id = ?", preview.Company.ID).Delete(&model.Company{}).Error; err != nil {
+		return err
+	}
+
+	return recordAudit(ctx, tx, config.AuditActionDelete, config.AuditEntityCompany, preview.Company.ID, &preview.Company, nil)
+}
+
+// transferCompany moves the employees and the undelivered packages listed in
+// preview to the target company. Moved employees lose their office, as the
+// offices stay behind and get archived. Undelivered packages that are still
+// accepted at or delivered to one of those offices have to be moved to
+// offices of the target company first.
+func (c *CompanyRepository) transferCompany(ctx context.Context, tx *gorm.DB, preview *model.CompanyDeletionPreview, targetCompanyID string) error {
+	target := model.Company{}
+	if err := tx.Scopes(notArchived).Where("id = ?", targetCompanyID).First(&target).Error; err != nil {
+		return ErrorInvalidTransferCompany
+	}
+	if target.ID == preview.Company.ID {
+		return ErrorInvalidTransferCompany
+	}
+
+	offices := map[string]bool{}
+	for _, office := range preview.Offices {
+		offices[office.ID] = true
+	}
+	for _, packageModel := range preview.UndeliveredPackages {
+		if offices[stringValue(packageModel.OfficeAcceptedAtID)] || offices[stringValue(packageModel.OfficeDeliveredAtID)] {
+			return fmt.Errorf("%w: package %s still goes through an office of the company", ErrorPackagesNeedOffice, packageModel.TrackingNumber)
+		}
+	}
+
+	for _, employee := range preview.Employees {
+		if err := tx.Model(&model.Employee{}).Where("id = ?", employee.ID).
+			Updates(map[string]interface{}{"company_id": target.ID, "office_id": nil}).Error; err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, tx, config.AuditActionTransfer, config.AuditEntityEmployee, employee.ID,
+			map[string]interface{}{"companyId": employee.CompanyID, "officeId": employee.OfficeID},
+			map[string]interface{}{"companyId": target.ID, "officeId": nil}); err != nil {
+			return err
+		}
+	}
+	preview.Employees = nil
+
+	for _, packageModel := range preview.UndeliveredPackages {
+		if err := tx.Model(&model.Package{}).Where("id = ?", packageModel.ID).Update("company_id", target.ID).Error; err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, tx, config.AuditActionTransfer, config.AuditEntityPackage, packageModel.ID,
+			map[string]string{"companyID": packageModel.CompanyID},
+			map[string]string{"companyID": target.ID}); err != nil {
+			return err
+		}
+	}
+
+	delivered := []model.Package{}
+	for _, packageModel := range preview.Packages {
+		if IsPackageClosed(&packageModel) {
+			delivered = append(delivered, packageModel)
+		}
+	}
+	preview.Packages = delivered
+	preview.UndeliveredPackages = nil
+
+	return nil
+}
+
+// archiveCompany marks the company and everything listed in preview as
+// archived, keeping the rows so historical packages stay consistent.
+func (c *CompanyRepository) archiveCompany(ctx context.Context, tx *gorm.DB, preview *model.CompanyDeletionPreview) error {
+	now := time.Now()
+
+	for _, office := range preview.Offices {
+		if err := archiveRow(ctx, tx, &model.Office{}, config.AuditEntityOffice, office.ID, now); err != nil {
+			return err
+		}
+	}
+	for _, employee := range preview.Employees {
+		if err := archiveRow(ctx, tx, &model.Employee{}, config.AuditEntityEmployee, employee.ID, now); err != nil {
+			return err
+		}
+	}
+	for _, packageModel := range preview.Packages {
+		if err := archiveRow(ctx, tx, &model.Package{}, config.AuditEntityPackage, packageModel.ID, now); err != nil {
+			return err
+		}
+	}
+
+	return archiveRow(ctx, tx, &model.Company{}, config.AuditEntityCompany, preview.Company.ID, now)
+}
+
+func archiveRow(ctx context.Context, tx *gorm.DB, entity interface{}, entityType, id string, archivedAt time.Time) error {
+	if err := tx.Model(entity).Where("id = ?", id).Update("archived_at", archivedAt).Error; err != nil {
+		return err
+	}
+
+	return recordAudit(ctx, tx, config.AuditActionArchive, entityType, id,
+		map[string]interface{}{"archivedAt": nil},
+		map[string]interface{}{"archivedAt": archivedAt})
 }
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"logistic_company/config"
+	"logistic_company/model"
+	"testing"
+	"time"
+)
+
+func TestUpdateCompanyKeepsFieldsNotGiven(t *testing.T) {
+	r := newTestRepository(t)
+	ctx := context.Background()
+	company := createTestCompany(t, r, model.Company{StorageDays: 7, LockerPickupDays: 5, CODFeePercentage: 2})
+	archivedAt := time.Now().Truncate(time.Second)
+	if err := r.db.Model(&company).Update("archived_at", archivedAt).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	update := model.Company{ID: company.ID, Name: "renamed"}
+	if err := r.CompanyRepository.UpdateCompany(ctx, &update, []string{"Name"}); err != nil {
+		t.Fatal(err)
+	}
+	if update.Name != "renamed" || update.StorageDays != 7 || update.LockerPickupDays != 5 || update.CODFeePercentage != 2 {
+		t.Errorf("UpdateCompany() = %+v, want only the name changed", update)
+	}
+	if update.ArchivedAt == nil {
+		t.Error("UpdateCompany() unarchived the company")
+	}
+
+	// Zero is a setting of its own, not a missing value
+	update = model.Company{ID: company.ID, StorageDays: 0, ArchivedAt: nil}
+	if err := r.CompanyRepository.UpdateCompany(ctx, &update, []string{"StorageDays", "ArchivedAt"}); err != nil {
+		t.Fatal(err)
+	}
+	if update.StorageDays != 0 || update.Name != "renamed" || update.ArchivedAt == nil {
+		t.Errorf("UpdateCompany() = %+v, want storage days 0 and the company still archived", update)
+	}
+
+	logs := []model.AuditLog{}
+	filter := model.AuditFilter{EntityType: config.AuditEntityCompany, EntityID: company.ID}
+	if err := r.AuditRepository.GetAuditLogs(ctx, &logs, filter, 10, 0); err != nil {
+		t.Fatal(err)
+	}
+	if len(logs) != 2 {
+		t.Fatalf("got %d audit logs, want 2", len(logs))
+	}
+	for _, log := range logs {
+		changes := string(log.Changes)
+		if changes != `{"name":{"before":"`+company.Name+`","after":"renamed"}}` &&
+			changes != `{"storageDays":{"before":7,"after":0}}` {
+			t.Errorf("audit changes = %s, want only the updated field", changes)
+		}
+	}
+}
+
+func TestDeleteCompany(t *testing.T) {
+	r := newTestRepository(t)
+	ctx := context.Background()
+
+	unused := createTestCompany(t, r, model.Company{})
+	if err := r.CompanyRepository.DeleteCompany(ctx, unused.ID, config.CompanyDeletionRefuse, ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.db.Where("id = ?", unused.ID).First(&model.Company{}).Error; !errors.Is(err, ErrorNotFound) {
+		t.Errorf("unused company was not deleted: %v", err)
+	}
+
+	company := createTestCompany(t, r, model.Company{})
+	office := createTestOffice(t, r, company.ID)
+	if err := r.CompanyRepository.DeleteCompany(ctx, company.ID, config.CompanyDeletionRefuse, ""); !errors.Is(err, ErrorCompanyHasDependents) {
+		t.Errorf("DeleteCompany() error = %v, want %v", err, ErrorCompanyHasDependents)
+	}
+
+	if err := r.CompanyRepository.DeleteCompany(ctx, company.ID, config.CompanyDeletionArchive, ""); err != nil {
+		t.Fatal(err)
+	}
+	archived := model.Office{}
+	if err := r.db.Where("id = ?", office.ID).First(&archived).Error; err != nil || archived.ArchivedAt == nil {
+		t.Errorf("office was not archived with its company: %v", err)
+	}
+}
+
+func TestDeleteCompanyTransfer(t *testing.T) {
+	r := newTestRepository(t)
+	ctx := context.Background()
+	company := createTestCompany(t, r, model.Company{})
+	office := createTestOffice(t, r, company.ID)
+	courrier := createTestEmployee(t, r, config.RoleCourrier, company.ID, &office.ID)
+	target := createTestCompany(t, r, model.Company{})
+	targetOffice := createTestOffice(t, r, target.ID)
+	createTestEmployee(t, r, config.RoleCourrier, target.ID, &targetOffice.ID)
+	sender := createTestClient(t, r, "", "")
+	receiver := createTestClient(t, r, "", "")
+	packageModel := createTestPackage(t, r, model.Package{
+		SenderID: sender.ID, ReceiverID: &receiver.ID, CompanyID: company.ID, Weight: 1, RegisteredByID: &courrier.ID,
+		IsDeliveredToOffice: true, OfficeAcceptedAtID: &office.ID, OfficeDeliveredAtID: &office.ID,
+	})
+
+	err := r.CompanyRepository.DeleteCompany(ctx, company.ID, config.CompanyDeletionTransfer, target.ID)
+	if !errors.Is(err, ErrorPackagesNeedOffice) {
+		t.Fatalf("DeleteCompany() error = %v, want %v", err, ErrorPackagesNeedOffice)
+	}
+
+	if err := r.db.Model(&packageModel).Updates(map[string]interface{}{
+		"office_accepted_at": targetOffice.ID, "office_delivered_at": targetOffice.ID,
+	}).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := r.CompanyRepository.DeleteCompany(ctx, company.ID, config.CompanyDeletionTransfer, target.ID); err != nil {
+		t.Fatal(err)
+	}
+	if moved := getTestPackage(t, r, packageModel.ID); moved.CompanyID != target.ID || moved.ArchivedAt != nil {
+		t.Errorf("package company = %s archived at %v, want it moved to %s", moved.CompanyID, moved.ArchivedAt, target.ID)
+	}
+	employee := model.Employee{}
+	if err := r.db.Where("id = ?", courrier.ID).First(&employee).Error; err != nil || *employee.CompanyID != target.ID {
+		t.Errorf("courrier was not moved to the target company: %v", err)
+	}
+}
@@ -0,0 +1,195 @@
+package repository
+
+import (
+	"context"
+	"logistic_company/config"
+	"logistic_company/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var attemptReasons = map[string]bool{
+	config.AttemptReasonNotHome:         true,
+	config.AttemptReasonAddressNotFound: true,
+	config.AttemptReasonNoAccess:        true,
+	config.AttemptReasonBusinessClosed:  true,
+	config.AttemptReasonOther:           true,
+}
+
+func IsFailedDeliveryAction(action string) bool {
+	return action == config.FailedDeliveryHoldAtOffice || action == config.FailedDeliveryReturnToSender
+}
+
+func (r *PackageRepository) GetDeliveryAttempts(ctx context.Context, attempts *[]model.DeliveryAttempt, packageID string) error {
+	return r.db.WithContext(ctx).Where("package_id = ?", packageID).Order("attempt_number").Find(attempts).Error
+}
+
+func (r *PackageRepository) CreateDeliveryAttempt(ctx context.Context, attempt *model.DeliveryAttempt) error {
+	tx := r.db.WithContext(ctx).Begin()
+
+	packageModel := model.Package{}
+	if err := tx.Where("id = ?", attempt.PackageID).First(&packageModel).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := recordDeliveryAttempt(ctx, tx, &packageModel, attempt); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// recordDeliveryAttempt stores a failed delivery attempt. Once the company's
+// attempt limit is reached the package is held at an office or sent back,
+// depending on the company's failed delivery action.
+func recordDeliveryAttempt(ctx context.Context, tx *gorm.DB, packageModel *model.Package, attempt *model.DeliveryAttempt) error {
+	if !attemptReasons[attempt.Reason] {
+		return ErrorInvalidDeliveryAttempt
+	}
+	if IsPackageClosed(packageModel) {
+		return ErrorPackageDelivered
+	}
+	// Only packages the courier has to deliver, the same ones runs take
+	if !isDeliverable(packageModel) {
+		return ErrorInvalidDeliveryAttempt
+	}
+
+	var previous int64
+	if err := tx.Model(&model.DeliveryAttempt{}).Where("package_id = ?", packageModel.ID).Count(&previous).Error; err != nil {
+		return err
+	}
+	attempt.Number = int(previous) + 1
+	attempt.AttemptedAt = time.Now()
+	if attempt.CourrierID == "" {
+		attempt.CourrierID = stringValue(packageModel.CourrierID)
+	}
+	if err := tx.Create(attempt).Error; err != nil {
+		return err
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionCreate, config.AuditEntityAttempt, attempt.ID, nil, attempt); err != nil {
+		return err
+	}
+	if err := closeRunStop(tx, packageModel.ID, config.StopOutcomeFailed); err != nil {
+		return err
+	}
+
+	// A scheduled redelivery is used up by this attempt
+	if packageModel.RedeliveryDate != nil {
+		if err := tx.Model(&model.Package{}).Where("id = ?", packageModel.ID).Update("redelivery_date", nil).Error; err != nil {
+			return err
+		}
+		packageModel.RedeliveryDate = nil
+	}
+
+	company := model.Company{}
+	if err := tx.Where("id = ?", packageModel.CompanyID).First(&company).Error; err != nil {
+		return err
+	}
+	maxAttempts := company.MaxDeliveryAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = config.DefaultMaxDeliveryAttempts
+	}
+	if attempt.Number < maxAttempts {
+		return setPackageStatus(ctx, tx, packageModel, config.StatusDeliveryFailed)
+	}
+
+	action := company.FailedDeliveryAction
+	if action == "" {
+		action = config.DefaultFailedDeliveryAction
+	}
+	if action == config.FailedDeliveryReturnToSender {
+		return createReturn(ctx, tx, packageModel, &model.Package{}, config.ReturnReasonUndeliverable)
+	}
+	return holdAtOffice(ctx, tx, packageModel)
+}
+
+// holdAtOffice keeps the package for pickup. Packages that were going to an
+// address are held at the office of their courier, or at the office they
+// were accepted at when the courier has none.
+func holdAtOffice(ctx context.Context, tx *gorm.DB, packageModel *model.Package) error {
+	if !packageModel.IsDeliveredToOffice || packageModel.OfficeDeliveredAtID == nil {
+		officeID := packageModel.OfficeAcceptedAtID
+		courrier := model.Employee{}
+		if err := tx.Where("id = ?", packageModel.CourrierID).First(&courrier).Error; err == nil && courrier.OfficeID != nil {
+			officeID = courrier.OfficeID
+		}
+		packageModel.IsDeliveredToOffice = true
+		packageModel.OfficeDeliveredAtID = officeID
+	}
+	return setPackageStatus(ctx, tx, packageModel, config.StatusHeldAtOffice)
+}
+
+// ScheduleRedelivery applies the receiver's choice after a failed delivery,
+// either a new delivery date or pickup at one of the company's offices.
+func (r *PackageRepository) ScheduleRedelivery(ctx context.Context, packageModel *model.Package, id string, request model.RedeliveryRequest) error {
+	tx := r.db.WithContext(ctx).Begin()
+
+	if err := tx.Where("id = ?", id).First(packageModel).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if packageModel.DeliveryStatus != config.StatusDeliveryFailed &&
+		packageModel.DeliveryStatus != config.StatusRedeliveryScheduled {
+		tx.Rollback()
+		return ErrorInvalidRedelivery
+	}
+
+	updates := map[string]interface{}{"delivery_status": config.StatusRedeliveryScheduled}
+	switch request.Option {
+	case config.RedeliveryDate:
+		date, err := time.ParseInLocation(config.DateFormat, request.Date, time.Local)
+		if err != nil {
+			tx.Rollback()
+			return ErrorInvalidRedelivery
+		}
+		now := time.Now()
+		if date.Before(time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)) {
+			tx.Rollback()
+			return ErrorInvalidRedelivery
+		}
+		updates["redelivery_date"] = date
+	case config.RedeliveryOfficePickup:
+		office := model.Office{}
+		if err := tx.Scopes(notArchived).Where("id = ? AND company_id = ?", request.OfficeID, packageModel.CompanyID).
+			First(&office).Error; err != nil {
+			tx.Rollback()
+			return ErrorInvalidRedelivery
+		}
+		updates["redelivery_date"] = nil
+		updates["is_delivered_to_office"] = true
+		updates["office_delivered_at"] = office.ID
+	default:
+		tx.Rollback()
+		return ErrorInvalidRedelivery
+	}
+
+	before := *packageModel
+	if err := tx.Model(&model.Package{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Where("id = ?", id).First(packageModel).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionUpdate, config.AuditEntityPackage, id, &before, packageModel); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if before.DeliveryStatus != packageModel.DeliveryStatus {
+		if err := recordStatusEvent(ctx, tx, packageModel); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	if stringValue(before.OfficeDeliveredAtID) != stringValue(packageModel.OfficeDeliveredAtID) {
+		if err := planNewPackageHops(ctx, tx, packageModel); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
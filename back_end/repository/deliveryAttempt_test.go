@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"logistic_company/config"
+	"logistic_company/model"
+	"testing"
+)
+
+func TestCreateDeliveryAttemptForDeliverablePackages(t *testing.T) {
+	r := newTestRepository(t)
+	ctx := context.Background()
+	company := createTestCompany(t, r, model.Company{MaxDeliveryAttempts: 3})
+	office := createTestOffice(t, r, company.ID)
+	courrier := createTestEmployee(t, r, config.RoleCourrier, company.ID, &office.ID)
+	sender := createTestClient(t, r, "", "")
+	receiver := createTestClient(t, r, "", "")
+	location := "1 Vitosha Blvd, Sofia"
+
+	tests := []struct {
+		status  string
+		wantErr error
+	}{
+		{config.StatusRegistered, nil},
+		{config.StatusOutForDelivery, nil},
+		{config.StatusDeliveryFailed, nil},
+		{config.StatusRedeliveryScheduled, nil},
+		{config.StatusRequested, ErrorInvalidDeliveryAttempt},
+		{config.StatusArrivedAtOffice, ErrorInvalidDeliveryAttempt},
+		{config.StatusHeldAtOffice, ErrorInvalidDeliveryAttempt},
+		{config.StatusInLocker, ErrorInvalidDeliveryAttempt},
+		{config.StatusLockerExpired, ErrorInvalidDeliveryAttempt},
+		{config.StatusReturnToSender, ErrorInvalidDeliveryAttempt},
+		{config.StatusReturned, ErrorPackageDelivered},
+	}
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			packageModel := createTestPackage(t, r, model.Package{
+				SenderID: sender.ID, ReceiverID: &receiver.ID, CompanyID: company.ID, Weight: 1, RegisteredByID: &courrier.ID,
+				CourrierID: &courrier.ID, OfficeAcceptedAtID: &office.ID, DeliveryLocation: &location,
+			})
+			if err := r.db.Model(&packageModel).Update("delivery_status", tt.status).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			attempt := model.DeliveryAttempt{PackageID: packageModel.ID, Reason: config.AttemptReasonNotHome}
+			err := r.PackageRepository.CreateDeliveryAttempt(ctx, &attempt)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CreateDeliveryAttempt() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
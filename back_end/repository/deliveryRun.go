@@ -0,0 +1,396 @@
+package repository
+
+import (
+	"context"
+	"logistic_company/config"
+	"logistic_company/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type DeliveryRunRepository struct {
+	db *gorm.DB
+}
+
+func NewDeliveryRunRepository(db *gorm.DB) *DeliveryRunRepository {
+	return &DeliveryRunRepository{
+		db: db,
+	}
+}
+
+func preloadRun(db *gorm.DB) *gorm.DB {
+	return db.Preload("Courrier").Preload("Office").
+		Preload("Stops", func(db *gorm.DB) *gorm.DB {
+			return db.Order("sequence")
+		}).
+		Preload("Stops.Package.Receiver").Preload("Stops.Package.OfficeDeliveredAt")
+}
+
+func (d *DeliveryRunRepository) GetDeliveryRunByID(ctx context.Context, run *model.DeliveryRun, id string) error {
+	return d.db.WithContext(ctx).Scopes(preloadRun).Where("id = ?", id).First(run).Error
+}
+
+func (d *DeliveryRunRepository) GetDeliveryRunsByCourrierID(ctx context.Context, runs *[]model.DeliveryRun, id, date string, limit, offset int) error {
+	query := d.db.WithContext(ctx).Scopes(preloadRun).Where("courrier_id = ?", id)
+	if date != "" {
+		query = query.Where("run_date = ?", date)
+	}
+	return query.Order("run_date DESC").Limit(limit).Offset(offset).Find(runs).Error
+}
+
+// deliverableStatuses are the statuses of packages a courier still has to
+// take to the receiver, an office or a locker. Packages waiting at an office
+// or in a locker, on their way back or not collected yet are not delivered
+// by couriers.
+var deliverableStatuses = []string{
+	config.StatusRegistered,
+	config.StatusInTransit,
+	config.StatusOutForDelivery,
+	config.StatusDeliveryFailed,
+	config.StatusRedeliveryScheduled,
+}
+
+// isDeliverable reports whether a courier can still take the package out
+// for delivery.
+func isDeliverable(packageModel *model.Package) bool {
+	if IsPackageClosed(packageModel) {
+		return false
+	}
+	for _, status := range deliverableStatuses {
+		if packageModel.DeliveryStatus == status {
+			return true
+		}
+	}
+	return false
+}
+
+// deliverableOn limits packages to the open ones of a courier that can be
+// delivered on the day of t.
+func deliverableOn(courrierID string, t time.Time) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("courrier_id = ?", courrierID).Scopes(openPackages).
+			Where("delivery_status IN ?", deliverableStatuses).
+			Where("redelivery_date IS NULL OR redelivery_date <= ?", t.Format(config.DateFormat))
+	}
+}
+
+// CreateDeliveryRun creates the run of a courier for a day. Without explicit
+// package IDs the run contains every package the courier can deliver that
+// is not already part of another unfinished run.
+func (d *DeliveryRunRepository) CreateDeliveryRun(ctx context.Context, run *model.DeliveryRun, request model.DeliveryRunRequest) error {
+	date, err := time.Parse(config.DateFormat, request.Date)
+	if err != nil {
+		return ErrorInvalidDeliveryRun
+	}
+
+	tx := d.db.WithContext(ctx).Begin()
+
+	courrier := model.Employee{}
+	if err := tx.Scopes(notArchived).Where("id = ? AND role = ?", request.CourrierID, config.RoleCourrier).
+		First(&courrier).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	planned := tx.Model(&model.DeliveryRunStop{}).Select("delivery_run_stop.package_id").
+		Joins("JOIN delivery_run ON delivery_run.id = delivery_run_stop.run_id").
+		Where("delivery_run.status <> ?", config.RunStatusFinished)
+	query := tx.Model(&model.Package{}).
+		Scopes(deliverableOn(courrier.ID, date)).
+		Where("id NOT IN (?)", planned)
+	if len(request.PackageIDs) > 0 {
+		query = query.Where("id IN ?", request.PackageIDs)
+	}
+
+	packages := []model.Package{}
+	if err := query.Order("is_delivered_to_office, office_delivered_at, delivery_location").Find(&packages).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if len(packages) == 0 || (len(request.PackageIDs) > 0 && len(packages) != len(request.PackageIDs)) {
+		tx.Rollback()
+		return ErrorInvalidDeliveryRun
+	}
+
+	*run = model.DeliveryRun{
+		CourrierID: courrier.ID,
+		OfficeID:   courrier.OfficeID,
+		Date:       date,
+		Status:     config.RunStatusPlanned,
+	}
+	if courrier.CompanyID != nil {
+		run.CompanyID = *courrier.CompanyID
+	}
+	for i, packageModel := range packages {
+		run.Stops = append(run.Stops, model.DeliveryRunStop{
+			PackageID: packageModel.ID,
+			Sequence:  i + 1,
+			Outcome:   config.StopOutcomePending,
+		})
+	}
+
+	if err := tx.Create(run).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionCreate, config.AuditEntityDeliveryRun, run.ID, nil, run); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+	return d.GetDeliveryRunByID(ctx, run, run.ID)
+}
+
+// ReorderDeliveryRun sets the order in which the stops are visited. stopIDs
+// has to contain every stop of the run exactly once.
+func (d *DeliveryRunRepository) ReorderDeliveryRun(ctx context.Context, run *model.DeliveryRun, id string, stopIDs []string) error {
+	tx := d.db.WithContext(ctx).Begin()
+
+	if err := tx.Preload("Stops").Where("id = ?", id).First(run).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if run.Status == config.RunStatusFinished || len(stopIDs) != len(run.Stops) {
+		tx.Rollback()
+		return ErrorInvalidDeliveryRun
+	}
+
+	sequences := map[string]int{}
+	for i, stopID := range stopIDs {
+		sequences[stopID] = i + 1
+	}
+	for _, stop := range run.Stops {
+		sequence, ok := sequences[stop.ID]
+		if !ok {
+			tx.Rollback()
+			return ErrorInvalidDeliveryRun
+		}
+		if err := tx.Model(&model.DeliveryRunStop{}).Where("id = ?", stop.ID).Update("sequence", sequence).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionUpdate, config.AuditEntityDeliveryRun, run.ID,
+		nil, map[string][]string{"stopOrder": stopIDs}); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+	return d.GetDeliveryRunByID(ctx, run, id)
+}
+
+// StartDeliveryRun marks the run as started and sends its packages out for
+// delivery. Packages that were delivered, picked up or moved somewhere the
+// courier does not deliver from since the run was planned are skipped.
+func (d *DeliveryRunRepository) StartDeliveryRun(ctx context.Context, run *model.DeliveryRun, id string) error {
+	tx := d.db.WithContext(ctx).Begin()
+
+	if err := tx.Preload("Stops").Where("id = ?", id).First(run).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if run.Status != config.RunStatusPlanned {
+		tx.Rollback()
+		return ErrorInvalidDeliveryRun
+	}
+
+	for _, stop := range run.Stops {
+		packageModel := model.Package{}
+		if err := tx.Scopes(forUpdate).Where("id = ?", stop.PackageID).First(&packageModel).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+		if !isDeliverable(&packageModel) {
+			if err := tx.Model(&model.DeliveryRunStop{}).Where("id = ?", stop.ID).
+				Update("outcome", config.StopOutcomeSkipped).Error; err != nil {
+				tx.Rollback()
+				return err
+			}
+			continue
+		}
+		if err := tx.Model(&model.DeliveryRunStop{}).Where("id = ?", stop.ID).
+			Update("previous_status", packageModel.DeliveryStatus).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := setPackageStatus(ctx, tx, &packageModel, config.StatusOutForDelivery); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	if err := updateRunStatus(ctx, tx, run, config.RunStatusInProgress); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+	return d.GetDeliveryRunByID(ctx, run, id)
+}
+
+// FinishDeliveryRun closes the run. Stops that were never visited are marked
+// as skipped and their packages go back to the status they had before the
+// run started, unless they were delivered or moved on in the meantime.
+func (d *DeliveryRunRepository) FinishDeliveryRun(ctx context.Context, run *model.DeliveryRun, id string) error {
+	tx := d.db.WithContext(ctx).Begin()
+
+	if err := tx.Preload("Stops").Where("id = ?", id).First(run).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if run.Status != config.RunStatusInProgress {
+		tx.Rollback()
+		return ErrorInvalidDeliveryRun
+	}
+
+	for _, stop := range run.Stops {
+		if stop.Outcome != config.StopOutcomePending {
+			continue
+		}
+		if err := tx.Model(&model.DeliveryRunStop{}).Where("id = ?", stop.ID).
+			Update("outcome", config.StopOutcomeSkipped).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+		packageModel := model.Package{}
+		if err := tx.Scopes(forUpdate).Where("id = ?", stop.PackageID).First(&packageModel).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+		if IsPackageClosed(&packageModel) || packageModel.DeliveryStatus != config.StatusOutForDelivery {
+			continue
+		}
+		previousStatus := stop.PreviousStatus
+		if previousStatus == "" {
+			previousStatus = config.StatusRegistered
+		}
+		if err := setPackageStatus(ctx, tx, &packageModel, previousStatus); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	if err := updateRunStatus(ctx, tx, run, config.RunStatusFinished); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+	return d.GetDeliveryRunByID(ctx, run, id)
+}
+
+// closeRunStop records what happened to a package outside of its run on
+// the pending stop of the run it is out with.
+func closeRunStop(tx *gorm.DB, packageID, outcome string) error {
+	started := tx.Model(&model.DeliveryRun{}).Select("id").Where("status = ?", config.RunStatusInProgress)
+	return tx.Model(&model.DeliveryRunStop{}).
+		Where("package_id = ? AND outcome = ? AND run_id IN (?)", packageID, config.StopOutcomePending, started).
+		Updates(map[string]interface{}{
+			"outcome":      outcome,
+			"completed_at": time.Now(),
+		}).Error
+}
+
+// RecordStopOutcome stores the outcome of a visited stop and moves its
+// package to the matching delivery status. Failed stops are recorded as
+// delivery attempts and refused packages are sent back to their sender.
+func (d *DeliveryRunRepository) RecordStopOutcome(ctx context.Context, stop *model.DeliveryRunStop, runID, stopID string, request model.DeliveryRunStopOutcomeRequest) error {
+	statuses := map[string]string{
+		config.StopOutcomeDelivered: config.StatusDelivired,
+		config.StopOutcomeFailed:    config.StatusDeliveryFailed,
+		config.StopOutcomeRefused:   config.StatusRefused,
+	}
+	status, ok := statuses[request.Outcome]
+	if !ok {
+		return ErrorInvalidDeliveryRun
+	}
+
+	tx := d.db.WithContext(ctx).Begin()
+
+	run := model.DeliveryRun{}
+	if err := tx.Where("id = ?", runID).First(&run).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Preload("Package").Where("id = ? AND run_id = ?", stopID, runID).First(stop).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if run.Status != config.RunStatusInProgress || stop.Outcome != config.StopOutcomePending {
+		tx.Rollback()
+		return ErrorInvalidDeliveryRun
+	}
+
+	now := time.Now()
+	stop.Outcome = request.Outcome
+	stop.Note = request.Note
+	stop.CompletedAt = &now
+	if err := tx.Model(&model.DeliveryRunStop{}).Where("id = ?", stop.ID).Updates(map[string]interface{}{
+		"outcome":      stop.Outcome,
+		"note":         stop.Note,
+		"completed_at": stop.CompletedAt,
+	}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if request.Outcome == config.StopOutcomeFailed {
+		reason := request.Reason
+		if reason == "" {
+			reason = config.AttemptReasonOther
+		}
+		attempt := model.DeliveryAttempt{
+			PackageID:  stop.PackageID,
+			CourrierID: run.CourrierID,
+			Reason:     reason,
+			Note:       request.Note,
+		}
+		if err := recordDeliveryAttempt(ctx, tx, stop.Package, &attempt); err != nil {
+			tx.Rollback()
+			return err
+		}
+		return tx.Commit().Error
+	}
+	if err := setPackageStatus(ctx, tx, stop.Package, status); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if request.Outcome == config.StopOutcomeRefused {
+		if err := createReturn(ctx, tx, stop.Package, &model.Package{}, config.ReturnReasonRefused); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+func updateRunStatus(ctx context.Context, tx *gorm.DB, run *model.DeliveryRun, status string) error {
+	before := map[string]interface{}{"status": run.Status, "startedAt": run.StartedAt, "finishedAt": run.FinishedAt}
+
+	now := time.Now()
+	run.Status = status
+	if status == config.RunStatusInProgress {
+		run.StartedAt = &now
+	} else {
+		run.FinishedAt = &now
+	}
+	if err := tx.Model(&model.DeliveryRun{}).Where("id = ?", run.ID).Updates(map[string]interface{}{
+		"status":      run.Status,
+		"started_at":  run.StartedAt,
+		"finished_at": run.FinishedAt,
+	}).Error; err != nil {
+		return err
+	}
+
+	return recordAudit(ctx, tx, config.AuditActionUpdate, config.AuditEntityDeliveryRun, run.ID, before,
+		map[string]interface{}{"status": run.Status, "startedAt": run.StartedAt, "finishedAt": run.FinishedAt})
+}
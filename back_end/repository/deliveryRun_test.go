@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"logistic_company/config"
+	"logistic_company/model"
+	"testing"
+	"time"
+)
+
+func TestCreateDeliveryRunTakesDeliverablePackages(t *testing.T) {
+	r := newTestRepository(t)
+	ctx := context.Background()
+	company := createTestCompany(t, r, model.Company{})
+	office := createTestOffice(t, r, company.ID)
+	courrier := createTestEmployee(t, r, config.RoleCourrier, company.ID, &office.ID)
+	sender := createTestClient(t, r, "", "")
+	receiver := createTestClient(t, r, "", "")
+
+	statuses := map[string]bool{
+		config.StatusRegistered:          true,
+		config.StatusInTransit:           true,
+		config.StatusDeliveryFailed:      true,
+		config.StatusRedeliveryScheduled: true,
+		config.StatusArrivedAtOffice:     false,
+		config.StatusHeldAtOffice:        false,
+		config.StatusInLocker:            false,
+		config.StatusLockerExpired:       false,
+		config.StatusReturnToSender:      false,
+		config.StatusReturned:            false,
+		config.StatusRefused:             false,
+	}
+	want := map[string]string{}
+	for status, deliverable := range statuses {
+		packageModel := createTestPackage(t, r, model.Package{
+			SenderID: sender.ID, ReceiverID: &receiver.ID, CompanyID: company.ID, Weight: 1, RegisteredByID: &courrier.ID,
+			CourrierID: &courrier.ID, IsDeliveredToOffice: true, OfficeAcceptedAtID: &office.ID, OfficeDeliveredAtID: &office.ID,
+		})
+		if err := r.db.Model(&packageModel).Update("delivery_status", status).Error; err != nil {
+			t.Fatal(err)
+		}
+		if deliverable {
+			want[packageModel.ID] = status
+		}
+	}
+
+	// The route of the day lists the same packages the run is made of
+	day := model.CourrierDay{}
+	if err := r.CollectionRepository.GetCourrierDay(ctx, &day, courrier.ID, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if len(day.Deliveries) != len(want) {
+		t.Errorf("GetCourrierDay() returned %d deliveries, want %d", len(day.Deliveries), len(want))
+	}
+	for _, packageModel := range day.Deliveries {
+		if _, ok := want[packageModel.ID]; !ok {
+			t.Errorf("GetCourrierDay() includes a package with status %q", packageModel.DeliveryStatus)
+		}
+	}
+
+	run := model.DeliveryRun{}
+	request := model.DeliveryRunRequest{CourrierID: courrier.ID, Date: time.Now().Format(config.DateFormat)}
+	if err := r.DeliveryRunRepository.CreateDeliveryRun(ctx, &run, request); err != nil {
+		t.Fatal(err)
+	}
+	for _, stop := range run.Stops {
+		if _, ok := want[stop.PackageID]; !ok {
+			packageModel := getTestPackage(t, r, stop.PackageID)
+			t.Errorf("run includes a package with status %q", packageModel.DeliveryStatus)
+		}
+		delete(want, stop.PackageID)
+	}
+	for _, status := range want {
+		t.Errorf("run is missing the package with status %q", status)
+	}
+}
+
+func TestDeliveryRunLeavesPackagesHandledOutsideTheRun(t *testing.T) {
+	r := newTestRepository(t)
+	ctx := context.Background()
+	company := createTestCompany(t, r, model.Company{})
+	office := createTestOffice(t, r, company.ID)
+	courrier := createTestEmployee(t, r, config.RoleCourrier, company.ID, &office.ID)
+	sender := createTestClient(t, r, "", "")
+	receiver := createTestClient(t, r, "", "")
+	location := "1 Vitosha Blvd, Sofia"
+	packages := map[string]model.Package{}
+	for _, name := range []string{"delivered", "held", "skipped"} {
+		packages[name] = createTestPackage(t, r, model.Package{
+			SenderID: sender.ID, ReceiverID: &receiver.ID, CompanyID: company.ID, Weight: 1, RegisteredByID: &courrier.ID,
+			CourrierID: &courrier.ID, OfficeAcceptedAtID: &office.ID, DeliveryLocation: &location,
+		})
+	}
+
+	run := model.DeliveryRun{}
+	request := model.DeliveryRunRequest{CourrierID: courrier.ID, Date: time.Now().Format(config.DateFormat)}
+	if err := r.DeliveryRunRepository.CreateDeliveryRun(ctx, &run, request); err != nil {
+		t.Fatal(err)
+	}
+	// Held at the office after the run was planned
+	if err := r.db.Model(&model.Package{}).Where("id = ?", packages["held"].ID).
+		Update("delivery_status", config.StatusHeldAtOffice).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := r.DeliveryRunRepository.StartDeliveryRun(ctx, &run, run.ID); err != nil {
+		t.Fatal(err)
+	}
+	proof := model.ProofOfDelivery{
+		PackageID: packages["delivered"].ID, CourrierID: courrier.ID, RecipientName: "receiver", SignatureKey: "signature",
+	}
+	if err := r.PackageRepository.CreateProofOfDelivery(ctx, &proof); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.DeliveryRunRepository.FinishDeliveryRun(ctx, &run, run.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]struct{ status, outcome string }{
+		"delivered": {config.StatusDelivired, config.StopOutcomeDelivered},
+		"held":      {config.StatusHeldAtOffice, config.StopOutcomeSkipped},
+		"skipped":   {config.StatusRegistered, config.StopOutcomeSkipped},
+	}
+	outcomes := map[string]string{}
+	for _, stop := range run.Stops {
+		outcomes[stop.PackageID] = stop.Outcome
+	}
+	for name, want := range want {
+		packageModel := getTestPackage(t, r, packages[name].ID)
+		if packageModel.DeliveryStatus != want.status || outcomes[packageModel.ID] != want.outcome {
+			t.Errorf("%s package ended %q with stop %q, want %q with stop %q",
+				name, packageModel.DeliveryStatus, outcomes[packageModel.ID], want.status, want.outcome)
+		}
+	}
+}
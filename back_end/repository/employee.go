@@ -5,6 +5,7 @@ import (
 	"errors"
 	"logistic_company/config"
 	"logistic_company/model"
+	"time"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -21,15 +22,15 @@ func NewEmployeeRepository(db *gorm.DB) *EmployeeRepository {
 }
 
 func (e *EmployeeRepository) GetAllEmployees(ctx context.Context, employees *[]model.Employee, limit, offset int) error {
-	return e.db.WithContext(ctx).Preload(clause.Associations).Model(&model.Employee{}).Limit(limit).Offset(offset).Find(employees).Error
+	return e.db.WithContext(ctx).Preload(clause.Associations).Model(&model.Employee{}).Scopes(notArchived).Limit(limit).Offset(offset).Find(employees).Error
 }
 
 func (e *EmployeeRepository) GetEmployeesByName(ctx context.Context, employees *[]model.Employee, name string, limit, offset int) error {
-	return e.db.WithContext(ctx).Preload(clause.Associations).Model(&model.Employee{}).Limit(limit).Offset(offset).Where("name LIKE '%?%'", name).Find(employees).Error
+	return e.db.WithContext(ctx).Preload(clause.Associations).Model(&model.Employee{}).Scopes(notArchived).Limit(limit).Offset(offset).Where("name LIKE '%?%'", name).Find(employees).Error
 }
 
 func (e *EmployeeRepository) GetEmployeesByCompanyID(ctx context.Context, employees *[]model.Employee, id string, limit, offset int) error {
-	return e.db.WithContext(ctx).Preload(clause.Associations).Model(&model.Employee{}).Limit(limit).Offset(offset).Where("company_id = ?", id).Find(employees).Error
+	return e.db.WithContext(ctx).Preload(clause.Associations).Model(&model.Employee{}).Scopes(notArchived).Limit(limit).Offset(offset).Where("company_id = ?", id).Find(employees).Error
 }
 
 func (e *EmployeeRepository) GetEmployeeById(ctx context.Context, employee *model.Employee, id string) error {
@@ -37,18 +38,53 @@ func (e *EmployeeRepository) GetEmployeeById(ctx context.Context, employee *mode
 }
 
 func (e *EmployeeRepository) CreateEmployee(ctx context.Context, employee *model.EmployeeRegister) error {
-	return e.db.WithContext(ctx).Preload(clause.Associations).Model(&employee).Create(employee).Error
+	tx := e.db.WithContext(ctx).Begin()
+
+	if err := tx.Preload(clause.Associations).Model(&employee).Create(employee).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionCreate, config.AuditEntityEmployee, employee.ID, nil, &employee.Employee); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
 }
 
 func (e *EmployeeRepository) UpdateEmployee(ctx context.Context, employee *model.EmployeeRegister) error {
-	return e.db.WithContext(ctx).Preload(clause.Associations).Model(&employee).Where("id = ?", employee.ID).Updates(employee).Error
+	tx := e.db.WithContext(ctx).Begin()
+
+	before := model.Employee{}
+	if err := tx.Model(&model.Employee{}).Where("id = ?", employee.ID).First(&before).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Preload(clause.Associations).Model(&employee).Where("id = ?", employee.ID).Updates(employee).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	after := model.Employee{}
+	if err := tx.Model(&model.Employee{}).Where("id = ?", employee.ID).First(&after).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionUpdate, config.AuditEntityEmployee, employee.ID, &before, &after); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
 }
 
+// DeleteEmployee hands the undelivered packages of an employee over to their
+// colleagues before removing them. Employees still referenced by delivered
+// packages are archived instead, so the package history stays intact.
 func (e *EmployeeRepository) DeleteEmployee(ctx context.Context, id string) error {
 	tx := e.db.WithContext(ctx).Begin()
 	employee := model.Employee{}
 
-	err := tx.Model(&model.Employee{}).Where("id = ?", id).First(&employee).Error
+	err := tx.Model(&model.Employee{}).Scopes(notArchived).Where("id = ?", id).First(&employee).Error
 	if err != nil {
 		tx.Rollback()
 		return err
@@ -57,60 +93,34 @@ func (e *EmployeeRepository) DeleteEmployee(ctx context.Context, id string) erro
 		tx.Rollback()
 		return errors.New("can't delete admin")
 	} else {
-		if err := e.reassignCourrierPackages(tx, &employee); err != nil {
+		plan := model.ReassignmentPlan{}
+		if err := e.reassignPackages(ctx, tx, &plan, &employee); err != nil {
 			tx.Rollback()
 			return err
 		}
 	}
 
-	err = tx.Model(&model.Employee{}).Clauses(clause.OnConflict{UpdateAll: true}).
-		Where("id = ?", id).Delete(&model.Employee{}).Error
-
+	var references int64
+	err = tx.Model(&model.Package{}).Where("courrier_id = ? OR registered_by = ?", id, id).Count(&references).Error
 	if err != nil {
 		tx.Rollback()
 		return err
 	}
 
-	tx.Commit()
-
-	return nil
-}
-
-func (e *EmployeeRepository) reassignCourrierPackages(tx *gorm.DB, employee *model.Employee) error {
-	packages := []model.Package{}
-
-	if err := tx.Model(&model.Package{}).Where("courrier_id = ?", employee.ID).Find(&packages).Error; err != nil {
-		return err
+	if references > 0 {
+		err = archiveRow(ctx, tx, &model.Employee{}, config.AuditEntityEmployee, id, time.Now())
+	} else {
+		err = tx.Model(&model.Employee{}).Where("id = ?", id).Delete(&model.Employee{}).Error
+		if err == nil {
+			err = recordAudit(ctx, tx, config.AuditActionDelete, config.AuditEntityEmployee, id, &employee, nil)
+		}
 	}
-
-	employees := []model.Employee{}
-	err := e.db.Model(&model.Employee{}).
-		Where("role = ?", employee.Role).
-		Where("id <> ?", employee.ID).
-		Where("company_id = ?", employee.CompanyID).
-		Order("RANDOM()").Limit(len(packages)).Find(&employees).Error
 	if err != nil {
+		tx.Rollback()
 		return err
 	}
 
-	if len(employees) == 0 {
-		return errors.New("no courriers available")
-	}
-
-	columnToUpdate := ""
-	if employee.Role == config.RoleCourrier {
-		columnToUpdate = "courrier_id"
-	} else {
-		columnToUpdate = "registered_by"
-	}
-
-	for i := 0; i < len(packages); i++ {
-		if err := tx.Model(&model.Package{}).
-			Clauses(clause.OnConflict{UpdateAll: true}).
-			Where("id = ?", packages[i].ID).Update(columnToUpdate, employees[i%len(packages)].ID).Error; err != nil {
-			return err
-		}
-	}
+	tx.Commit()
 
 	return nil
 }
@@ -1,7 +1,58 @@
 package repository
 
 import (
+	"errors"
+
 	"gorm.io/gorm"
 )
 
 var ErrorNotFound = gorm.ErrRecordNotFound
+
+var (
+	ErrorCompanyHasDependents   = errors.New("company still has offices, employees or packages")
+	ErrorInvalidDeletionMode    = errors.New("invalid deletion mode")
+	ErrorInvalidTransferCompany = errors.New("invalid company to transfer to")
+	ErrorPackagesNeedOffice     = errors.New("undelivered packages need to be moved to offices of the target company first")
+	ErrorNoCourriersAvailable   = errors.New("no courriers available")
+	ErrorInvalidCourrier        = errors.New("courrier does not deliver for the company")
+	ErrorInvalidDeliveryRun     = errors.New("invalid delivery run operation")
+	ErrorPackageDelivered       = errors.New("package is already delivered or returned")
+	ErrorInvalidDeliveryAttempt = errors.New("invalid delivery attempt")
+	ErrorInvalidRedelivery      = errors.New("invalid redelivery request")
+	ErrorInvalidReturn          = errors.New("package cannot be returned")
+	ErrorHandoverExists         = errors.New("cash in this currency was already handed over for this day")
+	ErrorInvalidHandover        = errors.New("invalid cash handover")
+	ErrorPayoutPaid             = errors.New("payout batch is already paid")
+	ErrorNotInsured             = errors.New("package is not insured")
+	ErrorInvalidClaim           = errors.New("invalid insurance claim")
+	ErrorClaimExists            = errors.New("package already has an open claim")
+	ErrorInvalidClaimTransition = errors.New("invalid claim status change")
+	ErrorPackageRejected        = errors.New("package cannot be accepted")
+	ErrorInvalidPackageType     = errors.New("invalid package type")
+	ErrorInvalidShipment        = errors.New("invalid shipment")
+	ErrorInvalidScan            = errors.New("invalid scan")
+	ErrorInvalidLinehaul        = errors.New("invalid linehaul")
+	ErrorNoNetworkPath          = errors.New("no path between the offices")
+	ErrorInvalidTransfer        = errors.New("invalid transfer batch operation")
+	ErrorHolidayExists          = errors.New("office already has a holiday on that date")
+	ErrorNotReadyForPickup      = errors.New("package is not ready for pickup")
+	ErrorInvalidPickup          = errors.New("pickup could not be verified")
+	ErrorInvalidLocker          = errors.New("invalid locker")
+	ErrorLockerInUse            = errors.New("locker still holds packages")
+	ErrorNoCompartmentAvailable = errors.New("no locker compartment available")
+	ErrorDoesNotFitLocker       = errors.New("package does not fit in a locker compartment")
+	ErrorInvalidLockerCode      = errors.New("invalid locker code")
+	ErrorLockerCodesTaken       = errors.New("no free locker code was found, try again")
+	ErrorNotAddressDelivery     = errors.New("office and locker deliveries are handed over with their pickup code")
+	ErrorLockerLocked           = errors.New("too many wrong codes, the locker takes no codes for a while")
+	ErrorNoLockerCode           = errors.New("package has no locker code")
+	ErrorInvalidAddress         = errors.New("invalid address")
+	ErrorNotRequested           = errors.New("package is not a pending shipment request")
+	ErrorNotHandedIn            = errors.New("package has not been handed in at an office yet")
+	ErrorInvalidCollection      = errors.New("invalid collection")
+	ErrorInvalidReceiver        = errors.New("receiver needs an account or a name with a phone or email")
+	ErrorInvalidVerification    = errors.New("verification code is invalid or expired")
+	ErrorTooManyVerifications   = errors.New("too many verification codes requested, try again later")
+	ErrorInvalidAuditFilter     = errors.New("from and to must be dates (YYYY-MM-DD) or RFC 3339 times")
+	ErrorTrackingNumbersTaken   = errors.New("no free tracking number was found, try again")
+)
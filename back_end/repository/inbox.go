@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"logistic_company/config"
+	"logistic_company/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type InboxRepository struct {
+	db *gorm.DB
+}
+
+func NewInboxRepository(db *gorm.DB) *InboxRepository {
+	return &InboxRepository{
+		db: db,
+	}
+}
+
+// GetInbox returns a page of the inbox of a user, newest first, optionally
+// only the unread entries.
+func (i *InboxRepository) GetInbox(ctx context.Context, inbox *model.Inbox, userID string, unreadOnly bool, limit, offset int) error {
+	db := i.db.WithContext(ctx)
+	if err := db.Model(&model.InboxNotification{}).Where("user_id = ? AND read_at IS NULL", userID).
+		Count(&inbox.Unread).Error; err != nil {
+		return err
+	}
+
+	query := db.Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+	inbox.Notifications = []model.InboxNotification{}
+	return query.Order("created_at DESC").Offset(offset).Limit(limit).Find(&inbox.Notifications).Error
+}
+
+// MarkRead marks an entry of the inbox of a user as read, entries that were
+// read before keep their time.
+func (i *InboxRepository) MarkRead(ctx context.Context, notification *model.InboxNotification, userID, id string) error {
+	tx := i.db.WithContext(ctx).Begin()
+
+	if err := tx.Where("id = ? AND user_id = ?", id, userID).First(notification).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if notification.ReadAt == nil {
+		now := time.Now()
+		notification.ReadAt = &now
+		if err := tx.Model(&model.InboxNotification{}).Where("id = ?", id).Update("read_at", notification.ReadAt).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+// MarkAllRead marks the whole inbox of a user as read and returns how many
+// entries were unread.
+func (i *InboxRepository) MarkAllRead(ctx context.Context, userID string) (int64, error) {
+	result := i.db.WithContext(ctx).Model(&model.InboxNotification{}).Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", time.Now())
+	return result.RowsAffected, result.Error
+}
+
+func addToInbox(tx *gorm.DB, userID, notificationType, title, body string, packageID *string) error {
+	return tx.Create(&model.InboxNotification{
+		UserID:    userID,
+		Type:      notificationType,
+		Title:     title,
+		Body:      body,
+		PackageID: packageID,
+		CreatedAt: time.Now(),
+	}).Error
+}
+
+// inboxForEvent tells the registered receiver about every status change of a
+// package and the sender about its registration and how it ended. The courier hears
+// about the packages assigned to them.
+func inboxForEvent(tx *gorm.DB, packageModel *model.Package, event *model.PackageEvent) error {
+	userIDs := []string{}
+	var title, body string
+	switch event.Type {
+	case config.EventCreated:
+		userIDs = append(userIDs, packageModel.SenderID)
+		if packageModel.ReceiverID != nil {
+			userIDs = append(userIDs, *packageModel.ReceiverID)
+		}
+		title = fmt.Sprintf("Package %s registered", packageModel.TrackingNumber)
+		body = fmt.Sprintf("Package %s was registered.", packageModel.TrackingNumber)
+		if event.Status == config.StatusRequested {
+			title = fmt.Sprintf("Package %s requested", packageModel.TrackingNumber)
+			body = fmt.Sprintf("Package %s was requested and waits to be handed in.", packageModel.TrackingNumber)
+		}
+		if packageModel.CourrierID != nil {
+			if err := addToInbox(tx, *packageModel.CourrierID, config.InboxPackageEvent,
+				fmt.Sprintf("Package %s assigned to you", packageModel.TrackingNumber),
+				fmt.Sprintf("Package %s was registered and assigned to you for delivery.", packageModel.TrackingNumber),
+				&packageModel.ID); err != nil {
+				return err
+			}
+		}
+	case config.EventStatusChanged:
+		if packageModel.ReceiverID != nil {
+			userIDs = append(userIDs, *packageModel.ReceiverID)
+		}
+		switch event.Status {
+		case config.StatusDelivired, config.StatusRefused, config.StatusReturnToSender, config.StatusReturned:
+			userIDs = append(userIDs, packageModel.SenderID)
+		}
+		title = fmt.Sprintf("Package %s: %s", packageModel.TrackingNumber, event.Status)
+		body = fmt.Sprintf("The status of package %s changed to %s.", packageModel.TrackingNumber, event.Status)
+	default:
+		return nil
+	}
+
+	seen := map[string]bool{}
+	for _, userID := range userIDs {
+		if userID == "" || seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		if err := addToInbox(tx, userID, config.InboxPackageEvent, title, body, &packageModel.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// inboxForReassignment tells the employee whose packages were reassigned and
+// the colleagues who took them over how many packages moved.
+func inboxForReassignment(tx *gorm.DB, plan *model.ReassignmentPlan) error {
+	if len(plan.Assignments) == 0 {
+		return nil
+	}
+	received := map[string]int{}
+	for _, assignment := range plan.Assignments {
+		received[assignment.ToEmployeeID]++
+	}
+
+	if err := addToInbox(tx, plan.EmployeeID, config.InboxReassignment, "Packages reassigned",
+		fmt.Sprintf("Your %d open packages were reassigned to %d colleagues.", len(plan.Assignments), len(received)), nil); err != nil {
+		return err
+	}
+	for employeeID, count := range received {
+		if err := addToInbox(tx, employeeID, config.InboxReassignment, "Packages reassigned to you",
+			fmt.Sprintf("%d open packages of a colleague were reassigned to you.", count), nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
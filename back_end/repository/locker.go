@@ -0,0 +1,590 @@
+package repository
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"logistic_company/config"
+	"logistic_company/model"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// lockerMaxWeight is the heaviest package in kg a compartment takes
+const lockerMaxWeight = 25
+
+// lockerSizes are the compartment sizes from small to large with the
+// largest package dimensions in cm they take, shortest side first.
+var lockerSizes = []struct {
+	size       string
+	dimensions [3]float64
+}{
+	{config.LockerSizeSmall, [3]float64{8, 38, 64}},
+	{config.LockerSizeMedium, [3]float64{19, 38, 64}},
+	{config.LockerSizeLarge, [3]float64{38, 41, 64}},
+}
+
+func IsLockerSize(size string) bool {
+	for _, lockerSize := range lockerSizes {
+		if lockerSize.size == size {
+			return true
+		}
+	}
+	return false
+}
+
+// fittingSizes lists the compartment sizes a package fits in, smallest
+// first.
+func fittingSizes(packageModel *model.Package) []string {
+	if packageModel.Weight > lockerMaxWeight {
+		return nil
+	}
+	dimensions := []float64{packageModel.Length, packageModel.Width, packageModel.Height}
+	sort.Float64s(dimensions)
+
+	sizes := []string{}
+	for _, lockerSize := range lockerSizes {
+		if dimensions[0] <= lockerSize.dimensions[0] && dimensions[1] <= lockerSize.dimensions[1] &&
+			dimensions[2] <= lockerSize.dimensions[2] {
+			sizes = append(sizes, lockerSize.size)
+		}
+	}
+	return sizes
+}
+
+// OpenCompartmentFunc opens the door of a locker compartment, it is how the
+// repository reaches the locker provider.
+type OpenCompartmentFunc func(ctx context.Context, locker *model.Locker, compartment *model.LockerCompartment) error
+
+type LockerRepository struct {
+	db *gorm.DB
+}
+
+func NewLockerRepository(db *gorm.DB) *LockerRepository {
+	return &LockerRepository{
+		db: db,
+	}
+}
+
+func (l *LockerRepository) GetLockersByCompanyID(ctx context.Context, lockers *[]model.Locker, id string, limit, offset int) error {
+	return l.db.WithContext(ctx).Preload("Compartments", func(db *gorm.DB) *gorm.DB {
+		return db.Order("number")
+	}).Where("company_id = ?", id).Offset(offset).Limit(limit).Find(lockers).Error
+}
+
+func (l *LockerRepository) GetLockerById(ctx context.Context, locker *model.Locker, id string) error {
+	return l.db.WithContext(ctx).Preload("Office").Preload("Compartments", func(db *gorm.DB) *gorm.DB {
+		return db.Order("number")
+	}).Where("id = ?", id).First(locker).Error
+}
+
+// CreateLocker creates a locker with the given number of compartments of
+// every size, numbered from the small ones to the large ones.
+func (l *LockerRepository) CreateLocker(ctx context.Context, locker *model.Locker, compartments map[string]int) error {
+	tx := l.db.WithContext(ctx).Begin()
+
+	if err := tx.Where("id = ? AND company_id = ?", locker.OfficeID, locker.CompanyID).First(&model.Office{}).Error; err != nil {
+		tx.Rollback()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrorInvalidLocker
+		}
+		return err
+	}
+	for size, count := range compartments {
+		if !IsLockerSize(size) || count < 0 {
+			tx.Rollback()
+			return ErrorInvalidLocker
+		}
+	}
+	locker.Compartments = nil
+	for _, lockerSize := range lockerSizes {
+		for i := 0; i < compartments[lockerSize.size]; i++ {
+			locker.Compartments = append(locker.Compartments, model.LockerCompartment{
+				Number: len(locker.Compartments) + 1,
+				Size:   lockerSize.size,
+			})
+		}
+	}
+	if len(locker.Compartments) == 0 {
+		tx.Rollback()
+		return ErrorInvalidLocker
+	}
+
+	locker.CreatedAt = time.Now()
+	if err := tx.Create(locker).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionCreate, config.AuditEntityLocker, locker.ID, nil, locker); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// DeleteLocker deletes a locker none of whose compartments is reserved.
+func (l *LockerRepository) DeleteLocker(ctx context.Context, id string) error {
+	tx := l.db.WithContext(ctx).Begin()
+
+	before := model.Locker{}
+	if err := tx.Preload("Compartments").Where("id = ?", id).First(&before).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	for _, compartment := range before.Compartments {
+		if compartment.PackageID != nil {
+			tx.Rollback()
+			return ErrorLockerInUse
+		}
+	}
+	if err := tx.Where("locker_id = ?", id).Delete(&model.LockerCompartment{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Where("id = ?", id).Delete(&model.Locker{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionDelete, config.AuditEntityLocker, id, &before, nil); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// lockerDestination points a new locker delivery at its locker, so the
+// package is priced and assigned like an address delivery to the locker.
+// Lockers cannot collect cash, so they do not take cash on delivery.
+func lockerDestination(tx *gorm.DB, packageModel *model.Package) error {
+	if packageModel.LockerID == nil {
+		return nil
+	}
+	if packageModel.IsDeliveredToOffice || packageModel.CODAmount > 0 {
+		return ErrorInvalidLocker
+	}
+	locker := model.Locker{}
+	if err := tx.Where("id = ? AND company_id = ?", *packageModel.LockerID, packageModel.CompanyID).First(&locker).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrorInvalidLocker
+		}
+		return err
+	}
+	if len(fittingSizes(packageModel)) == 0 {
+		return ErrorDoesNotFitLocker
+	}
+
+	packageModel.DeliveryLocation = &locker.Location
+	packageModel.DeliveryLatitude = locker.Latitude
+	packageModel.DeliveryLongitude = locker.Longitude
+	return nil
+}
+
+// reserveCompartment holds the smallest free compartment a new locker
+// delivery fits in and gives the courier the code to drop it off.
+func reserveCompartment(ctx context.Context, tx *gorm.DB, packageModel *model.Package) error {
+	if packageModel.LockerID == nil {
+		return nil
+	}
+
+	compartment := model.LockerCompartment{}
+	for _, size := range fittingSizes(packageModel) {
+		candidates := []model.LockerCompartment{}
+		if err := tx.Where("locker_id = ? AND size = ? AND package_id IS NULL", *packageModel.LockerID, size).
+			Order("number").Find(&candidates).Error; err != nil {
+			return err
+		}
+		// Another package may reserve the same compartment concurrently,
+		// only the update that still finds it free wins
+		for _, candidate := range candidates {
+			result := tx.Model(&model.LockerCompartment{}).Where("id = ? AND package_id IS NULL", candidate.ID).
+				Update("package_id", packageModel.ID)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 1 {
+				compartment = candidate
+				break
+			}
+		}
+		if compartment.ID != "" {
+			break
+		}
+	}
+	if compartment.ID == "" {
+		return ErrorNoCompartmentAvailable
+	}
+
+	code, err := newLockerCode(tx, *packageModel.LockerID)
+	if err != nil {
+		return err
+	}
+	before := *packageModel
+	packageModel.LockerCompartmentID = &compartment.ID
+	packageModel.LockerCourierCode = code
+	if err := tx.Model(&model.Package{}).Where("id = ?", packageModel.ID).Updates(map[string]interface{}{
+		"locker_compartment_id": packageModel.LockerCompartmentID,
+		"locker_courier_code":   packageModel.LockerCourierCode,
+	}).Error; err != nil {
+		return err
+	}
+	return recordAudit(ctx, tx, config.AuditActionUpdate, config.AuditEntityPackage, packageModel.ID, &before, packageModel)
+}
+
+// releaseCompartment frees the compartment reserved for a package.
+func releaseCompartment(tx *gorm.DB, packageModel *model.Package) error {
+	if packageModel.LockerCompartmentID == nil {
+		return nil
+	}
+	if err := tx.Model(&model.LockerCompartment{}).Where("id = ? AND package_id = ?", *packageModel.LockerCompartmentID, packageModel.ID).
+		Update("package_id", nil).Error; err != nil {
+		return err
+	}
+	packageModel.LockerCompartmentID = nil
+	return nil
+}
+
+// newLockerCode returns a code no other package at the locker can be opened
+// with, so a code entered at the locker names one package.
+func newLockerCode(tx *gorm.DB, lockerID string) (string, error) {
+	for attempt := 0; attempt < config.LockerCodeAttempts; attempt++ {
+		code, err := newCode(config.LockerCodeLength)
+		if err != nil {
+			return "", err
+		}
+		var count int64
+		if err := tx.Model(&model.Package{}).Where("locker_id = ? AND delivery_date IS NULL", lockerID).
+			Where("locker_courier_code = ? OR locker_pickup_code = ?", code, code).Count(&count).Error; err != nil {
+			return "", err
+		}
+		if count == 0 {
+			return code, nil
+		}
+	}
+	return "", ErrorLockerCodesTaken
+}
+
+// GetLockerAccess returns the code the courier or the receiver opens the
+// compartment of a locker delivery with.
+func (l *LockerRepository) GetLockerAccess(ctx context.Context, access *model.LockerAccess, packageModel *model.Package, courrier bool) error {
+	code := packageModel.LockerPickupCode
+	if courrier {
+		code = packageModel.LockerCourierCode
+	}
+	if packageModel.LockerID == nil || packageModel.LockerCompartmentID == nil || code == "" {
+		return ErrorNoLockerCode
+	}
+	compartment := model.LockerCompartment{}
+	if err := l.db.WithContext(ctx).Where("id = ?", *packageModel.LockerCompartmentID).First(&compartment).Error; err != nil {
+		return err
+	}
+
+	*access = model.LockerAccess{
+		PackageID:   packageModel.ID,
+		LockerID:    *packageModel.LockerID,
+		Compartment: compartment.Number,
+		Code:        code,
+		Deadline:    packageModel.LockerDeadline,
+	}
+	return nil
+}
+
+// LockerOpener is who enters a code at a locker. Couriers use the courier
+// codes of their own packages and receivers the pickup codes of theirs, the
+// staff running the locker uses any code.
+type LockerOpener struct {
+	CourrierID string
+	ReceiverID string
+	Staff      bool
+}
+
+// mayUse reports whether the opener may enter the courier code, or the
+// pickup code, of a package.
+func (o LockerOpener) mayUse(packageModel *model.Package, courierCode bool) bool {
+	switch {
+	case o.Staff:
+		return true
+	case courierCode:
+		return o.CourrierID != "" && o.CourrierID == stringValue(packageModel.CourrierID)
+	default:
+		return o.ReceiverID != "" && packageModel.ReceiverID != nil && o.ReceiverID == *packageModel.ReceiverID
+	}
+}
+
+// OpenCompartment is a code entered at a locker. The courier code drops the
+// package off, or takes it back to the locker's office once the pickup
+// expired. The pickup code hands the package over to the receiver. The
+// compartment is opened through open, if the locker fails to open it
+// nothing changes. Codes the opener may not use count as wrong codes, and
+// too many wrong codes in a row lock the locker for a while.
+func (l *LockerRepository) OpenCompartment(ctx context.Context, opening *model.LockerOpening, lockerID, code string, opener LockerOpener, open OpenCompartmentFunc) error {
+	tx := l.db.WithContext(ctx).Begin()
+
+	locker := model.Locker{}
+	if err := tx.Scopes(forUpdate).Where("id = ?", lockerID).First(&locker).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if locker.LockedUntil != nil && time.Now().Before(*locker.LockedUntil) {
+		tx.Rollback()
+		return ErrorLockerLocked
+	}
+	if len(code) != config.LockerCodeLength {
+		return rejectLockerCode(ctx, tx, &locker)
+	}
+	packageModel := model.Package{}
+	err := tx.Scopes(openPackages).Where("locker_id = ? AND locker_compartment_id IS NOT NULL", lockerID).
+		Where("locker_courier_code = ? OR locker_pickup_code = ?", code, code).First(&packageModel).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return rejectLockerCode(ctx, tx, &locker)
+	}
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	compartment := model.LockerCompartment{}
+	if err := tx.Where("id = ?", *packageModel.LockerCompartmentID).First(&compartment).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	pickupCode := codeMatches(code, packageModel.LockerPickupCode)
+	courierCode := codeMatches(code, packageModel.LockerCourierCode)
+	var action func() error
+	switch {
+	case !opener.mayUse(&packageModel, courierCode):
+	case pickupCode && packageModel.DeliveryStatus == config.StatusInLocker:
+		action = func() error { return lockerPickUp(ctx, tx, &packageModel) }
+	case courierCode && packageModel.LockerDroppedAt == nil:
+		action = func() error { return lockerDropOff(ctx, tx, &packageModel) }
+	case courierCode && packageModel.DeliveryStatus == config.StatusLockerExpired:
+		action = func() error { return lockerRetrieve(ctx, tx, &packageModel, &locker) }
+	}
+	if action == nil {
+		return rejectLockerCode(ctx, tx, &locker)
+	}
+	if err := open(ctx, &locker, &compartment); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := action(); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if locker.CodeFailures > 0 {
+		if err := tx.Model(&locker).Update("code_failures", 0).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	*opening = model.LockerOpening{
+		LockerID:       locker.ID,
+		Compartment:    compartment.Number,
+		TrackingNumber: packageModel.TrackingNumber,
+		Status:         packageModel.DeliveryStatus,
+	}
+	return tx.Commit().Error
+}
+
+// rejectLockerCode counts a wrong code entered at a locker and commits it,
+// the locker is locked once too many wrong codes were entered in a row.
+func rejectLockerCode(ctx context.Context, tx *gorm.DB, locker *model.Locker) error {
+	before := *locker
+	locker.CodeFailures++
+	if locker.CodeFailures >= config.MaxLockerCodeFailures {
+		lockedUntil := time.Now().Add(config.LockerLockoutMinutes * time.Minute)
+		locker.CodeFailures = 0
+		locker.LockedUntil = &lockedUntil
+	}
+	if err := tx.Model(&model.Locker{}).Where("id = ?", locker.ID).Updates(map[string]interface{}{
+		"code_failures": locker.CodeFailures,
+		"locked_until":  locker.LockedUntil,
+	}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if locker.LockedUntil != before.LockedUntil {
+		if err := recordAudit(ctx, tx, config.AuditActionUpdate, config.AuditEntityLocker, locker.ID, &before, locker); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+	return ErrorInvalidLockerCode
+}
+
+func codeMatches(code, expected string) bool {
+	return expected != "" && subtle.ConstantTimeCompare([]byte(code), []byte(expected)) == 1
+}
+
+// lockerDropOff puts a package into its compartment and gives the receiver
+// the code to pick it up until the company's pickup days run out.
+func lockerDropOff(ctx context.Context, tx *gorm.DB, packageModel *model.Package) error {
+	company := model.Company{}
+	if err := tx.Where("id = ?", packageModel.CompanyID).First(&company).Error; err != nil {
+		return err
+	}
+	code, err := newLockerCode(tx, *packageModel.LockerID)
+	if err != nil {
+		return err
+	}
+
+	before := *packageModel
+	now := time.Now()
+	packageModel.LockerCourierCode = ""
+	packageModel.LockerPickupCode = code
+	packageModel.LockerDroppedAt = &now
+	packageModel.LockerDeadline = nil
+	if company.LockerPickupDays > 0 {
+		deadline := now.AddDate(0, 0, company.LockerPickupDays)
+		packageModel.LockerDeadline = &deadline
+	}
+	if err := tx.Model(&model.Package{}).Where("id = ?", packageModel.ID).Updates(map[string]interface{}{
+		"locker_courier_code": packageModel.LockerCourierCode,
+		"locker_pickup_code":  packageModel.LockerPickupCode,
+		"locker_dropped_at":   packageModel.LockerDroppedAt,
+		"locker_deadline":     packageModel.LockerDeadline,
+	}).Error; err != nil {
+		return err
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionUpdate, config.AuditEntityPackage, packageModel.ID, &before, packageModel); err != nil {
+		return err
+	}
+	if err := setPackageStatus(ctx, tx, packageModel, config.StatusInLocker); err != nil {
+		return err
+	}
+	if err := closeRunStop(tx, packageModel.ID, config.StopOutcomeDelivered); err != nil {
+		return err
+	}
+	return recordLockerEvent(ctx, tx, packageModel, config.EventLockerDropOff)
+}
+
+// lockerPickUp hands a package over to the receiver and frees its
+// compartment.
+func lockerPickUp(ctx context.Context, tx *gorm.DB, packageModel *model.Package) error {
+	before := *packageModel
+	packageModel.LockerPickupCode = ""
+	if err := releaseCompartment(tx, packageModel); err != nil {
+		return err
+	}
+	if err := tx.Model(&model.Package{}).Where("id = ?", packageModel.ID).Updates(map[string]interface{}{
+		"locker_pickup_code":    "",
+		"locker_compartment_id": nil,
+	}).Error; err != nil {
+		return err
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionUpdate, config.AuditEntityPackage, packageModel.ID, &before, packageModel); err != nil {
+		return err
+	}
+	if err := setPackageStatus(ctx, tx, packageModel, config.StatusDelivired); err != nil {
+		return err
+	}
+	if err := closeRunStop(tx, packageModel.ID, config.StopOutcomeDelivered); err != nil {
+		return err
+	}
+	return recordLockerEvent(ctx, tx, packageModel, config.EventLockerPickUp)
+}
+
+// lockerRetrieve takes an expired package out of its compartment and turns
+// it into an office delivery to the locker's office, where the receiver can
+// still pick it up.
+func lockerRetrieve(ctx context.Context, tx *gorm.DB, packageModel *model.Package, locker *model.Locker) error {
+	office := model.Office{}
+	if err := tx.Where("id = ?", locker.OfficeID).First(&office).Error; err != nil {
+		return err
+	}
+
+	before := *packageModel
+	packageModel.LockerCourierCode = ""
+	if err := releaseCompartment(tx, packageModel); err != nil {
+		return err
+	}
+	packageModel.IsDeliveredToOffice = true
+	packageModel.OfficeDeliveredAtID = &office.ID
+	packageModel.DeliveryLocation = &office.Location
+	packageModel.DeliveryLatitude = office.Latitude
+	packageModel.DeliveryLongitude = office.Longitude
+	if err := tx.Model(&model.Package{}).Where("id = ?", packageModel.ID).Updates(map[string]interface{}{
+		"locker_courier_code":    "",
+		"locker_compartment_id":  nil,
+		"is_delivered_to_office": true,
+		"office_delivered_at":    office.ID,
+		"delivery_location":      office.Location,
+		"delivery_latitude":      office.Latitude,
+		"delivery_longitude":     office.Longitude,
+	}).Error; err != nil {
+		return err
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionUpdate, config.AuditEntityPackage, packageModel.ID, &before, packageModel); err != nil {
+		return err
+	}
+	if err := setPackageStatus(ctx, tx, packageModel, config.StatusInTransit); err != nil {
+		return err
+	}
+	return recordLockerEvent(ctx, tx, packageModel, config.EventLockerRetrieved)
+}
+
+func recordLockerEvent(ctx context.Context, tx *gorm.DB, packageModel *model.Package, eventType string) error {
+	return recordPackageEvent(ctx, tx, &model.PackageEvent{
+		PackageID: packageModel.ID,
+		Type:      eventType,
+		Status:    packageModel.DeliveryStatus,
+		Note:      *packageModel.LockerID,
+	})
+}
+
+// ProcessLockers expires the locker deliveries not picked up by their
+// deadline. The receiver's code stops working and the courier gets a code
+// to take the package back to the locker's office.
+func (l *LockerRepository) ProcessLockers(ctx context.Context) error {
+	packages := []model.Package{}
+	if err := l.db.WithContext(ctx).Where("delivery_status = ? AND delivery_date IS NULL", config.StatusInLocker).
+		Where("locker_deadline < ?", time.Now()).Find(&packages).Error; err != nil {
+		return err
+	}
+
+	var errs []error
+	for i := range packages {
+		if err := l.expireLocker(ctx, &packages[i]); err != nil {
+			errs = append(errs, fmt.Errorf("package %s: %w", packages[i].ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (l *LockerRepository) expireLocker(ctx context.Context, packageModel *model.Package) error {
+	tx := l.db.WithContext(ctx).Begin()
+
+	code, err := newLockerCode(tx, *packageModel.LockerID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	before := *packageModel
+	packageModel.LockerPickupCode = ""
+	packageModel.LockerCourierCode = code
+	if err := tx.Model(&model.Package{}).Where("id = ?", packageModel.ID).Updates(map[string]interface{}{
+		"locker_pickup_code":  packageModel.LockerPickupCode,
+		"locker_courier_code": packageModel.LockerCourierCode,
+	}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionUpdate, config.AuditEntityPackage, packageModel.ID, &before, packageModel); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := setPackageStatus(ctx, tx, packageModel, config.StatusLockerExpired); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
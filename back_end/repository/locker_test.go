@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"logistic_company/config"
+	"logistic_company/model"
+	"strings"
+	"testing"
+)
+
+func TestOpenCompartment(t *testing.T) {
+	r := newTestRepository(t)
+	ctx := context.Background()
+	company := createTestCompany(t, r, model.Company{LockerPickupDays: 3})
+	office := createTestOffice(t, r, company.ID)
+	courrier := createTestEmployee(t, r, config.RoleCourrier, company.ID, &office.ID)
+	sender := createTestClient(t, r, "", "")
+	receiver := createTestClient(t, r, "", "")
+	locker := model.Locker{CompanyID: company.ID, Name: "locker", Location: "locker street 1", OfficeID: office.ID}
+	if err := r.LockerRepository.CreateLocker(ctx, &locker, map[string]int{config.LockerSizeSmall: 2}); err != nil {
+		t.Fatal(err)
+	}
+	packageModel := createTestPackage(t, r, model.Package{
+		SenderID: sender.ID, ReceiverID: &receiver.ID, CompanyID: company.ID, Weight: 1, RegisteredByID: &courrier.ID,
+		CourrierID: &courrier.ID, OfficeAcceptedAtID: &office.ID, LockerID: &locker.ID,
+	})
+	openDoor := func(context.Context, *model.Locker, *model.LockerCompartment) error { return nil }
+	wrongCode := strings.Repeat("x", config.LockerCodeLength)
+
+	tests := []struct {
+		name       string
+		code       string
+		opener     LockerOpener
+		wantStatus string
+		wantErr    error
+	}{
+		{"receiver with the courier code", packageModel.LockerCourierCode, LockerOpener{ReceiverID: receiver.ID}, "", ErrorInvalidLockerCode},
+		{"other courier with the courier code", packageModel.LockerCourierCode, LockerOpener{CourrierID: receiver.ID}, "", ErrorInvalidLockerCode},
+		{"courier with the courier code", packageModel.LockerCourierCode, LockerOpener{CourrierID: courrier.ID}, config.StatusInLocker, nil},
+		{"wrong code", wrongCode, LockerOpener{Staff: true}, "", ErrorInvalidLockerCode},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opening := model.LockerOpening{}
+			err := r.LockerRepository.OpenCompartment(ctx, &opening, locker.ID, tt.code, tt.opener, openDoor)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("OpenCompartment() error = %v, want %v", err, tt.wantErr)
+			}
+			if opening.Status != tt.wantStatus {
+				t.Errorf("OpenCompartment() status = %q, want %q", opening.Status, tt.wantStatus)
+			}
+		})
+	}
+
+	// The code that worked reset the count, so the locker locks after the
+	// wrong code above and the ones below
+	for i := 1; i < config.MaxLockerCodeFailures; i++ {
+		err := r.LockerRepository.OpenCompartment(ctx, &model.LockerOpening{}, locker.ID, wrongCode, LockerOpener{Staff: true}, openDoor)
+		if !errors.Is(err, ErrorInvalidLockerCode) {
+			t.Fatalf("wrong code %d: error = %v, want %v", i+1, err, ErrorInvalidLockerCode)
+		}
+	}
+	pickupCode := getTestPackage(t, r, packageModel.ID).LockerPickupCode
+	err := r.LockerRepository.OpenCompartment(ctx, &model.LockerOpening{}, locker.ID, pickupCode, LockerOpener{ReceiverID: receiver.ID}, openDoor)
+	if !errors.Is(err, ErrorLockerLocked) {
+		t.Errorf("OpenCompartment() after %d wrong codes error = %v, want %v", config.MaxLockerCodeFailures, err, ErrorLockerLocked)
+	}
+}
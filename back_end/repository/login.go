@@ -23,7 +23,7 @@ func NewLoginRepository(db *gorm.DB) *LoginRepository {
 
 func (l *LoginRepository) Login(ctx context.Context, email, password string) (string, string, error) {
 	var employee *model.EmployeeRegister
-	if err := l.db.WithContext(ctx).Model(&model.EmployeeRegister{}).
+	if err := l.db.WithContext(ctx).Model(&model.EmployeeRegister{}).Scopes(notArchived).
 		Where("email = ?", email).
 		First(&employee).Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		return "", "", err
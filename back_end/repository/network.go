@@ -0,0 +1,533 @@
+package repository
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"fmt"
+	"logistic_company/config"
+	"logistic_company/model"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NetworkRepository struct {
+	db *gorm.DB
+}
+
+func NewNetworkRepository(db *gorm.DB) *NetworkRepository {
+	return &NetworkRepository{
+		db: db,
+	}
+}
+
+func (n *NetworkRepository) GetLinehaulsByCompanyID(ctx context.Context, linehauls *[]model.Linehaul, companyID string) error {
+	return n.db.WithContext(ctx).Preload(clause.Associations).Where("company_id = ?", companyID).Find(linehauls).Error
+}
+
+func (n *NetworkRepository) GetLinehaulByID(ctx context.Context, linehaul *model.Linehaul, id string) error {
+	return n.db.WithContext(ctx).Preload(clause.Associations).Where("id = ?", id).First(linehaul).Error
+}
+
+// checkLinehaul makes sure a linehaul connects two different offices of its
+// company.
+func checkLinehaul(tx *gorm.DB, linehaul *model.Linehaul) error {
+	if linehaul.FromOfficeID == linehaul.ToOfficeID || linehaul.TransitHours <= 0 {
+		return ErrorInvalidLinehaul
+	}
+	var count int64
+	if err := tx.Model(&model.Office{}).Scopes(notArchived).
+		Where("id IN ? AND company_id = ?", []string{linehaul.FromOfficeID, linehaul.ToOfficeID}, linehaul.CompanyID).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count != 2 {
+		return ErrorInvalidLinehaul
+	}
+	return nil
+}
+
+func (n *NetworkRepository) CreateLinehaul(ctx context.Context, linehaul *model.Linehaul) error {
+	tx := n.db.WithContext(ctx).Begin()
+
+	if err := checkLinehaul(tx, linehaul); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Omit(clause.Associations).Create(linehaul).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionCreate, config.AuditEntityLinehaul, linehaul.ID, nil, linehaul); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// UpdateLinehaul changes the transit time of a linehaul and whether it runs.
+// Paths that were already planned are not changed.
+func (n *NetworkRepository) UpdateLinehaul(ctx context.Context, linehaul *model.Linehaul) error {
+	tx := n.db.WithContext(ctx).Begin()
+
+	before := model.Linehaul{}
+	if err := tx.Where("id = ?", linehaul.ID).First(&before).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	after := before
+	after.TransitHours = linehaul.TransitHours
+	after.Active = linehaul.Active
+	if err := checkLinehaul(tx, &after); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Model(&model.Linehaul{}).Where("id = ?", linehaul.ID).
+		Updates(map[string]interface{}{"transit_hours": after.TransitHours, "active": after.Active}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionUpdate, config.AuditEntityLinehaul, linehaul.ID, &before, &after); err != nil {
+		tx.Rollback()
+		return err
+	}
+	*linehaul = after
+
+	return tx.Commit().Error
+}
+
+func (n *NetworkRepository) DeleteLinehaul(ctx context.Context, id string) error {
+	tx := n.db.WithContext(ctx).Begin()
+
+	before := model.Linehaul{}
+	if err := tx.Where("id = ?", id).First(&before).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Where("id = ?", id).Delete(&model.Linehaul{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionDelete, config.AuditEntityLinehaul, id, &before, nil); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// activeLinehauls loads the running linehauls of a company between offices
+// that are not archived.
+func activeLinehauls(tx *gorm.DB, companyID string) ([]model.Linehaul, error) {
+	linehauls := []model.Linehaul{}
+	err := tx.Where("company_id = ? AND active = ?", companyID, true).
+		Where("from_office_id IN (?)", tx.Model(&model.Office{}).Scopes(notArchived).Select("id")).
+		Where("to_office_id IN (?)", tx.Model(&model.Office{}).Scopes(notArchived).Select("id")).
+		Order("id").Find(&linehauls).Error
+	return linehauls, err
+}
+
+type pathItem struct {
+	officeID string
+	hours    float64
+}
+
+type pathQueue []pathItem
+
+func (q pathQueue) Len() int            { return len(q) }
+func (q pathQueue) Less(i, j int) bool  { return q[i].hours < q[j].hours }
+func (q pathQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *pathQueue) Push(x interface{}) { *q = append(*q, x.(pathItem)) }
+func (q *pathQueue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
+
+// shortestPath finds the linehauls of the fastest path from one office to
+// another with Dijkstra's algorithm. It reports false when the offices are
+// not connected.
+func shortestPath(linehauls []model.Linehaul, from, to string) ([]model.Linehaul, float64, bool) {
+	outgoing := map[string][]model.Linehaul{}
+	for _, linehaul := range linehauls {
+		outgoing[linehaul.FromOfficeID] = append(outgoing[linehaul.FromOfficeID], linehaul)
+	}
+
+	hours := map[string]float64{from: 0}
+	previous := map[string]model.Linehaul{}
+	done := map[string]bool{}
+	queue := &pathQueue{{officeID: from}}
+	for queue.Len() > 0 {
+		item := heap.Pop(queue).(pathItem)
+		if done[item.officeID] {
+			continue
+		}
+		done[item.officeID] = true
+		if item.officeID == to {
+			break
+		}
+		for _, linehaul := range outgoing[item.officeID] {
+			next := item.hours + linehaul.TransitHours
+			if known, ok := hours[linehaul.ToOfficeID]; !ok || next < known {
+				hours[linehaul.ToOfficeID] = next
+				previous[linehaul.ToOfficeID] = linehaul
+				heap.Push(queue, pathItem{officeID: linehaul.ToOfficeID, hours: next})
+			}
+		}
+	}
+	if !done[to] {
+		return nil, 0, false
+	}
+
+	path := []model.Linehaul{}
+	for officeID := to; officeID != from; officeID = previous[officeID].FromOfficeID {
+		path = append([]model.Linehaul{previous[officeID]}, path...)
+	}
+	return path, hours[to], true
+}
+
+func (n *NetworkRepository) FindPath(ctx context.Context, path *model.NetworkPath, companyID, from, to string) error {
+	linehauls, err := activeLinehauls(n.db.WithContext(ctx), companyID)
+	if err != nil {
+		return err
+	}
+	var ok bool
+	path.Linehauls, path.TransitHours, ok = shortestPath(linehauls, from, to)
+	if !ok {
+		return ErrorNoNetworkPath
+	}
+	return nil
+}
+
+// destinationOffice is the office a package leaves for the receiver from,
+// the pickup office or the office of the courrier delivering it.
+func destinationOffice(tx *gorm.DB, packageModel *model.Package) (string, error) {
+	if packageModel.IsDeliveredToOffice && packageModel.OfficeDeliveredAtID != nil {
+		return *packageModel.OfficeDeliveredAtID, nil
+	}
+	courrier := model.Employee{}
+	err := tx.Where("id = ?", packageModel.CourrierID).First(&courrier).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) || courrier.OfficeID == nil {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return *courrier.OfficeID, nil
+}
+
+// planHops plans the path of a package from where it is now to its
+// destination office, replacing the hops that were planned before. Hops that
+// are on their way are kept, the path continues from their end.
+func planHops(ctx context.Context, tx *gorm.DB, packageModel *model.Package) error {
+	hops := []model.PackageHop{}
+	if err := tx.Where("package_id = ?", packageModel.ID).Order("sequence").Find(&hops).Error; err != nil {
+		return err
+	}
+	start, sequence := stringValue(packageModel.OfficeAcceptedAtID), 0
+	for _, hop := range hops {
+		if hop.Status == config.HopPlanned {
+			continue
+		}
+		start, sequence = hop.ToOfficeID, hop.Sequence
+	}
+	if err := tx.Where("package_id = ? AND status = ?", packageModel.ID, config.HopPlanned).
+		Delete(&model.PackageHop{}).Error; err != nil {
+		return err
+	}
+
+	destination, err := destinationOffice(tx, packageModel)
+	if err != nil || destination == "" || destination == start {
+		return err
+	}
+	linehauls, err := activeLinehauls(tx, packageModel.CompanyID)
+	if err != nil {
+		return err
+	}
+	path, _, ok := shortestPath(linehauls, start, destination)
+	if !ok {
+		return ErrorNoNetworkPath
+	}
+
+	for i, linehaul := range path {
+		hop := model.PackageHop{
+			PackageID:    packageModel.ID,
+			Sequence:     sequence + i + 1,
+			LinehaulID:   linehaul.ID,
+			FromOfficeID: linehaul.FromOfficeID,
+			ToOfficeID:   linehaul.ToOfficeID,
+			Status:       config.HopPlanned,
+		}
+		if err := tx.Create(&hop).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// planNewPackageHops plans the path of a new package. Packages between
+// offices the network does not connect yet are accepted without a path.
+func planNewPackageHops(ctx context.Context, tx *gorm.DB, packageModel *model.Package) error {
+	if err := planHops(ctx, tx, packageModel); err != nil && !errors.Is(err, ErrorNoNetworkPath) {
+		return err
+	}
+	return nil
+}
+
+func (n *NetworkRepository) GetPackageHops(ctx context.Context, hops *[]model.PackageHop, packageID string) error {
+	return n.db.WithContext(ctx).Where("package_id = ?", packageID).Order("sequence").Find(hops).Error
+}
+
+// PlanPackageHops plans the path of a package again, for example after its
+// destination office changed.
+func (n *NetworkRepository) PlanPackageHops(ctx context.Context, hops *[]model.PackageHop, packageID string) error {
+	tx := n.db.WithContext(ctx).Begin()
+
+	packageModel := model.Package{}
+	if err := tx.Where("id = ?", packageID).First(&packageModel).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if IsPackageClosed(&packageModel) {
+		tx.Rollback()
+		return ErrorPackageDelivered
+	}
+	if err := planHops(ctx, tx, &packageModel); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Where("package_id = ?", packageID).Order("sequence").Find(hops).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// hopOffices lists the offices on the planned path of a package, starting
+// with the one it is expected at next.
+func hopOffices(tx *gorm.DB, packageModel *model.Package) ([]string, error) {
+	hops := []model.PackageHop{}
+	if err := tx.Where("package_id = ?", packageModel.ID).Order("sequence").Find(&hops).Error; err != nil {
+		return nil, err
+	}
+
+	offices := []string{}
+	for _, hop := range hops {
+		if hop.Status == config.HopInTransit {
+			offices = append(offices, hop.ToOfficeID)
+			break
+		}
+		if hop.Status == config.HopPlanned {
+			offices = append(offices, hop.FromOfficeID)
+			break
+		}
+	}
+	for _, hop := range hops {
+		offices = append(offices, hop.FromOfficeID, hop.ToOfficeID)
+	}
+	return offices, nil
+}
+
+func (n *NetworkRepository) GetTransferBatchByID(ctx context.Context, batch *model.TransferBatch, id string) error {
+	return n.db.WithContext(ctx).Preload("Hops", func(db *gorm.DB) *gorm.DB {
+		return db.Order("package_id")
+	}).Where("id = ?", id).First(batch).Error
+}
+
+// GetTransferBatchesByOfficeID lists the batches leaving an office, or the
+// ones arriving at it when incoming is set.
+func (n *NetworkRepository) GetTransferBatchesByOfficeID(ctx context.Context, batches *[]model.TransferBatch, officeID string, incoming bool, status string, limit, offset int) error {
+	column := "from_office_id = ?"
+	if incoming {
+		column = "to_office_id = ?"
+	}
+	query := n.db.WithContext(ctx).Preload("Hops").Where(column, officeID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	return query.Order("created_at DESC").Offset(offset).Limit(limit).Find(batches).Error
+}
+
+// CreateTransferBatch opens a batch on a running linehaul of the company.
+func (n *NetworkRepository) CreateTransferBatch(ctx context.Context, batch *model.TransferBatch) error {
+	tx := n.db.WithContext(ctx).Begin()
+
+	var count int64
+	if err := tx.Model(&model.Linehaul{}).
+		Where("company_id = ? AND from_office_id = ? AND to_office_id = ? AND active = ?",
+			batch.CompanyID, batch.FromOfficeID, batch.ToOfficeID, true).
+		Count(&count).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if count == 0 {
+		tx.Rollback()
+		return ErrorInvalidTransfer
+	}
+
+	batch.Status = config.TransferOpen
+	batch.CreatedAt = time.Now()
+	if err := tx.Omit(clause.Associations).Create(batch).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionCreate, config.AuditEntityTransfer, batch.ID, nil, batch); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// AddTransferPackages puts the packages with the tracking numbers or IDs in
+// codes on an open batch. Every package needs a planned hop along the batch's
+// linehaul that is not on another batch yet.
+func (n *NetworkRepository) AddTransferPackages(ctx context.Context, batch *model.TransferBatch, id string, codes []string) error {
+	tx := n.db.WithContext(ctx).Begin()
+
+	if err := tx.Where("id = ?", id).First(batch).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if batch.Status != config.TransferOpen {
+		tx.Rollback()
+		return ErrorInvalidTransfer
+	}
+
+	for _, code := range codes {
+		packageModel := model.Package{}
+		if err := tx.Where("(tracking_number = ? OR id = ?) AND company_id = ?", code, code, batch.CompanyID).
+			First(&packageModel).Error; err != nil {
+			tx.Rollback()
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("%w: package %s not found", ErrorInvalidTransfer, code)
+			}
+			return err
+		}
+		result := tx.Model(&model.PackageHop{}).
+			Where("package_id = ? AND from_office_id = ? AND to_office_id = ?", packageModel.ID, batch.FromOfficeID, batch.ToOfficeID).
+			Where("status = ? AND transfer_batch_id IS NULL", config.HopPlanned).
+			Update("transfer_batch_id", batch.ID)
+		if result.Error != nil {
+			tx.Rollback()
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			tx.Rollback()
+			return fmt.Errorf("%w: package %s is not routed along this linehaul", ErrorInvalidTransfer, code)
+		}
+	}
+	if err := tx.Preload("Hops").Where("id = ?", id).First(batch).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionUpdate, config.AuditEntityTransfer, batch.ID, nil, map[string]interface{}{"packages": codes}); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// DepartTransferBatch records the departure scan of a batch, its packages
+// are in transit until the batch arrives.
+func (n *NetworkRepository) DepartTransferBatch(ctx context.Context, batch *model.TransferBatch, id string) error {
+	return n.moveTransferBatch(ctx, batch, id, config.TransferOpen, config.TransferDeparted)
+}
+
+// ArriveTransferBatch records the arrival scan of a batch. Packages that
+// reached the end of their path have arrived at their destination office.
+func (n *NetworkRepository) ArriveTransferBatch(ctx context.Context, batch *model.TransferBatch, id string) error {
+	return n.moveTransferBatch(ctx, batch, id, config.TransferDeparted, config.TransferArrived)
+}
+
+func (n *NetworkRepository) moveTransferBatch(ctx context.Context, batch *model.TransferBatch, id, from, to string) error {
+	tx := n.db.WithContext(ctx).Begin()
+
+	if err := tx.Preload("Hops").Where("id = ?", id).First(batch).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if batch.Status != from || len(batch.Hops) == 0 {
+		tx.Rollback()
+		return ErrorInvalidTransfer
+	}
+
+	before := *batch
+	before.Hops = nil
+	now := time.Now()
+	batch.Status = to
+	hopStatus, eventType, officeID := config.HopInTransit, config.EventDeparted, batch.FromOfficeID
+	updates := map[string]interface{}{"status": to}
+	if to == config.TransferDeparted {
+		batch.DepartedAt = &now
+		updates["departed_at"] = now
+	} else {
+		batch.ArrivedAt = &now
+		updates["arrived_at"] = now
+		hopStatus, eventType, officeID = config.HopCompleted, config.EventArrived, batch.ToOfficeID
+	}
+	if err := tx.Model(&model.TransferBatch{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	for i := range batch.Hops {
+		hop := &batch.Hops[i]
+		hop.Status = hopStatus
+		if err := tx.Model(&model.PackageHop{}).Where("id = ?", hop.ID).Update("status", hopStatus).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := moveTransferPackage(ctx, tx, hop, eventType, officeID, batch.ID); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	after := *batch
+	after.Hops = nil
+	if err := recordAudit(ctx, tx, config.AuditActionUpdate, config.AuditEntityTransfer, id, &before, &after); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// moveTransferPackage records the departure or arrival of one package of a
+// batch in its history and updates its status.
+func moveTransferPackage(ctx context.Context, tx *gorm.DB, hop *model.PackageHop, eventType, officeID, batchID string) error {
+	packageModel := model.Package{}
+	if err := tx.Where("id = ?", hop.PackageID).First(&packageModel).Error; err != nil {
+		return err
+	}
+	if err := recordPackageEvent(ctx, tx, &model.PackageEvent{
+		PackageID: packageModel.ID,
+		Type:      eventType,
+		Status:    packageModel.DeliveryStatus,
+		OfficeID:  &officeID,
+		BatchID:   &batchID,
+	}); err != nil {
+		return err
+	}
+
+	status := config.StatusInTransit
+	if eventType == config.EventArrived {
+		var remaining int64
+		if err := tx.Model(&model.PackageHop{}).Where("package_id = ? AND status <> ?", packageModel.ID, config.HopCompleted).
+			Count(&remaining).Error; err != nil {
+			return err
+		}
+		if remaining > 0 {
+			return nil
+		}
+		status = config.StatusArrivedAtOffice
+	}
+	return setPackageStatus(ctx, tx, &packageModel, status)
+}
@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"logistic_company/config"
+	"logistic_company/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type NotificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) *NotificationRepository {
+	return &NotificationRepository{
+		db: db,
+	}
+}
+
+// notificationTemplate picks the message the sender and the receiver get
+// about a package event, none for most events.
+func notificationTemplate(event *model.PackageEvent) (template string, toSender bool) {
+	// Shipment requests are registered when they are accepted
+	if event.Type == config.EventCreated && event.Status != config.StatusRequested {
+		return config.TemplatePackageRegistered, true
+	}
+	if event.Type != config.EventStatusChanged {
+		return "", false
+	}
+	switch event.Status {
+	case config.StatusRegistered:
+		return config.TemplatePackageRegistered, true
+	case config.StatusOutForDelivery:
+		return config.TemplateOutForDelivery, false
+	case config.StatusArrivedAtOffice, config.StatusHeldAtOffice, config.StatusInLocker:
+		return config.TemplateReadyForPickup, false
+	case config.StatusDelivired:
+		return config.TemplateDelivered, true
+	}
+	return "", false
+}
+
+// queueNotifications queues the notifications about a package event on the
+// channels its receiver, and for some events its sender, chose. They are
+// queued in the transaction of the change itself so no change goes unnoticed
+// and no notification is sent for a change that was rolled back.
+func queueNotifications(tx *gorm.DB, packageModel *model.Package, event *model.PackageEvent) error {
+	template, toSender := notificationTemplate(event)
+	if template == "" {
+		return nil
+	}
+	// Receivers without an account are not notified
+	clientIDs := []string{}
+	if packageModel.ReceiverID != nil {
+		clientIDs = append(clientIDs, *packageModel.ReceiverID)
+	}
+	if toSender && (packageModel.ReceiverID == nil || packageModel.SenderID != *packageModel.ReceiverID) {
+		clientIDs = append(clientIDs, packageModel.SenderID)
+	}
+
+	for _, clientID := range clientIDs {
+		if err := queueNotification(tx, clientID, packageModel.ID, template, event.CreatedAt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// queueNotification queues a notification about a package on every channel
+// the client chose.
+func queueNotification(tx *gorm.DB, clientID, packageID, template string, createdAt time.Time) error {
+	preference, err := notificationPreference(tx, clientID)
+	if err != nil {
+		return err
+	}
+	channels := []string{}
+	if preference.Email {
+		channels = append(channels, config.ChannelEmail)
+	}
+	if preference.SMS {
+		channels = append(channels, config.ChannelSMS)
+	}
+	for _, channel := range channels {
+		if err := tx.Create(&model.Notification{
+			ClientID:  clientID,
+			PackageID: packageID,
+			Template:  template,
+			Channel:   channel,
+			Status:    config.NotificationPending,
+			CreatedAt: createdAt,
+		}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func notificationPreference(tx *gorm.DB, clientID string) (model.NotificationPreference, error) {
+	preference := model.NotificationPreference{}
+	err := tx.Where("client_id = ?", clientID).First(&preference).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return model.NotificationPreference{ClientID: clientID, Email: true}, nil
+	}
+	return preference, err
+}
+
+func (n *NotificationRepository) GetPreference(ctx context.Context, preference *model.NotificationPreference, clientID string) error {
+	if err := n.db.WithContext(ctx).Where("id = ?", clientID).First(&model.Client{}).Error; err != nil {
+		return err
+	}
+	found, err := notificationPreference(n.db.WithContext(ctx), clientID)
+	if err != nil {
+		return err
+	}
+	*preference = found
+	return nil
+}
+
+func (n *NotificationRepository) SetPreference(ctx context.Context, preference *model.NotificationPreference) error {
+	tx := n.db.WithContext(ctx).Begin()
+
+	if err := tx.Where("id = ?", preference.ClientID).First(&model.Client{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	before, err := notificationPreference(tx, preference.ClientID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Save(preference).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionUpdate, config.AuditEntityClient, preference.ClientID, &before, preference); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (n *NotificationRepository) GetNotificationsByClientID(ctx context.Context, notifications *[]model.Notification, clientID string, limit, offset int) error {
+	return n.db.WithContext(ctx).Where("client_id = ?", clientID).Order("created_at DESC").
+		Offset(offset).Limit(limit).Find(notifications).Error
+}
+
+func (n *NotificationRepository) GetNotificationsByPackageID(ctx context.Context, notifications *[]model.Notification, packageID string) error {
+	return n.db.WithContext(ctx).Where("package_id = ?", packageID).Order("created_at").Find(notifications).Error
+}
+
+// GetPendingNotifications returns the oldest notifications waiting to be
+// sent with their client and package.
+func (n *NotificationRepository) GetPendingNotifications(ctx context.Context, notifications *[]model.Notification, limit int) error {
+	return n.db.WithContext(ctx).Preload("Client").Preload("Package.OfficeDeliveredAt").Preload("Package.Locker").
+		Where("status = ?", config.NotificationPending).Order("created_at").Limit(limit).Find(notifications).Error
+}
+
+// RecordAttempt stores the outcome of sending a notification. Notifications
+// that failed are retried until they run out of attempts.
+func (n *NotificationRepository) RecordAttempt(ctx context.Context, notification *model.Notification, sendErr error) error {
+	notification.Attempts++
+	notification.Error = ""
+	switch {
+	case sendErr == nil:
+		now := time.Now()
+		notification.Status = config.NotificationSent
+		notification.SentAt = &now
+	case notification.Attempts >= config.MaxNotificationAttempts:
+		notification.Status = config.NotificationFailed
+		notification.Error = sendErr.Error()
+	default:
+		notification.Error = sendErr.Error()
+	}
+
+	return n.db.WithContext(ctx).Model(&model.Notification{}).Where("id = ?", notification.ID).Updates(map[string]interface{}{
+		"recipient": notification.Recipient,
+		"subject":   notification.Subject,
+		"body":      notification.Body,
+		"status":    notification.Status,
+		"attempts":  notification.Attempts,
+		"error":     notification.Error,
+		"sent_at":   notification.SentAt,
+	}).Error
+}
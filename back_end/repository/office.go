@@ -3,7 +3,10 @@ package repository
 import (
 	"context"
 	"errors"
+	"fmt"
+	"logistic_company/config"
 	"logistic_company/model"
+	"time"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -20,25 +23,240 @@ func NewOfficeRepository(db *gorm.DB) *OfficeRepository {
 }
 
 func (o *OfficeRepository) GetAllOffices(ctx context.Context, offices *[]model.Office, limit, offset int) error {
-	return o.db.WithContext(ctx).Preload(clause.Associations).Offset(offset).Limit(limit).Find(offices).Error
+	db := o.db.WithContext(ctx)
+	if err := db.Preload(clause.Associations).Scopes(notArchived).Offset(offset).Limit(limit).Find(offices).Error; err != nil {
+		return err
+	}
+	return withOfficeStatus(db, *offices)
 }
 
 func (o *OfficeRepository) GetOfficeById(ctx context.Context, office *model.Office, id string) error {
-	return o.db.WithContext(ctx).Preload(clause.Associations).Where("id = ?", id).First(office).Error
+	db := o.db.WithContext(ctx)
+	if err := db.Preload(clause.Associations).Where("id = ?", id).First(office).Error; err != nil {
+		return err
+	}
+	offices := []model.Office{*office}
+	if err := withOfficeStatus(db, offices); err != nil {
+		return err
+	}
+	*office = offices[0]
+	return nil
+}
+
+// withOfficeStatus fills in how many parcels the offices store and whether
+// they are open now.
+func withOfficeStatus(tx *gorm.DB, offices []model.Office) error {
+	if len(offices) == 0 {
+		return nil
+	}
+	ids := make([]string, len(offices))
+	for i, office := range offices {
+		ids[i] = office.ID
+	}
+
+	stored, err := storedParcels(tx, ids)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	onHoliday := []string{}
+	if err := tx.Model(&model.OfficeHoliday{}).Where("office_id IN ? AND holiday_date = ?", ids, now.Format(config.DateFormat)).
+		Pluck("office_id", &onHoliday).Error; err != nil {
+		return err
+	}
+	closed := map[string]bool{}
+	for _, id := range onHoliday {
+		closed[id] = true
+	}
+
+	for i := range offices {
+		offices[i].StoredParcels = stored[offices[i].ID]
+		offices[i].OpenNow = !closed[offices[i].ID] && officeOpenAt(&offices[i], now)
+	}
+	return nil
+}
+
+// storedParcels counts the undelivered packages to be picked up at the
+// offices, including the ones on their way there, so an office does not take
+// more parcels than it can store.
+func storedParcels(tx *gorm.DB, officeIDs []string) (map[string]int, error) {
+	rows := []struct {
+		OfficeDeliveredAt string
+		Count             int
+	}{}
+	if err := tx.Model(&model.Package{}).Select("office_delivered_at, COUNT(*) AS count").
+		Where("office_delivered_at IN ? AND is_delivered_to_office = ? AND delivery_date IS NULL", officeIDs, true).
+		Where("delivery_status NOT IN ?", []string{config.StatusReturnToSender, config.StatusReturned}).
+		Group("office_delivered_at").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	stored := map[string]int{}
+	for _, row := range rows {
+		stored[row.OfficeDeliveredAt] = row.Count
+	}
+	return stored, nil
+}
+
+// officeOpenAt reports whether the opening hours of an office cover t,
+// holidays are not taken into account.
+func officeOpenAt(office *model.Office, t time.Time) bool {
+	if office.PermanentlyClosed {
+		return false
+	}
+	if len(office.OpeningHours) == 0 {
+		return true
+	}
+	clock := t.Format(config.TimeOfDayFormat)
+	for _, hours := range office.OpeningHours {
+		if hours.Weekday == int(t.Weekday()) && hours.Open <= clock && clock < hours.Close {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidOpeningHours reports whether the opening hours name weekdays and
+// times of day with every opening before its closing.
+func ValidOpeningHours(openingHours []model.OpeningHours) bool {
+	for _, hours := range openingHours {
+		open, err := time.Parse(config.TimeOfDayFormat, hours.Open)
+		if err != nil {
+			return false
+		}
+		closing, err := time.Parse(config.TimeOfDayFormat, hours.Close)
+		if err != nil || hours.Weekday < 0 || hours.Weekday > 6 || !open.Before(closing) {
+			return false
+		}
+	}
+	return true
+}
+
+// GetNearbyOfficeCandidates lists the offices open to clients that have
+// coordinates, optionally of one company only. Sorting them by distance is
+// up to the caller.
+func (o *OfficeRepository) GetNearbyOfficeCandidates(ctx context.Context, offices *[]model.Office, companyID string) error {
+	db := o.db.WithContext(ctx)
+	query := db.Scopes(notArchived).
+		Where("is_hub = ? AND permanently_closed = ?", false, false).
+		Where("latitude IS NOT NULL AND longitude IS NOT NULL")
+	if companyID != "" {
+		query = query.Where("company_id = ?", companyID)
+	}
+	if err := query.Find(offices).Error; err != nil {
+		return err
+	}
+	return withOfficeStatus(db, *offices)
+}
+
+func (o *OfficeRepository) GetOfficeHolidays(ctx context.Context, holidays *[]model.OfficeHoliday, officeID string) error {
+	return o.db.WithContext(ctx).Where("office_id = ?", officeID).Order("holiday_date").Find(holidays).Error
+}
+
+func (o *OfficeRepository) CreateOfficeHoliday(ctx context.Context, holiday *model.OfficeHoliday) error {
+	tx := o.db.WithContext(ctx).Begin()
+
+	var count int64
+	if err := tx.Model(&model.OfficeHoliday{}).Where("office_id = ? AND holiday_date = ?", holiday.OfficeID, holiday.Date.Format(config.DateFormat)).
+		Count(&count).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if count > 0 {
+		tx.Rollback()
+		return ErrorHolidayExists
+	}
+	if err := tx.Create(holiday).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionCreate, config.AuditEntityHoliday, holiday.ID, nil, holiday); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (o *OfficeRepository) DeleteOfficeHoliday(ctx context.Context, officeID, id string) error {
+	tx := o.db.WithContext(ctx).Begin()
+
+	before := model.OfficeHoliday{}
+	if err := tx.Where("id = ? AND office_id = ?", id, officeID).First(&before).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Where("id = ?", id).Delete(&model.OfficeHoliday{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionDelete, config.AuditEntityHoliday, id, &before, nil); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
 }
 
 func (o *OfficeRepository) CreateOffice(ctx context.Context, office *model.Office) error {
-	return o.db.WithContext(ctx).Preload(clause.Associations).Model(&office).Create(office).Error
+	tx := o.db.WithContext(ctx).Begin()
+
+	if err := tx.Preload(clause.Associations).Model(&office).Create(office).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionCreate, config.AuditEntityOffice, office.ID, nil, office); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
 }
 
 func (o *OfficeRepository) UpdateOffice(ctx context.Context, office *model.Office) error {
-	return o.db.WithContext(ctx).Preload(clause.Associations).Model(&office).Where("id = ?", office.ID).Updates(office).Error
+	tx := o.db.WithContext(ctx).Begin()
+
+	before := model.Office{}
+	if err := tx.Where("id = ?", office.ID).First(&before).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Preload(clause.Associations).Model(&office).Where("id = ?", office.ID).Updates(office).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	after := model.Office{}
+	if err := tx.Where("id = ?", office.ID).First(&after).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionUpdate, config.AuditEntityOffice, office.ID, &before, &after); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
 }
 
 func (o *OfficeRepository) DeleteOffice(ctx context.Context, id string) error {
 	tx := o.db.WithContext(ctx).Begin()
 
-	err := o.reassignEmployees(tx, id)
+	before := model.Office{}
+	if err := tx.Where("id = ?", id).First(&before).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	err := o.reassignEmployees(ctx, tx, id)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	err = tx.Where("from_office_id = ? OR to_office_id = ?", id, id).Delete(&model.Linehaul{}).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	err = tx.Where("office_id = ?", id).Delete(&model.OfficeHoliday{}).Error
 	if err != nil {
 		tx.Rollback()
 		return err
@@ -48,11 +266,16 @@ func (o *OfficeRepository) DeleteOffice(ctx context.Context, id string) error {
 		tx.Rollback()
 		return err
 	}
+	err = recordAudit(ctx, tx, config.AuditActionDelete, config.AuditEntityOffice, id, &before, nil)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
 	tx.Commit()
 	return nil
 }
 
-func (o *OfficeRepository) reassignEmployees(tx *gorm.DB, id string) error {
+func (o *OfficeRepository) reassignEmployees(ctx context.Context, tx *gorm.DB, id string) error {
 	employees := []model.Employee{}
 	office := model.Office{}
 
@@ -65,7 +288,7 @@ func (o *OfficeRepository) reassignEmployees(tx *gorm.DB, id string) error {
 	}
 	offices := []model.Office{}
 
-	if err := tx.Model(&model.Office{}).Where("company_id = ?", office.CompanyID).
+	if err := tx.Model(&model.Office{}).Scopes(notArchived).Where("company_id = ?", office.CompanyID).
 		Where("id <> ?", id).
 		Find(&offices).Error; err != nil {
 		return err
@@ -79,14 +302,31 @@ func (o *OfficeRepository) reassignEmployees(tx *gorm.DB, id string) error {
 		if err := tx.Model(&model.Employee{}).Where("id = ?", employees[i].ID).Update("office_id", offices[i%len(employees)].ID).Error; err != nil {
 			return err
 		}
+		if err := recordAudit(ctx, tx, config.AuditActionReassign, config.AuditEntityEmployee, employees[i].ID,
+			map[string]string{"officeId": id},
+			map[string]string{"officeId": offices[i%len(employees)].ID}); err != nil {
+			return err
+		}
+		if err := addToInbox(tx, employees[i].ID, config.InboxOfficeChanged, "You moved to another office",
+			fmt.Sprintf("Office %s closed, you now work at office %s.", office.Location, offices[i%len(employees)].Location), nil); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
 func (o *OfficeRepository) GetOfficesByLocation(ctx context.Context, offices *[]model.Office, limit, offset int, location string) error {
-	return o.db.WithContext(ctx).Preload(clause.Associations).Offset(offset).Limit(limit).Where("location LIKE '%?%'", location).Find(offices).Error
+	db := o.db.WithContext(ctx)
+	if err := db.Preload(clause.Associations).Scopes(notArchived).Offset(offset).Limit(limit).Where("location LIKE '%?%'", location).Find(offices).Error; err != nil {
+		return err
+	}
+	return withOfficeStatus(db, *offices)
 }
 
 func (o *OfficeRepository) GetOfficesByCompanyID(ctx context.Context, offices *[]model.Office, id string, limit, offset int) error {
-	return o.db.WithContext(ctx).Preload(clause.Associations).Offset(offset).Limit(limit).Where("company_id = ?", id).Find(offices).Error
+	db := o.db.WithContext(ctx)
+	if err := db.Preload(clause.Associations).Scopes(notArchived).Offset(offset).Limit(limit).Where("company_id = ?", id).Find(offices).Error; err != nil {
+		return err
+	}
+	return withOfficeStatus(db, *offices)
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"logistic_company/config"
 	"logistic_company/model"
+	"time"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -19,6 +20,18 @@ func NewPackageRepository(db *gorm.DB) *PackageRepository {
 	}
 }
 
+// openPackages limits a query to packages that are still on their way,
+// neither delivered nor back with their sender.
+func openPackages(db *gorm.DB) *gorm.DB {
+	return db.Where("delivery_date IS NULL AND delivery_status <> ?", config.StatusReturned)
+}
+
+// IsPackageClosed reports whether a package was delivered or returned to its
+// sender, after which nothing happens to it anymore.
+func IsPackageClosed(packageModel *model.Package) bool {
+	return packageModel.DeliveryDate != nil || packageModel.DeliveryStatus == config.StatusReturned
+}
+
 func (r *PackageRepository) GetAllPackages(ctx context.Context, packages *[]model.Package, limit, offset int) error {
 	return r.db.WithContext(ctx).Preload(clause.Associations).Offset(offset).Limit(limit).Find(&packages).Error
 }
@@ -52,7 +65,7 @@ func (r *PackageRepository) GetPackagesByEmployeeID(ctx context.Context, package
 }
 
 func (r *PackageRepository) GetNotDeliveredPackages(ctx context.Context, packages *[]model.Package, limit, offset int) error {
-	return r.db.WithContext(ctx).Preload(clause.Associations).Where("delivery_date IS NULL").Offset(offset).Limit(limit).Find(packages).Error
+	return r.db.WithContext(ctx).Preload(clause.Associations).Scopes(openPackages).Offset(offset).Limit(limit).Find(packages).Error
 }
 
 func (r *PackageRepository) GetPackageById(ctx context.Context, packageModel *model.Package, id string) error {
@@ -60,13 +73,367 @@ func (r *PackageRepository) GetPackageById(ctx context.Context, packageModel *mo
 }
 
 func (r *PackageRepository) CreatePackage(ctx context.Context, packageModel *model.Package) error {
-	return r.db.WithContext(ctx).Preload(clause.Associations).Model(&packageModel).Create(packageModel).Error
+	tx := r.db.WithContext(ctx).Begin()
+
+	if err := createPackage(ctx, tx, packageModel); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// createPackage prices and stores a new package. Packages without a courier
+// get one from the assignment strategy configured for their company, locker
+// deliveries get a compartment reserved. Shipment requests are only quoted,
+// they get their courier, compartment and path once they are accepted.
+func createPackage(ctx context.Context, tx *gorm.DB, packageModel *model.Package) error {
+	requested := packageModel.DeliveryStatus == config.StatusRequested
+	if err := packageReceiver(tx, packageModel); err != nil {
+		return err
+	}
+	if err := lockerDestination(tx, packageModel); err != nil {
+		return err
+	}
+	if err := deliveryAddress(tx, packageModel); err != nil {
+		return err
+	}
+	if packageModel.CourrierID == nil && !requested {
+		if err := assignCourrier(tx, packageModel); err != nil {
+			return err
+		}
+	} else if packageModel.CourrierID != nil {
+		if err := checkCourrier(tx, packageModel); err != nil {
+			return err
+		}
+	}
+	if packageModel.DeliveryStatus == "" {
+		packageModel.DeliveryStatus = config.StatusRegistered
+	}
+	if packageModel.TrackingNumber == "" {
+		trackingNumber, err := uniqueTrackingNumber(tx)
+		if err != nil {
+			return err
+		}
+		packageModel.TrackingNumber = trackingNumber
+	}
+	if err := pricePackage(tx, packageModel); err != nil {
+		return err
+	}
+	if err := priceCOD(tx, packageModel); err != nil {
+		return err
+	}
+	if err := priceInsurance(tx, packageModel); err != nil {
+		return err
+	}
+	if requested {
+		packageModel.QuotedPrice = packageModel.Price
+	}
+	if err := tx.Preload(clause.Associations).Model(&packageModel).Create(packageModel).Error; err != nil {
+		return err
+	}
+	if err := recordCreatedEvent(ctx, tx, packageModel); err != nil {
+		return err
+	}
+	if requested {
+		return recordAudit(ctx, tx, config.AuditActionCreate, config.AuditEntityPackage, packageModel.ID, nil, packageModel)
+	}
+	if err := reserveCompartment(ctx, tx, packageModel); err != nil {
+		return err
+	}
+	if err := planNewPackageHops(ctx, tx, packageModel); err != nil {
+		return err
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionCreate, config.AuditEntityPackage, packageModel.ID, nil, packageModel); err != nil {
+		return err
+	}
+
+	// Office deliveries handed in at their pickup office are there already
+	if packageModel.IsDeliveredToOffice && stringValue(packageModel.OfficeDeliveredAtID) == stringValue(packageModel.OfficeAcceptedAtID) {
+		return setPackageStatus(ctx, tx, packageModel, config.StatusArrivedAtOffice)
+	}
+	return nil
+}
+
+// repriced applies the size, type, office and cash on delivery and insurance
+// fields of an update to the stored package. changed is false when the update
+// leaves all of them as they are.
+func repriced(before, update *model.Package) (packageModel model.Package, changed bool) {
+	packageModel = *before
+	floats := []struct{ stored, updated *float64 }{
+		{&packageModel.Weight, &update.Weight},
+		{&packageModel.Length, &update.Length},
+		{&packageModel.Width, &update.Width},
+		{&packageModel.Height, &update.Height},
+		{&packageModel.CODAmount, &update.CODAmount},
+		{&packageModel.DeclaredValue, &update.DeclaredValue},
+	}
+	for _, field := range floats {
+		if *field.updated != 0 && *field.updated != *field.stored {
+			*field.stored = *field.updated
+			changed = true
+		}
+	}
+	ids := []struct{ stored, updated **string }{
+		{&packageModel.PackageTypeID, &update.PackageTypeID},
+		{&packageModel.OfficeAcceptedAtID, &update.OfficeAcceptedAtID},
+		{&packageModel.OfficeDeliveredAtID, &update.OfficeDeliveredAtID},
+	}
+	for _, field := range ids {
+		if *field.updated != nil && stringValue(*field.updated) != stringValue(*field.stored) {
+			*field.stored = *field.updated
+			changed = true
+		}
+	}
+	if update.IsDeliveredToOffice && !packageModel.IsDeliveredToOffice {
+		packageModel.IsDeliveredToOffice = true
+		changed = true
+	}
+	if update.Insured && !packageModel.Insured {
+		packageModel.Insured = true
+		changed = true
+	}
+	return packageModel, changed
 }
 
+// UpdatePackage applies the supplied fields to a package. Prices and fees are
+// never taken from the update, changes to what they depend on price the
+// package again and check it against its type and offices.
 func (r *PackageRepository) UpdatePackage(ctx context.Context, packageModel *model.Package) error {
-	return r.db.WithContext(ctx).Preload(clause.Associations).Model(&packageModel).Where("id = ?", packageModel.ID).Updates(packageModel).Error
+	tx := r.db.WithContext(ctx).Begin()
+
+	before := model.Package{}
+	if err := tx.Scopes(forUpdate).Where("id = ?", packageModel.ID).First(&before).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	packageModel.Price, packageModel.QuotedPrice = 0, 0
+	packageModel.VolumetricWeight, packageModel.ChargeableWeight = 0, 0
+	packageModel.CODFee, packageModel.InsurancePremium, packageModel.StorageFee = 0, 0, 0
+	priced, changed := repriced(&before, packageModel)
+	if changed {
+		if IsPackageClosed(&before) {
+			tx.Rollback()
+			return ErrorPackageDelivered
+		}
+		if err := pricePackage(tx, &priced); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := priceCOD(tx, &priced); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := priceInsurance(tx, &priced); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	if packageModel.CourrierID != nil && *packageModel.CourrierID != stringValue(before.CourrierID) {
+		courrier := before
+		courrier.CourrierID = packageModel.CourrierID
+		if packageModel.CompanyID != "" {
+			courrier.CompanyID = packageModel.CompanyID
+		}
+		if err := checkCourrier(tx, &courrier); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	if packageModel.DeliveryAddressID != nil &&
+		(before.DeliveryAddressID == nil || *before.DeliveryAddressID != *packageModel.DeliveryAddressID) {
+		destination := before
+		destination.DeliveryAddressID = packageModel.DeliveryAddressID
+		if err := deliveryAddress(tx, &destination); err != nil {
+			tx.Rollback()
+			return err
+		}
+		packageModel.DeliveryLocation = destination.DeliveryLocation
+		packageModel.DeliveryLatitude = destination.DeliveryLatitude
+		packageModel.DeliveryLongitude = destination.DeliveryLongitude
+	}
+	if err := tx.Preload(clause.Associations).Model(&packageModel).Where("id = ?", packageModel.ID).Updates(packageModel).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if changed {
+		if err := tx.Model(&model.Package{}).Where("id = ?", packageModel.ID).Updates(map[string]interface{}{
+			"volumetric_weight": priced.VolumetricWeight,
+			"chargeable_weight": priced.ChargeableWeight,
+			"price":             priced.Price,
+			"cod_amount":        priced.CODAmount,
+			"cod_fee":           priced.CODFee,
+			"insurance_premium": priced.InsurancePremium,
+		}).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	after := model.Package{}
+	if err := tx.Where("id = ?", packageModel.ID).First(&after).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionUpdate, config.AuditEntityPackage, packageModel.ID, &before, &after); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if after.DeliveryStatus != before.DeliveryStatus {
+		if err := recordStatusEvent(ctx, tx, &after); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	if after.DeliveryStatus == config.StatusDelivired && before.DeliveryStatus != config.StatusDelivired {
+		if err := delivered(ctx, tx, &after); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	if (after.DeliveryStatus == config.StatusArrivedAtOffice || after.DeliveryStatus == config.StatusHeldAtOffice) &&
+		before.DeliveryStatus != after.DeliveryStatus {
+		if err := readyForPickup(ctx, tx, &after); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	*packageModel = after
+
+	return tx.Commit().Error
 }
 
 func (r *PackageRepository) DeletePackage(ctx context.Context, packageModel *model.Package, id string) error {
-	return r.db.WithContext(ctx).Where("id = ?", id).Delete(packageModel).Error
+	tx := r.db.WithContext(ctx).Begin()
+
+	if err := tx.Where("id = ?", id).First(packageModel).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := releaseCompartment(tx, packageModel); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Where("id = ?", id).Delete(packageModel).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionDelete, config.AuditEntityPackage, id, packageModel, nil); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// setPackageStatus moves a package to a new delivery status. Delivered
+// packages get their delivery date and are added to the company revenue.
+func setPackageStatus(ctx context.Context, tx *gorm.DB, packageModel *model.Package, status string) error {
+	if packageModel.DeliveryStatus == status {
+		return nil
+	}
+
+	before := *packageModel
+	packageModel.DeliveryStatus = status
+	if err := tx.Model(packageModel).Omit(clause.Associations).Where("id = ?", packageModel.ID).Updates(packageModel).Error; err != nil {
+		return err
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionUpdate, config.AuditEntityPackage, packageModel.ID, &before, packageModel); err != nil {
+		return err
+	}
+	if err := recordStatusEvent(ctx, tx, packageModel); err != nil {
+		return err
+	}
+
+	switch status {
+	case config.StatusDelivired:
+		return delivered(ctx, tx, packageModel)
+	case config.StatusArrivedAtOffice, config.StatusHeldAtOffice:
+		return readyForPickup(ctx, tx, packageModel)
+	}
+	return nil
+}
+
+// delivered dates a delivered package and adds its price and fees to the
+// company revenue, records its cash on delivery collection and closes the
+// original package of a delivered return shipment. The revenue is read from
+// the stored package and only counted the first time it is delivered.
+func delivered(ctx context.Context, tx *gorm.DB, packageModel *model.Package) error {
+	stored := model.Package{}
+	if err := tx.Where("id = ?", packageModel.ID).First(&stored).Error; err != nil {
+		return err
+	}
+	if stored.DeliveryDate != nil {
+		return nil
+	}
+
+	now := time.Now()
+	if err := tx.Model(&model.Package{}).Where("id = ?", stored.ID).Update("delivery_date", now).Error; err != nil {
+		return err
+	}
+	stored.DeliveryDate = &now
+	packageModel.DeliveryDate = &now
+	revenue := stored.Price + stored.CODFee + stored.InsurancePremium + stored.StorageFee
+	if err := tx.Model(&model.Company{}).Where("id = ?", stored.CompanyID).
+		Update("revenue", gorm.Expr("revenue + ?", revenue)).Error; err != nil {
+		return err
+	}
+	if err := recordCODCollection(ctx, tx, &stored); err != nil {
+		return err
+	}
+	if packageModel.ReturnOfID != nil {
+		return markReturned(ctx, tx, packageModel)
+	}
+	return nil
+}
+
+// GetDeliverablePackagesByCourrierID returns the packages a courier can
+// deliver on the day of date, the same ones delivery runs are made of.
+func (r *PackageRepository) GetDeliverablePackagesByCourrierID(ctx context.Context, packages *[]model.Package, id string, date time.Time) error {
+	return r.db.WithContext(ctx).Preload("OfficeDeliveredAt").
+		Scopes(deliverableOn(id, date)).Find(packages).Error
+}
+
+// CreateProofOfDelivery stores the proof collected by the courier and marks
+// the package as delivered. Only address deliveries are handed over by
+// couriers, office and locker deliveries need their pickup PIN or code.
+func (r *PackageRepository) CreateProofOfDelivery(ctx context.Context, proof *model.ProofOfDelivery) error {
+	tx := r.db.WithContext(ctx).Begin()
+
+	packageModel := model.Package{}
+	if err := tx.Scopes(forUpdate).Where("id = ?", proof.PackageID).First(&packageModel).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if IsPackageClosed(&packageModel) {
+		tx.Rollback()
+		return ErrorPackageDelivered
+	}
+	if packageModel.IsDeliveredToOffice || packageModel.LockerID != nil {
+		tx.Rollback()
+		return ErrorNotAddressDelivery
+	}
+
+	proof.CreatedAt = time.Now()
+	if err := tx.Create(proof).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionCreate, config.AuditEntityProof, proof.ID, nil, proof); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := setPackageStatus(ctx, tx, &packageModel, config.StatusDelivired); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := closeRunStop(tx, packageModel.ID, config.StopOutcomeDelivered); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (r *PackageRepository) GetProofOfDelivery(ctx context.Context, proof *model.ProofOfDelivery, packageID string) error {
+	return r.db.WithContext(ctx).Where("package_id = ?", packageID).First(proof).Error
 }
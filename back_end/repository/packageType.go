@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"logistic_company/config"
+	"logistic_company/model"
+	"math"
+
+	"gorm.io/gorm"
+)
+
+func IsPackageTypeCode(code string) bool {
+	switch code {
+	case config.PackageTypeDocument, config.PackageTypeParcel, config.PackageTypePallet, config.PackageTypeFragile:
+		return true
+	}
+	return false
+}
+
+// defaultPackageTypes is the catalogue every new company starts with.
+func defaultPackageTypes(companyID string) []model.PackageType {
+	return []model.PackageType{
+		{CompanyID: companyID, Code: config.PackageTypeDocument, Name: "Document",
+			MaxWeight: 2, MaxLength: 35, MaxWidth: 25, MaxHeight: 5, PriceMultiplier: 1},
+		{CompanyID: companyID, Code: config.PackageTypeParcel, Name: "Parcel",
+			MaxWeight: 30, MaxLength: 120, MaxWidth: 80, MaxHeight: 80, PriceMultiplier: 1},
+		{CompanyID: companyID, Code: config.PackageTypePallet, Name: "Pallet",
+			MaxWeight: 1000, MaxLength: 120, MaxWidth: 100, MaxHeight: 200, PriceMultiplier: 1, Surcharge: 20},
+		{CompanyID: companyID, Code: config.PackageTypeFragile, Name: "Fragile",
+			MaxWeight: 30, MaxLength: 120, MaxWidth: 80, MaxHeight: 80, PriceMultiplier: 1.5},
+	}
+}
+
+func (c *CompanyRepository) GetPackageTypes(ctx context.Context, packageTypes *[]model.PackageType, companyID string) error {
+	return c.db.WithContext(ctx).Where("company_id = ?", companyID).Order("code").Find(packageTypes).Error
+}
+
+func (c *CompanyRepository) CreatePackageType(ctx context.Context, packageType *model.PackageType) error {
+	tx := c.db.WithContext(ctx).Begin()
+
+	if err := tx.Create(packageType).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionCreate, config.AuditEntityPackageType, packageType.ID, nil, packageType); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (c *CompanyRepository) UpdatePackageType(ctx context.Context, packageType *model.PackageType) error {
+	tx := c.db.WithContext(ctx).Begin()
+
+	before := model.PackageType{}
+	if err := tx.Where("id = ? AND company_id = ?", packageType.ID, packageType.CompanyID).First(&before).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Model(&model.PackageType{}).Where("id = ?", packageType.ID).Updates(packageType).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Where("id = ?", packageType.ID).First(packageType).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionUpdate, config.AuditEntityPackageType, packageType.ID, &before, packageType); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// DeletePackageType removes a type from the catalogue. Types that packages
+// were registered with are kept.
+func (c *CompanyRepository) DeletePackageType(ctx context.Context, companyID, id string) error {
+	tx := c.db.WithContext(ctx).Begin()
+
+	before := model.PackageType{}
+	if err := tx.Where("id = ? AND company_id = ?", id, companyID).First(&before).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	var used int64
+	if err := tx.Model(&model.Package{}).Where("package_type_id = ?", id).Count(&used).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if used > 0 {
+		tx.Rollback()
+		return ErrorInvalidPackageType
+	}
+	if err := tx.Where("id = ?", id).Delete(&model.PackageType{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionDelete, config.AuditEntityPackageType, id, &before, nil); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// pricePackage checks that the package fits its type and the offices it
+// passes through, and prices it by its chargeable weight.
+func pricePackage(tx *gorm.DB, packageModel *model.Package) error {
+	company := model.Company{}
+	if err := tx.Where("id = ?", packageModel.CompanyID).First(&company).Error; err != nil {
+		return err
+	}
+
+	var packageType *model.PackageType
+	if packageModel.PackageTypeID != nil {
+		packageType = &model.PackageType{}
+		if err := tx.Where("id = ? AND company_id = ?", *packageModel.PackageTypeID, company.ID).
+			First(packageType).Error; err != nil {
+			return ErrorInvalidPackageType
+		}
+		if err := checkPackageType(packageType, packageModel); err != nil {
+			return err
+		}
+	}
+
+	// Packages collected from the sender have no accepting office yet
+	officeIDs := []string{}
+	if packageModel.OfficeAcceptedAtID != nil {
+		officeIDs = append(officeIDs, *packageModel.OfficeAcceptedAtID)
+	}
+	if packageModel.IsDeliveredToOffice && packageModel.OfficeDeliveredAtID != nil {
+		officeIDs = append(officeIDs, *packageModel.OfficeDeliveredAtID)
+	}
+	for _, officeID := range officeIDs {
+		office := model.Office{}
+		if err := tx.Where("id = ?", officeID).First(&office).Error; err != nil {
+			return err
+		}
+		if err := checkOfficeOpen(tx, &office, packageModel); err != nil {
+			return err
+		}
+		if err := checkOffice(&office, packageType, packageModel); err != nil {
+			return err
+		}
+	}
+
+	divisor := company.VolumetricDivisor
+	if divisor <= 0 {
+		divisor = config.DefaultVolumetricDivisor
+	}
+	packageModel.VolumetricWeight = roundCents(packageModel.Length * packageModel.Width * packageModel.Height / divisor)
+	packageModel.ChargeableWeight = math.Max(packageModel.Weight, packageModel.VolumetricWeight)
+
+	pricePerKillogram := config.DeliveryToAddressPricePerKillogram
+	if packageModel.IsDeliveredToOffice {
+		pricePerKillogram = config.DeliveryToOfficePricePerKillogram
+	}
+	price := pricePerKillogram * packageModel.ChargeableWeight
+	if packageType != nil {
+		price = price*packageType.PriceMultiplier + packageType.Surcharge
+	}
+	packageModel.Price = roundCents(price)
+
+	return nil
+}
+
+func checkPackageType(packageType *model.PackageType, packageModel *model.Package) error {
+	limits := []struct {
+		name         string
+		value, limit float64
+	}{
+		{"weight", packageModel.Weight, packageType.MaxWeight},
+		{"length", packageModel.Length, packageType.MaxLength},
+		{"width", packageModel.Width, packageType.MaxWidth},
+		{"height", packageModel.Height, packageType.MaxHeight},
+	}
+	for _, limit := range limits {
+		if limit.limit > 0 && limit.value > limit.limit {
+			return fmt.Errorf("%w: %s %.2f is over the %s limit of %.2f", ErrorPackageRejected,
+				limit.name, limit.value, packageType.Name, limit.limit)
+		}
+	}
+	return nil
+}
+
+// checkOfficeOpen refuses packages at offices that closed for good and
+// office deliveries to offices that are full.
+func checkOfficeOpen(tx *gorm.DB, office *model.Office, packageModel *model.Package) error {
+	if office.PermanentlyClosed {
+		return fmt.Errorf("%w: office %s is closed permanently", ErrorPackageRejected, office.Location)
+	}
+	if office.Capacity == 0 || !packageModel.IsDeliveredToOffice || office.ID != stringValue(packageModel.OfficeDeliveredAtID) {
+		return nil
+	}
+	stored, err := storedParcels(tx, []string{office.ID})
+	if err != nil {
+		return err
+	}
+	if stored[office.ID] >= office.Capacity {
+		return fmt.Errorf("%w: office %s is full", ErrorPackageRejected, office.Location)
+	}
+	return nil
+}
+
+func checkOffice(office *model.Office, packageType *model.PackageType, packageModel *model.Package) error {
+	if office.MaxWeight > 0 && packageModel.Weight > office.MaxWeight {
+		return fmt.Errorf("%w: office %s accepts packages up to %.2f kg", ErrorPackageRejected, office.Location, office.MaxWeight)
+	}
+	longest := math.Max(packageModel.Length, math.Max(packageModel.Width, packageModel.Height))
+	if office.MaxDimension > 0 && longest > office.MaxDimension {
+		return fmt.Errorf("%w: office %s accepts packages up to %.0f cm", ErrorPackageRejected, office.Location, office.MaxDimension)
+	}
+	if packageType != nil {
+		for _, code := range office.RejectedPackageTypes {
+			if code == packageType.Code {
+				return fmt.Errorf("%w: office %s does not accept %s packages", ErrorPackageRejected, office.Location, packageType.Name)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"logistic_company/config"
+	"logistic_company/model"
+	"testing"
+)
+
+func TestPricePackage(t *testing.T) {
+	r := newTestRepository(t)
+	company := createTestCompany(t, r, model.Company{})
+	office := createTestOffice(t, r, company.ID)
+	smallOffice := createTestOffice(t, r, company.ID)
+	if err := r.db.Model(&smallOffice).Update("max_weight", 5).Error; err != nil {
+		t.Fatal(err)
+	}
+	packageTypeIDs := map[string]*string{}
+	for _, packageType := range getTestPackageTypes(t, r, company.ID) {
+		packageTypeIDs[packageType.Code] = &packageType.ID
+	}
+
+	tests := []struct {
+		name       string
+		pkg        model.Package
+		wantWeight float64
+		wantPrice  float64
+		wantErr    error
+	}{
+		{
+			name:       "address delivery by actual weight",
+			pkg:        model.Package{Weight: 2},
+			wantWeight: 2, wantPrice: 19.98,
+		},
+		{
+			name:       "office delivery by actual weight",
+			pkg:        model.Package{Weight: 2, IsDeliveredToOffice: true, OfficeDeliveredAtID: &office.ID},
+			wantWeight: 2, wantPrice: 9.98,
+		},
+		{
+			name:       "volumetric weight over actual weight",
+			pkg:        model.Package{Weight: 2, Length: 40, Width: 30, Height: 20},
+			wantWeight: 4.8, wantPrice: 47.95,
+		},
+		{
+			name:       "actual weight over volumetric weight",
+			pkg:        model.Package{Weight: 6, Length: 40, Width: 30, Height: 20},
+			wantWeight: 6, wantPrice: 59.94,
+		},
+		{
+			name:       "package type multiplier",
+			pkg:        model.Package{Weight: 2, PackageTypeID: packageTypeIDs[config.PackageTypeFragile]},
+			wantWeight: 2, wantPrice: 29.97,
+		},
+		{
+			name:       "package type surcharge",
+			pkg:        model.Package{Weight: 10, PackageTypeID: packageTypeIDs[config.PackageTypePallet]},
+			wantWeight: 10, wantPrice: 119.9,
+		},
+		{
+			name:    "over the package type limit",
+			pkg:     model.Package{Weight: 3, PackageTypeID: packageTypeIDs[config.PackageTypeDocument]},
+			wantErr: ErrorPackageRejected,
+		},
+		{
+			name:    "over the office limit",
+			pkg:     model.Package{Weight: 6, IsDeliveredToOffice: true, OfficeDeliveredAtID: &smallOffice.ID},
+			wantErr: ErrorPackageRejected,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			packageModel := test.pkg
+			packageModel.CompanyID = company.ID
+			packageModel.OfficeAcceptedAtID = &office.ID
+
+			err := pricePackage(r.db, &packageModel)
+			if test.wantErr != nil {
+				if !errors.Is(err, test.wantErr) {
+					t.Fatalf("pricePackage() error = %v, want %v", err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if packageModel.ChargeableWeight != test.wantWeight {
+				t.Errorf("ChargeableWeight = %v, want %v", packageModel.ChargeableWeight, test.wantWeight)
+			}
+			if packageModel.Price != test.wantPrice {
+				t.Errorf("Price = %v, want %v", packageModel.Price, test.wantPrice)
+			}
+		})
+	}
+}
+
+func getTestPackageTypes(t *testing.T, r *Repository, companyID string) []model.PackageType {
+	t.Helper()
+	packageTypes := []model.PackageType{}
+	if err := r.db.Where("company_id = ?", companyID).Find(&packageTypes).Error; err != nil {
+		t.Fatal(err)
+	}
+	return packageTypes
+}
+
+func TestUpdatePackagePricesAgain(t *testing.T) {
+	r := newTestRepository(t)
+	ctx := context.Background()
+	company := createTestCompany(t, r, model.Company{})
+	office := createTestOffice(t, r, company.ID)
+	smallOffice := createTestOffice(t, r, company.ID)
+	if err := r.db.Model(&smallOffice).Update("max_weight", 5).Error; err != nil {
+		t.Fatal(err)
+	}
+	courrier := createTestEmployee(t, r, config.RoleCourrier, company.ID, &office.ID)
+	sender := createTestClient(t, r, "", "")
+	receiver := createTestClient(t, r, "", "")
+	location := "1 Vitosha Blvd, Sofia"
+	packageModel := createTestPackage(t, r, model.Package{
+		SenderID: sender.ID, ReceiverID: &receiver.ID, CompanyID: company.ID, Weight: 2, RegisteredByID: &courrier.ID,
+		CourrierID: &courrier.ID, OfficeAcceptedAtID: &office.ID, DeliveryLocation: &location,
+	})
+
+	tests := []struct {
+		name       string
+		update     model.Package
+		wantWeight float64
+		wantPrice  float64
+		wantErr    error
+	}{
+		{"price from the client", model.Package{Price: 1}, 2, 19.98, nil},
+		{"heavier package", model.Package{Weight: 4, Price: 1}, 4, 39.96, nil},
+		{"over the office limit", model.Package{Weight: 6, IsDeliveredToOffice: true, OfficeDeliveredAtID: &smallOffice.ID}, 4, 39.96, ErrorPackageRejected},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			update := tt.update
+			update.ID = packageModel.ID
+			err := r.PackageRepository.UpdatePackage(ctx, &update)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UpdatePackage() error = %v, want %v", err, tt.wantErr)
+			}
+			stored := getTestPackage(t, r, packageModel.ID)
+			if stored.ChargeableWeight != tt.wantWeight || stored.Price != tt.wantPrice {
+				t.Errorf("stored package weighs %v and costs %v, want %v and %v",
+					stored.ChargeableWeight, stored.Price, tt.wantWeight, tt.wantPrice)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"logistic_company/config"
+	"logistic_company/model"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestReturnedPackagesAreClosed(t *testing.T) {
+	r := newTestRepository(t)
+	ctx := context.Background()
+	company := createTestCompany(t, r, model.Company{})
+	office := createTestOffice(t, r, company.ID)
+	pickupOffice := createTestOffice(t, r, company.ID)
+	courrier := createTestEmployee(t, r, config.RoleCourrier, company.ID, &office.ID)
+	sender := createTestClient(t, r, "", "")
+	receiver := createTestClient(t, r, "", "")
+
+	open := createTestPackage(t, r, model.Package{
+		SenderID: sender.ID, ReceiverID: &receiver.ID, CompanyID: company.ID, Weight: 1, RegisteredByID: &courrier.ID,
+		CourrierID: &courrier.ID, IsDeliveredToOffice: true, OfficeAcceptedAtID: &office.ID, OfficeDeliveredAtID: &pickupOffice.ID,
+	})
+	returned := createTestPackage(t, r, model.Package{
+		SenderID: sender.ID, ReceiverID: &receiver.ID, CompanyID: company.ID, Weight: 1, RegisteredByID: &courrier.ID,
+		CourrierID: &courrier.ID, IsDeliveredToOffice: true, OfficeAcceptedAtID: &office.ID, OfficeDeliveredAtID: &pickupOffice.ID,
+	})
+	returnPackage := model.Package{ReturnOfID: &returned.ID}
+	if err := markReturned(ctx, r.db, &returnPackage); err != nil {
+		t.Fatal(err)
+	}
+	returned = getTestPackage(t, r, returned.ID)
+	if !IsPackageClosed(&returned) {
+		t.Errorf("IsPackageClosed(%q) = false, want true", returned.DeliveryStatus)
+	}
+
+	tests := []struct {
+		name string
+		get  func(*[]model.Package) error
+	}{
+		{"GetNotDeliveredPackages", func(packages *[]model.Package) error {
+			return r.PackageRepository.GetNotDeliveredPackages(ctx, packages, 10, 0)
+		}},
+		{"GetDeliverablePackagesByCourrierID", func(packages *[]model.Package) error {
+			return r.PackageRepository.GetDeliverablePackagesByCourrierID(ctx, packages, courrier.ID, time.Now())
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			packages := []model.Package{}
+			if err := tt.get(&packages); err != nil {
+				t.Fatal(err)
+			}
+			if len(packages) != 1 || packages[0].ID != open.ID {
+				t.Errorf("%s() returned %d packages, want only the open one", tt.name, len(packages))
+			}
+		})
+	}
+
+	workloads, err := openWorkloads(r.db, "courrier_id", []model.Employee{courrier})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if workloads[courrier.ID] != 1 {
+		t.Errorf("openWorkloads() = %v, want 1 open package", workloads)
+	}
+}
+
+func TestCreateProofOfDeliveryOnlyForAddressDeliveries(t *testing.T) {
+	r := newTestRepository(t)
+	ctx := context.Background()
+	company := createTestCompany(t, r, model.Company{})
+	office := createTestOffice(t, r, company.ID)
+	pickupOffice := createTestOffice(t, r, company.ID)
+	courrier := createTestEmployee(t, r, config.RoleCourrier, company.ID, &office.ID)
+	sender := createTestClient(t, r, "", "")
+	receiver := createTestClient(t, r, "", "")
+	locker := model.Locker{CompanyID: company.ID, Name: "locker", Location: "locker street 1", OfficeID: office.ID}
+	if err := r.LockerRepository.CreateLocker(ctx, &locker, map[string]int{config.LockerSizeSmall: 1}); err != nil {
+		t.Fatal(err)
+	}
+	location := "1 Vitosha Blvd, Sofia"
+
+	tests := []struct {
+		name    string
+		pkg     model.Package
+		wantErr error
+	}{
+		{"address delivery", model.Package{DeliveryLocation: &location}, nil},
+		{"office delivery", model.Package{IsDeliveredToOffice: true, OfficeDeliveredAtID: &pickupOffice.ID}, ErrorNotAddressDelivery},
+		{"locker delivery", model.Package{LockerID: &locker.ID}, ErrorNotAddressDelivery},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			packageModel := tt.pkg
+			packageModel.SenderID, packageModel.ReceiverID, packageModel.CompanyID = sender.ID, &receiver.ID, company.ID
+			packageModel.Weight, packageModel.RegisteredByID, packageModel.CourrierID = 1, &courrier.ID, &courrier.ID
+			packageModel.OfficeAcceptedAtID = &office.ID
+			packageModel = createTestPackage(t, r, packageModel)
+
+			proof := model.ProofOfDelivery{
+				PackageID: packageModel.ID, CourrierID: courrier.ID, RecipientName: "receiver", SignatureKey: "signature",
+			}
+			err := r.PackageRepository.CreateProofOfDelivery(ctx, &proof)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateProofOfDelivery() error = %v, want %v", err, tt.wantErr)
+			}
+			wantStatus := packageModel.DeliveryStatus
+			if tt.wantErr == nil {
+				wantStatus = config.StatusDelivired
+			}
+			if got := getTestPackage(t, r, packageModel.ID).DeliveryStatus; got != wantStatus {
+				t.Errorf("package status = %q, want %q", got, wantStatus)
+			}
+		})
+	}
+}
+
+func TestDeliveredPackagesAddRevenueOnce(t *testing.T) {
+	r := newTestRepository(t)
+	ctx := context.Background()
+	company := createTestCompany(t, r, model.Company{CODFeePercentage: 2, InsuranceRatePercentage: 1, InsuranceMinPremium: 3})
+	office := createTestOffice(t, r, company.ID)
+	courrier := createTestEmployee(t, r, config.RoleCourrier, company.ID, &office.ID)
+	sender := createTestClient(t, r, "", "")
+	receiver := createTestClient(t, r, "", "")
+	location := "1 Vitosha Blvd, Sofia"
+	newPackage := func() model.Package {
+		return createTestPackage(t, r, model.Package{
+			SenderID: sender.ID, ReceiverID: &receiver.ID, CompanyID: company.ID, Weight: 1, RegisteredByID: &courrier.ID,
+			CourrierID: &courrier.ID, OfficeAcceptedAtID: &office.ID, DeliveryLocation: &location,
+			CODAmount: 50, CODCurrency: config.DefaultCurrency, Insured: true, DeclaredValue: 200,
+		})
+	}
+	revenue := func() float64 {
+		stored := model.Company{}
+		if err := r.db.Where("id = ?", company.ID).First(&stored).Error; err != nil {
+			t.Fatal(err)
+		}
+		return stored.Revenue
+	}
+
+	proofPackage, patchedPackage := newPackage(), newPackage()
+	proof := model.ProofOfDelivery{
+		PackageID: proofPackage.ID, CourrierID: courrier.ID, RecipientName: "receiver", SignatureKey: "signature",
+	}
+	if err := r.PackageRepository.CreateProofOfDelivery(ctx, &proof); err != nil {
+		t.Fatal(err)
+	}
+	// The PATCH body carries none of the fees
+	patch := model.Package{ID: patchedPackage.ID, DeliveryStatus: config.StatusDelivired}
+	if err := r.PackageRepository.UpdatePackage(ctx, &patch); err != nil {
+		t.Fatal(err)
+	}
+	want := 0.0
+	for _, packageModel := range []model.Package{proofPackage, patchedPackage} {
+		want += packageModel.Price + packageModel.CODFee + packageModel.InsurancePremium
+	}
+	if packageModel := getTestPackage(t, r, patchedPackage.ID); packageModel.DeliveryDate == nil {
+		t.Errorf("delivered package has no delivery date")
+	}
+	if got := revenue(); math.Abs(got-want) > 0.005 {
+		t.Errorf("revenue = %v, want %v", got, want)
+	}
+
+	// Later updates of delivered packages add nothing
+	patch = model.Package{ID: proofPackage.ID, DeliveryStatus: config.StatusDelivired, ReceiverName: "receiver"}
+	if err := r.PackageRepository.UpdatePackage(ctx, &patch); err != nil {
+		t.Fatal(err)
+	}
+	if got := revenue(); math.Abs(got-want) > 0.005 {
+		t.Errorf("revenue after an update = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,216 @@
+package repository
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"logistic_company/config"
+	"logistic_company/model"
+	"math"
+	"math/big"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// newCode returns a random code of the given number of digits.
+func newCode(digits int) (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(math.Pow10(digits))))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", digits, n), nil
+}
+
+// storageFee is what storing a package since arrivedAt costs at now, every
+// full day past the company's free storage days is charged.
+func storageFee(company *model.Company, arrivedAt, now time.Time) float64 {
+	days := int(now.Sub(arrivedAt).Hours()/24) - company.StorageFreeDays
+	if days <= 0 {
+		return 0
+	}
+	return roundCents(float64(days) * company.StorageFeePerDay)
+}
+
+// readyForPickup gives an office delivery that arrived at its office a
+// pickup PIN and its storage deadline. Packages that were ready before keep
+// their PIN and deadline.
+func readyForPickup(ctx context.Context, tx *gorm.DB, packageModel *model.Package) error {
+	if !packageModel.IsDeliveredToOffice || packageModel.PickupPIN != "" {
+		return nil
+	}
+	company := model.Company{}
+	if err := tx.Where("id = ?", packageModel.CompanyID).First(&company).Error; err != nil {
+		return err
+	}
+	pin, err := newCode(config.PickupPINLength)
+	if err != nil {
+		return err
+	}
+
+	before := *packageModel
+	now := time.Now()
+	packageModel.PickupPIN = pin
+	packageModel.ArrivedAtOfficeAt = &now
+	packageModel.StorageDeadline = nil
+	if company.StorageDays > 0 {
+		deadline := now.AddDate(0, 0, company.StorageDays)
+		packageModel.StorageDeadline = &deadline
+	}
+	if err := tx.Model(&model.Package{}).Where("id = ?", packageModel.ID).Updates(map[string]interface{}{
+		"pickup_pin":           packageModel.PickupPIN,
+		"arrived_at_office_at": packageModel.ArrivedAtOfficeAt,
+		"storage_deadline":     packageModel.StorageDeadline,
+	}).Error; err != nil {
+		return err
+	}
+	return recordAudit(ctx, tx, config.AuditActionUpdate, config.AuditEntityPackage, packageModel.ID, &before, packageModel)
+}
+
+func readyAtOffice(packageModel *model.Package) bool {
+	return packageModel.IsDeliveredToOffice && packageModel.DeliveryDate == nil && packageModel.PickupPIN != "" &&
+		(packageModel.DeliveryStatus == config.StatusArrivedAtOffice || packageModel.DeliveryStatus == config.StatusHeldAtOffice)
+}
+
+// GetPickupInfo returns what the receiver needs to pick a package up.
+func (r *PackageRepository) GetPickupInfo(ctx context.Context, info *model.PickupInfo, packageModel *model.Package) error {
+	if !readyAtOffice(packageModel) {
+		return ErrorNotReadyForPickup
+	}
+	company := model.Company{}
+	if err := r.db.WithContext(ctx).Where("id = ?", packageModel.CompanyID).First(&company).Error; err != nil {
+		return err
+	}
+
+	*info = model.PickupInfo{
+		PackageID:       packageModel.ID,
+		PIN:             packageModel.PickupPIN,
+		OfficeID:        stringValue(packageModel.OfficeDeliveredAtID),
+		StorageDeadline: packageModel.StorageDeadline,
+		StorageFee:      storageFee(&company, *packageModel.ArrivedAtOfficeAt, time.Now()),
+	}
+	return nil
+}
+
+// PickUp hands a package over to its receiver at the office. The receiver
+// proves who they are with the pickup PIN, or the staff checks their
+// identity document and confirms the name on it. officeID is the office of
+// the employee, empty for admins.
+func (r *PackageRepository) PickUp(ctx context.Context, packageModel *model.Package, id, officeID string, request model.PickupRequest) error {
+	tx := r.db.WithContext(ctx).Begin()
+
+	if err := tx.Preload("Receiver").Where("id = ?", id).First(packageModel).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if IsPackageClosed(packageModel) {
+		tx.Rollback()
+		return ErrorPackageDelivered
+	}
+	if !readyAtOffice(packageModel) || (officeID != "" && officeID != stringValue(packageModel.OfficeDeliveredAtID)) {
+		tx.Rollback()
+		return ErrorNotReadyForPickup
+	}
+
+	receiverName := packageModel.ReceiverName
+	if packageModel.Receiver != nil {
+		receiverName = packageModel.Receiver.Name
+	}
+	verified := false
+	switch {
+	case request.PIN != "":
+		verified = subtle.ConstantTimeCompare([]byte(request.PIN), []byte(packageModel.PickupPIN)) == 1
+	case request.IdentityChecked && receiverName != "":
+		verified = strings.EqualFold(strings.TrimSpace(request.RecipientName), receiverName)
+	}
+	if !verified {
+		tx.Rollback()
+		return ErrorInvalidPickup
+	}
+
+	company := model.Company{}
+	if err := tx.Where("id = ?", packageModel.CompanyID).First(&company).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	packageModel.StorageFee = storageFee(&company, *packageModel.ArrivedAtOfficeAt, time.Now())
+	packageModel.Receiver = nil
+	if err := setPackageStatus(ctx, tx, packageModel, config.StatusDelivired); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := closeRunStop(tx, packageModel.ID, config.StopOutcomeDelivered); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := recordPackageEvent(ctx, tx, &model.PackageEvent{
+		PackageID: packageModel.ID,
+		Type:      config.EventPickedUp,
+		Status:    packageModel.DeliveryStatus,
+		OfficeID:  packageModel.OfficeDeliveredAtID,
+		Note:      strings.TrimSpace(request.RecipientName),
+	}); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// ProcessStorage accrues the storage fees of the packages waiting at offices
+// and returns the ones past their storage deadline to the sender. Every
+// package is processed on its own so one failure does not hold up the rest.
+func (r *PackageRepository) ProcessStorage(ctx context.Context) error {
+	packages := []model.Package{}
+	if err := r.db.WithContext(ctx).Preload("Company").
+		Where("is_delivered_to_office = ? AND delivery_date IS NULL AND arrived_at_office_at IS NOT NULL", true).
+		Where("delivery_status IN ?", []string{config.StatusArrivedAtOffice, config.StatusHeldAtOffice}).
+		Find(&packages).Error; err != nil {
+		return err
+	}
+
+	var errs []error
+	now := time.Now()
+	for i := range packages {
+		if err := r.processStorage(ctx, &packages[i], now); err != nil {
+			errs = append(errs, fmt.Errorf("package %s: %w", packages[i].ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r *PackageRepository) processStorage(ctx context.Context, packageModel *model.Package, now time.Time) error {
+	company := packageModel.Company
+	if company == nil {
+		return nil
+	}
+	packageModel.Company = nil
+	tx := r.db.WithContext(ctx).Begin()
+
+	if fee := storageFee(company, *packageModel.ArrivedAtOfficeAt, now); fee != packageModel.StorageFee {
+		before := *packageModel
+		packageModel.StorageFee = fee
+		if err := tx.Model(&model.Package{}).Where("id = ?", packageModel.ID).Update("storage_fee", fee).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := recordAudit(ctx, tx, config.AuditActionUpdate, config.AuditEntityPackage, packageModel.ID, &before, packageModel); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if packageModel.StorageDeadline != nil && now.After(*packageModel.StorageDeadline) {
+		returnPackage := model.Package{}
+		err := createReturn(ctx, tx, packageModel, &returnPackage, config.ReturnReasonStorageExpired)
+		if err != nil && !errors.Is(err, ErrorInvalidReturn) {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"logistic_company/model"
+	"testing"
+	"time"
+)
+
+func TestStorageFee(t *testing.T) {
+	company := &model.Company{StorageFreeDays: 3, StorageFeePerDay: 1.5}
+	arrivedAt := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		stored time.Duration
+		want   float64
+	}{
+		{"same day", 2 * time.Hour, 0},
+		{"within free days", 3 * 24 * time.Hour, 0},
+		{"partial day past free days", 3*24*time.Hour + 23*time.Hour, 0},
+		{"one day past free days", 4 * 24 * time.Hour, 1.5},
+		{"ten days past free days", 13*24*time.Hour + time.Hour, 15},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := storageFee(company, arrivedAt, arrivedAt.Add(test.stored)); got != test.want {
+				t.Errorf("storageFee() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"logistic_company/config"
+	"logistic_company/model"
+	"sort"
+
+	"gorm.io/gorm"
+)
+
+// PreviewReassignment computes how the undelivered packages of an employee
+// would be distributed without changing anything.
+func (e *EmployeeRepository) PreviewReassignment(ctx context.Context, plan *model.ReassignmentPlan, id string) error {
+	employee := model.Employee{}
+	if err := e.db.WithContext(ctx).Scopes(notArchived).Where("id = ?", id).First(&employee).Error; err != nil {
+		return err
+	}
+
+	return planReassignment(e.db.WithContext(ctx), plan, &employee)
+}
+
+// ReassignPackages distributes the undelivered packages of an employee over
+// their colleagues. The plan is deterministic, so it matches the preview as
+// long as the workloads did not change in the meantime.
+func (e *EmployeeRepository) ReassignPackages(ctx context.Context, plan *model.ReassignmentPlan, id string) error {
+	tx := e.db.WithContext(ctx).Begin()
+
+	employee := model.Employee{}
+	if err := tx.Scopes(notArchived).Where("id = ?", id).First(&employee).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := e.reassignPackages(ctx, tx, plan, &employee); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (e *EmployeeRepository) reassignPackages(ctx context.Context, tx *gorm.DB, plan *model.ReassignmentPlan, employee *model.Employee) error {
+	if err := planReassignment(tx, plan, employee); err != nil {
+		return err
+	}
+
+	for _, assignment := range plan.Assignments {
+		if err := tx.Model(&model.Package{}).Where("id = ?", assignment.PackageID).
+			Update(plan.Column, assignment.ToEmployeeID).Error; err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, tx, config.AuditActionReassign, config.AuditEntityPackage, assignment.PackageID,
+			map[string]string{plan.Column: assignment.FromEmployeeID},
+			map[string]string{plan.Column: assignment.ToEmployeeID}); err != nil {
+			return err
+		}
+	}
+
+	return inboxForReassignment(tx, plan)
+}
+
+// planReassignment assigns every undelivered package of employee to a
+// colleague with the same role in the same company. Colleagues working at
+// the office the package is handled at are preferred, and among those the
+// one with the fewest open packages wins.
+func planReassignment(db *gorm.DB, plan *model.ReassignmentPlan, employee *model.Employee) error {
+	plan.EmployeeID = employee.ID
+	plan.Column = reassignmentColumn(employee.Role)
+	plan.Assignments = []model.PackageReassignment{}
+
+	packages := []model.Package{}
+	if err := db.Model(&model.Package{}).Where(plan.Column+" = ?", employee.ID).
+		Scopes(openPackages).Order("id").Find(&packages).Error; err != nil {
+		return err
+	}
+
+	candidates := []model.Employee{}
+	if err := db.Model(&model.Employee{}).Scopes(notArchived).
+		Where("role = ?", employee.Role).
+		Where("id <> ?", employee.ID).
+		Where("company_id = ?", employee.CompanyID).
+		Order("id").Find(&candidates).Error; err != nil {
+		return err
+	}
+
+	workloads, err := openWorkloads(db, plan.Column, candidates)
+	if err != nil {
+		return err
+	}
+	plan.Workloads = workloads
+
+	if len(packages) == 0 {
+		return nil
+	}
+	if len(candidates) == 0 {
+		return ErrorNoCourriersAvailable
+	}
+
+	for _, packageModel := range packages {
+		officeID := packageOfficeID(&packageModel, employee)
+		candidate, sameOffice := leastLoaded(candidates, workloads, officeID)
+		workloads[candidate.ID]++
+
+		reason := fmt.Sprintf("least loaded %s in the company", employee.Role)
+		if sameOffice {
+			reason = fmt.Sprintf("least loaded %s in office %s", employee.Role, *officeID)
+		}
+		plan.Assignments = append(plan.Assignments, model.PackageReassignment{
+			PackageID:      packageModel.ID,
+			FromEmployeeID: employee.ID,
+			ToEmployeeID:   candidate.ID,
+			OfficeID:       candidate.OfficeID,
+			Reason:         reason,
+		})
+	}
+
+	return nil
+}
+
+func reassignmentColumn(role string) string {
+	if role == config.RoleCourrier {
+		return "courrier_id"
+	}
+	return "registered_by"
+}
+
+// openWorkloads counts the undelivered packages of every employee in
+// employees, keyed by employee ID.
+func openWorkloads(db *gorm.DB, column string, employees []model.Employee) (map[string]int64, error) {
+	workloads := map[string]int64{}
+	if len(employees) == 0 {
+		return workloads, nil
+	}
+
+	ids := make([]string, 0, len(employees))
+	for _, employee := range employees {
+		ids = append(ids, employee.ID)
+		workloads[employee.ID] = 0
+	}
+
+	rows := []struct {
+		EmployeeID string
+		Workload   int64
+	}{}
+	if err := db.Model(&model.Package{}).
+		Select(column+" AS employee_id, COUNT(*) AS workload").
+		Where(column+" IN ?", ids).
+		Scopes(openPackages).
+		Group(column).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		workloads[row.EmployeeID] = row.Workload
+	}
+
+	return workloads, nil
+}
+
+// packageOfficeID returns the office a package is handled at: the office it
+// is delivered to, the office it was accepted at, or the office of the
+// employee it currently belongs to.
+func packageOfficeID(packageModel *model.Package, employee *model.Employee) *string {
+	if packageModel.IsDeliveredToOffice && packageModel.OfficeDeliveredAtID != nil {
+		return packageModel.OfficeDeliveredAtID
+	}
+	if packageModel.OfficeAcceptedAtID != nil {
+		return packageModel.OfficeAcceptedAtID
+	}
+	return employee.OfficeID
+}
+
+// leastLoaded picks the candidate with the lowest workload, preferring the
+// ones working at officeID. Ties are broken by ID to keep plans stable.
+func leastLoaded(candidates []model.Employee, workloads map[string]int64, officeID *string) (model.Employee, bool) {
+	sorted := make([]model.Employee, len(candidates))
+	copy(sorted, candidates)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		iSameOffice := inOffice(&sorted[i], officeID)
+		jSameOffice := inOffice(&sorted[j], officeID)
+		if iSameOffice != jSameOffice {
+			return iSameOffice
+		}
+		if workloads[sorted[i].ID] != workloads[sorted[j].ID] {
+			return workloads[sorted[i].ID] < workloads[sorted[j].ID]
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	return sorted[0], inOffice(&sorted[0], officeID)
+}
+
+func inOffice(employee *model.Employee, officeID *string) bool {
+	return officeID != nil && employee.OfficeID != nil && *employee.OfficeID == *officeID
+}
@@ -9,6 +9,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"gorm.io/gorm/logger"
 )
 
@@ -20,6 +21,18 @@ type Repository struct {
 	PackageRepository  *PackageRepository
 	ClientRepository   *ClientRepository
 	LoginRepository    *LoginRepository
+	AuditRepository    *AuditRepository
+
+	DeliveryRunRepository  *DeliveryRunRepository
+	CODRepository          *CODRepository
+	ClaimRepository        *ClaimRepository
+	ShipmentRepository     *ShipmentRepository
+	ScanRepository         *ScanRepository
+	NetworkRepository      *NetworkRepository
+	LockerRepository       *LockerRepository
+	NotificationRepository *NotificationRepository
+	InboxRepository        *InboxRepository
+	CollectionRepository   *CollectionRepository
 }
 
 func NewRepository(cfg config.Config) (*Repository, error) {
@@ -39,6 +52,18 @@ func NewRepository(cfg config.Config) (*Repository, error) {
 		PackageRepository:  NewPackageRepository(db),
 		ClientRepository:   NewClientRepository(db),
 		LoginRepository:    NewLoginRepository(db),
+		AuditRepository:    NewAuditRepository(db),
+
+		DeliveryRunRepository:  NewDeliveryRunRepository(db),
+		CODRepository:          NewCODRepository(db),
+		ClaimRepository:        NewClaimRepository(db),
+		ShipmentRepository:     NewShipmentRepository(db),
+		ScanRepository:         NewScanRepository(db),
+		NetworkRepository:      NewNetworkRepository(db),
+		LockerRepository:       NewLockerRepository(db),
+		NotificationRepository: NewNotificationRepository(db),
+		InboxRepository:        NewInboxRepository(db),
+		CollectionRepository:   NewCollectionRepository(db),
 	}, nil
 }
 
@@ -49,5 +74,50 @@ func (r *Repository) Migrate() error {
 		&model.EmployeeRegister{},
 		&model.Office{},
 		&model.Package{},
+		&model.AuditLog{},
+		&model.CourrierZone{},
+		&model.AssignmentCursor{},
+		&model.DeliveryRun{},
+		&model.DeliveryRunStop{},
+		&model.ProofOfDelivery{},
+		&model.DeliveryAttempt{},
+		&model.CODCollection{},
+		&model.CashHandover{},
+		&model.PayoutBatch{},
+		&model.InsuranceClaim{},
+		&model.ClaimEvidence{},
+		&model.PackageType{},
+		&model.Shipment{},
+		&model.PackageEvent{},
+		&model.Linehaul{},
+		&model.PackageHop{},
+		&model.TransferBatch{},
+		&model.OfficeHoliday{},
+		&model.Locker{},
+		&model.LockerCompartment{},
+		&model.Address{},
+		&model.Notification{},
+		&model.NotificationPreference{},
+		&model.InboxNotification{},
+		&model.Collection{},
+		&model.ClientVerification{},
 	)
 }
+
+// stringValue returns the value of a nullable column, empty for NULL.
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+// notArchived limits a query to rows that have not been archived.
+func notArchived(db *gorm.DB) *gorm.DB {
+	return db.Where("archived_at IS NULL")
+}
+
+// forUpdate locks the rows a query reads until the transaction ends.
+func forUpdate(db *gorm.DB) *gorm.DB {
+	return db.Clauses(clause.Locking{Strength: "UPDATE"})
+}
@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"logistic_company/config"
+	"logistic_company/model"
+	"testing"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newTestRepository migrates the schema into a fresh in-memory database
+// that enforces foreign keys like MySQL does.
+func newTestRepository(t *testing.T) *Repository {
+	t.Helper()
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared&_foreign_keys=on", uuid.New().String())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// One connection keeps the in-memory database alive and transactions
+	// from locking each other out
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	r := &Repository{
+		db:                 db,
+		EmployeeRepository: NewEmployeeRepository(db),
+		CompanyRepository:  NewCompanyRepository(db),
+		OfficeRepository:   NewOfficeRepository(db),
+		PackageRepository:  NewPackageRepository(db),
+		ClientRepository:   NewClientRepository(db),
+		AuditRepository:    NewAuditRepository(db),
+
+		DeliveryRunRepository:  NewDeliveryRunRepository(db),
+		CODRepository:          NewCODRepository(db),
+		ShipmentRepository:     NewShipmentRepository(db),
+		LockerRepository:       NewLockerRepository(db),
+		NotificationRepository: NewNotificationRepository(db),
+		CollectionRepository:   NewCollectionRepository(db),
+	}
+	if err := r.Migrate(); err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func createTestCompany(t *testing.T, r *Repository, company model.Company) model.Company {
+	t.Helper()
+	if company.Name == "" {
+		company.Name = "company-" + uuid.New().String()
+	}
+	if err := r.db.Create(&company).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := r.db.Create(defaultPackageTypes(company.ID)).Error; err != nil {
+		t.Fatal(err)
+	}
+	return company
+}
+
+func createTestOffice(t *testing.T, r *Repository, companyID string) model.Office {
+	t.Helper()
+	office := model.Office{Location: "office-" + uuid.New().String(), CompanyID: companyID}
+	if err := r.db.Create(&office).Error; err != nil {
+		t.Fatal(err)
+	}
+	return office
+}
+
+func createTestEmployee(t *testing.T, r *Repository, role, companyID string, officeID *string) model.Employee {
+	t.Helper()
+	name := uuid.New().String()
+	employee := model.EmployeeRegister{
+		Employee: model.Employee{Name: name, Email: name + "@example.com", Phone: name, Role: role,
+			CompanyID: &companyID, OfficeID: officeID},
+		Password: "password",
+	}
+	if err := r.db.Create(&employee).Error; err != nil {
+		t.Fatal(err)
+	}
+	return employee.Employee
+}
+
+func createTestClient(t *testing.T, r *Repository, email, phone string) model.Client {
+	t.Helper()
+	name := uuid.New().String()
+	if email == "" {
+		email = name + "@example.com"
+	}
+	if phone == "" {
+		phone = name
+	}
+	client := model.ClientRegister{
+		Client:   model.Client{Name: name, Email: email, Phone: phone},
+		Password: "password",
+	}
+	if err := r.db.Create(&client).Error; err != nil {
+		t.Fatal(err)
+	}
+	return client.Client
+}
+
+// createTestPackage registers an office-to-office package handled by a
+// courier of the office it is accepted at.
+func createTestPackage(t *testing.T, r *Repository, packageModel model.Package) model.Package {
+	t.Helper()
+	if err := r.PackageRepository.CreatePackage(context.Background(), &packageModel); err != nil {
+		t.Fatal(err)
+	}
+	return packageModel
+}
+
+func getTestPackage(t *testing.T, r *Repository, id string) model.Package {
+	t.Helper()
+	packageModel := model.Package{}
+	if err := r.db.Where("id = ?", id).First(&packageModel).Error; err != nil {
+		t.Fatal(err)
+	}
+	return packageModel
+}
+
+func TestMigrate(t *testing.T) {
+	r := newTestRepository(t)
+	company := createTestCompany(t, r, model.Company{})
+	createTestEmployee(t, r, config.RoleCourrier, company.ID, nil)
+}
@@ -0,0 +1,215 @@
+package repository
+
+import (
+	"context"
+	"logistic_company/config"
+	"logistic_company/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var returnReasons = map[string]bool{
+	config.ReturnReasonRefused:        true,
+	config.ReturnReasonUndeliverable:  true,
+	config.ReturnReasonSenderRecalled: true,
+	config.ReturnReasonOther:          true,
+	config.ReturnReasonStorageExpired: true,
+}
+
+func IsReturnPricing(pricing string) bool {
+	switch pricing {
+	case config.ReturnPricingFree, config.ReturnPricingOriginal, config.ReturnPricingPercentage, config.ReturnPricingFlat:
+		return true
+	}
+	return false
+}
+
+func returnPrice(company *model.Company, original *model.Package) float64 {
+	switch company.ReturnPricing {
+	case config.ReturnPricingFree:
+		return 0
+	case config.ReturnPricingPercentage:
+		return original.Price * company.ReturnPriceValue / 100
+	case config.ReturnPricingFlat:
+		return company.ReturnPriceValue
+	}
+	return original.Price
+}
+
+// CreateReturn sends a package back to its sender with a new linked return
+// shipment. Delivered packages and shipment requests cannot be returned.
+func (r *PackageRepository) CreateReturn(ctx context.Context, returnPackage *model.Package, id, reason string) error {
+	tx := r.db.WithContext(ctx).Begin()
+
+	original := model.Package{}
+	if err := tx.Scopes(forUpdate).Where("id = ?", id).First(&original).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := createReturn(ctx, tx, &original, returnPackage, reason); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// createReturn creates the return shipment of original. The return goes from
+// the receiver back to the office the sender handed the package in at, is
+// priced by the company's return pricing rule and gets a courier like any new
+// package.
+func createReturn(ctx context.Context, tx *gorm.DB, original, returnPackage *model.Package, reason string) error {
+	if !returnReasons[reason] {
+		return ErrorInvalidReturn
+	}
+	if original.ReturnOfID != nil || original.ReturnPackageID != nil {
+		return ErrorInvalidReturn
+	}
+	// Every return reason is about a package that never reached its receiver
+	if IsPackageClosed(original) || original.DeliveryDate != nil {
+		return ErrorPackageDelivered
+	}
+	// Shipment requests are cancelled, there is nothing to send back yet
+	if original.OfficeAcceptedAtID == nil {
+		return ErrorNotHandedIn
+	}
+
+	company := model.Company{}
+	if err := tx.Where("id = ?", original.CompanyID).First(&company).Error; err != nil {
+		return err
+	}
+	office := model.Office{}
+	if err := tx.Where("id = ?", *original.OfficeAcceptedAtID).First(&office).Error; err != nil {
+		return err
+	}
+
+	// The return starts where the original is now, which is the office it is
+	// held at for office deliveries
+	acceptedAtID := original.OfficeAcceptedAtID
+	if original.IsDeliveredToOffice && original.OfficeDeliveredAtID != nil {
+		acceptedAtID = original.OfficeDeliveredAtID
+	}
+	location := office.Location
+	// Packages to receivers without an account are returned on behalf of
+	// their sender
+	senderID, receiverID := original.SenderID, original.SenderID
+	if original.ReceiverID != nil {
+		senderID = *original.ReceiverID
+	}
+	trackingNumber, err := uniqueTrackingNumber(tx)
+	if err != nil {
+		return err
+	}
+
+	*returnPackage = model.Package{
+		TrackingNumber:      trackingNumber,
+		SenderID:            senderID,
+		ReceiverID:          &receiverID,
+		Weight:              original.Weight,
+		Length:              original.Length,
+		Width:               original.Width,
+		Height:              original.Height,
+		VolumetricWeight:    original.VolumetricWeight,
+		ChargeableWeight:    original.ChargeableWeight,
+		PackageTypeID:       original.PackageTypeID,
+		Price:               returnPrice(&company, original),
+		IsDeliveredToOffice: true,
+		DeliveryStatus:      config.StatusRegistered,
+		OfficeAcceptedAtID:  acceptedAtID,
+		DeliveryLocation:    &location,
+		DeliveryLatitude:    office.Latitude,
+		DeliveryLongitude:   office.Longitude,
+		OfficeDeliveredAtID: &office.ID,
+		CompanyID:           original.CompanyID,
+		ReturnOfID:          &original.ID,
+		ReturnReason:        reason,
+	}
+	if actor := AuditActorFromContext(ctx); actor.ID != "" && actor.Role != config.RoleClient {
+		returnPackage.RegisteredByID = &actor.ID
+	}
+	if err := assignCourrier(tx, returnPackage); err != nil {
+		return err
+	}
+	if err := tx.Omit(clause.Associations).Create(returnPackage).Error; err != nil {
+		return err
+	}
+	if err := recordCreatedEvent(ctx, tx, returnPackage); err != nil {
+		return err
+	}
+	if err := planNewPackageHops(ctx, tx, returnPackage); err != nil {
+		return err
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionReturn, config.AuditEntityPackage, returnPackage.ID, nil, returnPackage); err != nil {
+		return err
+	}
+
+	before := *original
+	if err := tx.Model(&model.Package{}).Where("id = ?", original.ID).
+		Update("return_package_id", returnPackage.ID).Error; err != nil {
+		return err
+	}
+	original.ReturnPackageID = &returnPackage.ID
+	if err := recordAudit(ctx, tx, config.AuditActionUpdate, config.AuditEntityPackage, original.ID, &before, original); err != nil {
+		return err
+	}
+
+	return setPackageStatus(ctx, tx, original, config.StatusReturnToSender)
+}
+
+// markReturned closes the original package once its return shipment was
+// delivered back to the sender.
+func markReturned(ctx context.Context, tx *gorm.DB, returnPackage *model.Package) error {
+	original := model.Package{}
+	if err := tx.Where("id = ?", *returnPackage.ReturnOfID).First(&original).Error; err != nil {
+		return err
+	}
+	return setPackageStatus(ctx, tx, &original, config.StatusReturned)
+}
+
+// GetReturnReport counts the packages of a company registered between from
+// and to that were returned, broken down by return reason.
+func (c *CompanyRepository) GetReturnReport(ctx context.Context, report *model.ReturnReport, companyID, from, to string) error {
+	db := c.db.WithContext(ctx)
+	if err := db.Where("id = ?", companyID).First(&model.Company{}).Error; err != nil {
+		return err
+	}
+
+	period := func(db *gorm.DB) *gorm.DB {
+		db = db.Where("company_id = ?", companyID)
+		if from != "" {
+			db = db.Where("created_at >= ?", from)
+		}
+		if to != "" {
+			db = db.Where("created_at < DATE_ADD(?, INTERVAL 1 DAY)", to)
+		}
+		return db
+	}
+
+	*report = model.ReturnReport{CompanyID: companyID, From: from, To: to, ByReason: []model.ReturnReasonCount{}}
+	if err := db.Model(&model.Package{}).Scopes(period).Where("return_of_id IS NULL").
+		Count(&report.TotalPackages).Error; err != nil {
+		return err
+	}
+	if err := db.Model(&model.Package{}).Scopes(period).Where("return_of_id IS NULL").
+		Where("return_package_id IS NOT NULL").Count(&report.ReturnedPackages).Error; err != nil {
+		return err
+	}
+
+	// Returns are attributed to the period their original was registered in
+	originals := db.Model(&model.Package{}).Select("id").Scopes(period).Where("return_of_id IS NULL")
+	if err := db.Model(&model.Package{}).Select("return_reason AS reason, COUNT(*) AS count").
+		Where("return_of_id IN (?)", originals).Group("return_reason").Order("count DESC").
+		Scan(&report.ByReason).Error; err != nil {
+		return err
+	}
+	if err := db.Model(&model.Package{}).Select("COALESCE(SUM(price), 0)").
+		Where("return_of_id IN (?)", originals).Scan(&report.ReturnRevenue).Error; err != nil {
+		return err
+	}
+
+	if report.TotalPackages > 0 {
+		report.ReturnRate = float64(report.ReturnedPackages) / float64(report.TotalPackages)
+	}
+	return nil
+}
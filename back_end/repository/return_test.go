@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"logistic_company/config"
+	"logistic_company/model"
+	"testing"
+)
+
+func TestCreateReturnOnlyForUndeliveredPackages(t *testing.T) {
+	r := newTestRepository(t)
+	ctx := context.Background()
+	company := createTestCompany(t, r, model.Company{})
+	office := createTestOffice(t, r, company.ID)
+	courrier := createTestEmployee(t, r, config.RoleCourrier, company.ID, &office.ID)
+	sender := createTestClient(t, r, "", "")
+	receiver := createTestClient(t, r, "", "")
+	location := "1 Vitosha Blvd, Sofia"
+	newPackage := func() model.Package {
+		return createTestPackage(t, r, model.Package{
+			SenderID: sender.ID, ReceiverID: &receiver.ID, CompanyID: company.ID, Weight: 1, RegisteredByID: &courrier.ID,
+			CourrierID: &courrier.ID, OfficeAcceptedAtID: &office.ID, DeliveryLocation: &location,
+		})
+	}
+
+	open := newPackage()
+	delivered := newPackage()
+	proof := model.ProofOfDelivery{
+		PackageID: delivered.ID, CourrierID: courrier.ID, RecipientName: "receiver", SignatureKey: "signature",
+	}
+	if err := r.PackageRepository.CreateProofOfDelivery(ctx, &proof); err != nil {
+		t.Fatal(err)
+	}
+	requested := model.Package{
+		SenderID: sender.ID, ReceiverID: &receiver.ID, CompanyID: company.ID, EstimatedWeight: 1, DeliveryLocation: &location,
+	}
+	if err := r.PackageRepository.RequestPackage(ctx, &requested); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		id      string
+		wantErr error
+	}{
+		{"open package", open.ID, nil},
+		{"delivered package", delivered.ID, ErrorPackageDelivered},
+		{"shipment request", requested.ID, ErrorNotHandedIn},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			returnPackage := model.Package{}
+			err := r.PackageRepository.CreateReturn(ctx, &returnPackage, tt.id, config.ReturnReasonRefused)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateReturn() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && stringValue(returnPackage.ReturnOfID) != tt.id {
+				t.Errorf("return is linked to %q, want %q", stringValue(returnPackage.ReturnOfID), tt.id)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"logistic_company/config"
+	"logistic_company/model"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var scanTypes = map[string]bool{
+	config.ScanReceivedAtOffice: true,
+	config.ScanLoadedToVehicle:  true,
+	config.ScanHandedToCourrier: true,
+}
+
+func IsScanType(scanType string) bool {
+	return scanTypes[scanType]
+}
+
+type ScanRepository struct {
+	db *gorm.DB
+}
+
+func NewScanRepository(db *gorm.DB) *ScanRepository {
+	return &ScanRepository{
+		db: db,
+	}
+}
+
+// recordPackageEvent appends an event to the history of a package. Events
+// caused by staff are attributed to the employee from the context.
+func recordPackageEvent(ctx context.Context, tx *gorm.DB, event *model.PackageEvent) error {
+	if actor := AuditActorFromContext(ctx); event.EmployeeID == nil && actor.ID != "" && actor.Role != config.RoleClient {
+		event.EmployeeID = &actor.ID
+	}
+	event.CreatedAt = time.Now()
+	return tx.Create(event).Error
+}
+
+func recordCreatedEvent(ctx context.Context, tx *gorm.DB, packageModel *model.Package) error {
+	event := model.PackageEvent{
+		PackageID: packageModel.ID,
+		Type:      config.EventCreated,
+		Status:    packageModel.DeliveryStatus,
+	}
+	// Packages collected from the sender are not at an office yet
+	if packageModel.OfficeAcceptedAtID != nil {
+		officeID := *packageModel.OfficeAcceptedAtID
+		event.OfficeID = &officeID
+	}
+	if err := recordPackageEvent(ctx, tx, &event); err != nil {
+		return err
+	}
+	if err := queueNotifications(tx, packageModel, &event); err != nil {
+		return err
+	}
+	return inboxForEvent(tx, packageModel, &event)
+}
+
+func recordStatusEvent(ctx context.Context, tx *gorm.DB, packageModel *model.Package) error {
+	event := model.PackageEvent{
+		PackageID: packageModel.ID,
+		Type:      config.EventStatusChanged,
+		Status:    packageModel.DeliveryStatus,
+	}
+	if err := recordPackageEvent(ctx, tx, &event); err != nil {
+		return err
+	}
+	if err := queueNotifications(tx, packageModel, &event); err != nil {
+		return err
+	}
+	return inboxForEvent(tx, packageModel, &event)
+}
+
+// expectedOffices lists the offices a package is expected to be scanned at,
+// the first one is where it is headed next. Besides the offices it was
+// accepted and is delivered at these are the offices along its planned path.
+func expectedOffices(tx *gorm.DB, packageModel *model.Package) ([]string, error) {
+	offices, err := hopOffices(tx, packageModel)
+	if err != nil {
+		return nil, err
+	}
+	if packageModel.IsDeliveredToOffice && packageModel.OfficeDeliveredAtID != nil {
+		offices = append(offices, *packageModel.OfficeDeliveredAtID)
+	}
+	if packageModel.OfficeAcceptedAtID != nil {
+		offices = append(offices, *packageModel.OfficeAcceptedAtID)
+	}
+	return offices, nil
+}
+
+func (s *ScanRepository) GetPackageEvents(ctx context.Context, events *[]model.PackageEvent, packageID string) error {
+	return s.db.WithContext(ctx).Where("package_id = ?", packageID).Order("created_at").Find(events).Error
+}
+
+func (s *ScanRepository) GetMisroutedScans(ctx context.Context, events *[]model.PackageEvent, companyID string, limit, offset int) error {
+	return s.db.WithContext(ctx).
+		Joins("JOIN package ON package.id = package_event.package_id").
+		Where("package_event.misrouted = ? AND package.company_id = ?", true, companyID).
+		Order("package_event.created_at DESC").Offset(offset).Limit(limit).Find(events).Error
+}
+
+// RecordScan records a scan of the package with the tracking number or ID
+// code at an office of the employee's company.
+func (s *ScanRepository) RecordScan(ctx context.Context, event *model.PackageEvent, employee *model.Employee, request model.ScanRequest) error {
+	tx := s.db.WithContext(ctx).Begin()
+
+	*event = model.PackageEvent{Type: request.Type, Note: request.Note}
+	if err := recordScan(ctx, tx, event, employee, request.TrackingCode, request.CourrierID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// RecordBatchScan records the same scan for every code of a cage or bag.
+// Codes that cannot be scanned are reported in their result and do not stop
+// the rest of the batch.
+func (s *ScanRepository) RecordBatchScan(ctx context.Context, results *[]model.ScanResult, employee *model.Employee, request model.BatchScanRequest) error {
+	tx := s.db.WithContext(ctx).Begin()
+
+	batchID := uuid.New().String()
+	for _, code := range request.TrackingCodes {
+		result := model.ScanResult{TrackingCode: code}
+		event := model.PackageEvent{
+			Type:      request.Type,
+			BatchID:   &batchID,
+			Container: request.Container,
+			Note:      request.Note,
+		}
+
+		// A savepoint keeps a failed scan from aborting the whole batch
+		tx.SavePoint("scan")
+		err := recordScan(ctx, tx, &event, employee, code, request.CourrierID)
+		switch {
+		case err == nil:
+			result.Event = &event
+		case errors.Is(err, gorm.ErrRecordNotFound), errors.Is(err, ErrorInvalidScan), errors.Is(err, ErrorPackageDelivered):
+			tx.RollbackTo("scan")
+			result.Error = err.Error()
+		default:
+			tx.Rollback()
+			return err
+		}
+		*results = append(*results, result)
+	}
+
+	return tx.Commit().Error
+}
+
+// recordScan checks the scanned package against the offices it is expected
+// at and records the scan, flagging it as a misroute when the package should
+// not be at the employee's office.
+// awaitingPickupOrReturn are the statuses a scan at the pickup office does
+// not move to arrived at office.
+var awaitingPickupOrReturn = map[string]bool{
+	config.StatusHeldAtOffice:   true,
+	config.StatusReturnToSender: true,
+	config.StatusReturned:       true,
+}
+
+func recordScan(ctx context.Context, tx *gorm.DB, event *model.PackageEvent, employee *model.Employee, code, courrierID string) error {
+	if !scanTypes[event.Type] || employee.OfficeID == nil || employee.CompanyID == nil {
+		return ErrorInvalidScan
+	}
+
+	packageModel := model.Package{}
+	if err := tx.Where("(tracking_number = ? OR id = ?) AND company_id = ?", code, code, *employee.CompanyID).
+		First(&packageModel).Error; err != nil {
+		return err
+	}
+	if IsPackageClosed(&packageModel) {
+		return ErrorPackageDelivered
+	}
+	if event.Type == config.ScanHandedToCourrier {
+		if courrierID != "" && courrierID != stringValue(packageModel.CourrierID) {
+			return ErrorInvalidScan
+		}
+		event.CourrierID = packageModel.CourrierID
+	}
+
+	expected, err := expectedOffices(tx, &packageModel)
+	if err != nil {
+		return err
+	}
+	event.Misrouted = true
+	for _, officeID := range expected {
+		event.Misrouted = event.Misrouted && officeID != *employee.OfficeID
+	}
+	if event.Misrouted {
+		event.ExpectedOfficeID = &expected[0]
+	}
+
+	event.PackageID = packageModel.ID
+	event.Status = packageModel.DeliveryStatus
+	event.OfficeID = employee.OfficeID
+	event.EmployeeID = &employee.ID
+	if err := recordPackageEvent(ctx, tx, event); err != nil {
+		return err
+	}
+
+	if err := recordAudit(ctx, tx, config.AuditActionCreate, config.AuditEntityScan, event.ID, nil, event); err != nil {
+		return err
+	}
+
+	// Office deliveries received at their pickup office are ready for pickup
+	if event.Type == config.ScanReceivedAtOffice && packageModel.IsDeliveredToOffice &&
+		*employee.OfficeID == stringValue(packageModel.OfficeDeliveredAtID) && !awaitingPickupOrReturn[packageModel.DeliveryStatus] {
+		return setPackageStatus(ctx, tx, &packageModel, config.StatusArrivedAtOffice)
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package repository
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"logistic_company/config"
+	"logistic_company/model"
+	"math/big"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ShipmentRepository struct {
+	db *gorm.DB
+}
+
+func NewShipmentRepository(db *gorm.DB) *ShipmentRepository {
+	return &ShipmentRepository{
+		db: db,
+	}
+}
+
+// newTrackingNumber returns the prefix followed by ten random digits.
+func newTrackingNumber() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1e10))
+	if err != nil {
+		return "", err
+	}
+	digits := n.String()
+	for len(digits) < 10 {
+		digits = "0" + digits
+	}
+	return config.TrackingNumberPrefix + digits, nil
+}
+
+// uniqueTrackingNumber returns a tracking number that no package, shipment
+// or parcel of a shipment uses yet.
+func uniqueTrackingNumber(tx *gorm.DB) (string, error) {
+	for attempt := 0; attempt < config.TrackingNumberAttempts; attempt++ {
+		trackingNumber, err := newTrackingNumber()
+		if err != nil {
+			return "", err
+		}
+		var packages, shipments int64
+		if err := tx.Model(&model.Package{}).
+			Where("tracking_number = ? OR tracking_number LIKE ?", trackingNumber, trackingNumber+"-%").
+			Count(&packages).Error; err != nil {
+			return "", err
+		}
+		if err := tx.Model(&model.Shipment{}).Where("tracking_number = ?", trackingNumber).
+			Count(&shipments).Error; err != nil {
+			return "", err
+		}
+		if packages == 0 && shipments == 0 {
+			return trackingNumber, nil
+		}
+	}
+	return "", ErrorTrackingNumbersTaken
+}
+
+// shipmentStatus derives the status of a shipment from its parcels. A
+// shipment is in a status once all its parcels are, until then it is
+// partially delivered, out for delivery or in transit.
+func shipmentStatus(parcels []model.Package) string {
+	if len(parcels) == 0 {
+		return config.StatusRegistered
+	}
+
+	counts := map[string]int{}
+	for _, parcel := range parcels {
+		counts[parcel.DeliveryStatus]++
+	}
+	switch {
+	case len(counts) == 1:
+		return parcels[0].DeliveryStatus
+	case counts[config.StatusDelivired] > 0:
+		return config.ShipmentStatusPartiallyDelivered
+	case counts[config.StatusOutForDelivery] > 0:
+		return config.StatusOutForDelivery
+	}
+	return config.ShipmentStatusInTransit
+}
+
+func withShipmentStatus(shipments []model.Shipment) {
+	for i := range shipments {
+		shipments[i].Status = shipmentStatus(shipments[i].Parcels)
+	}
+}
+
+func (s *ShipmentRepository) preloaded(ctx context.Context) *gorm.DB {
+	return s.db.WithContext(ctx).Preload("Sender").Preload("Receiver").
+		Preload("Parcels", func(db *gorm.DB) *gorm.DB {
+			return db.Order("parcel_number")
+		})
+}
+
+func (s *ShipmentRepository) GetShipmentById(ctx context.Context, shipment *model.Shipment, id string) error {
+	if err := s.preloaded(ctx).Where("id = ?", id).First(shipment).Error; err != nil {
+		return err
+	}
+	shipment.Status = shipmentStatus(shipment.Parcels)
+	return nil
+}
+
+func (s *ShipmentRepository) GetShipmentByTrackingNumber(ctx context.Context, shipment *model.Shipment, trackingNumber string) error {
+	if err := s.preloaded(ctx).Where("tracking_number = ?", trackingNumber).First(shipment).Error; err != nil {
+		return err
+	}
+	shipment.Status = shipmentStatus(shipment.Parcels)
+	return nil
+}
+
+func (s *ShipmentRepository) GetShipmentsBySenderID(ctx context.Context, shipments *[]model.Shipment, id string, limit, offset int) error {
+	if err := s.preloaded(ctx).Where("sender_id = ?", id).Order("created_at DESC").Offset(offset).Limit(limit).Find(shipments).Error; err != nil {
+		return err
+	}
+	withShipmentStatus(*shipments)
+	return nil
+}
+
+func (s *ShipmentRepository) GetShipmentsByReceiverID(ctx context.Context, shipments *[]model.Shipment, id string, limit, offset int) error {
+	if err := s.preloaded(ctx).Where("receiver_id = ?", id).Order("created_at DESC").Offset(offset).Limit(limit).Find(shipments).Error; err != nil {
+		return err
+	}
+	withShipmentStatus(*shipments)
+	return nil
+}
+
+// CreateShipment stores a shipment together with its parcels. Every parcel
+// is priced on its own and goes through the same checks as a single package,
+// the first parcel's courrier is reused for the rest so the shipment is
+// delivered in one go. template holds the delivery details shared by all
+// parcels.
+func (s *ShipmentRepository) CreateShipment(ctx context.Context, shipment *model.Shipment, template model.Package, parcels []model.Package) error {
+	if len(parcels) == 0 || template.ReceiverID == nil {
+		return ErrorInvalidShipment
+	}
+
+	tx := s.db.WithContext(ctx).Begin()
+
+	trackingNumber, err := uniqueTrackingNumber(tx)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	*shipment = model.Shipment{
+		TrackingNumber: trackingNumber,
+		SenderID:       template.SenderID,
+		ReceiverID:     *template.ReceiverID,
+		CompanyID:      template.CompanyID,
+		ParcelCount:    len(parcels),
+	}
+	if err := tx.Omit(clause.Associations).Create(shipment).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	courrierID := template.CourrierID
+	for i, parcel := range parcels {
+		packageModel := template
+		packageModel.CourrierID = courrierID
+		packageModel.Weight = parcel.Weight
+		packageModel.Length = parcel.Length
+		packageModel.Width = parcel.Width
+		packageModel.Height = parcel.Height
+		packageModel.PackageTypeID = parcel.PackageTypeID
+		packageModel.DeclaredValue = parcel.DeclaredValue
+		packageModel.Insured = parcel.Insured
+		packageModel.ShipmentID = &shipment.ID
+		packageModel.ParcelNumber = i + 1
+		packageModel.TrackingNumber = trackingNumber + "-" + fmt.Sprint(i+1)
+
+		if err := createPackage(ctx, tx, &packageModel); err != nil {
+			tx.Rollback()
+			return err
+		}
+		courrierID = packageModel.CourrierID
+
+		shipment.TotalWeight += packageModel.Weight
+		shipment.TotalChargeableWeight += packageModel.ChargeableWeight
+		shipment.TotalPrice += packageModel.Price + packageModel.CODFee + packageModel.InsurancePremium
+		shipment.Parcels = append(shipment.Parcels, packageModel)
+	}
+	shipment.TotalPrice = roundCents(shipment.TotalPrice)
+
+	if err := tx.Model(&model.Shipment{}).Where("id = ?", shipment.ID).Updates(map[string]interface{}{
+		"total_weight":            shipment.TotalWeight,
+		"total_chargeable_weight": shipment.TotalChargeableWeight,
+		"total_price":             shipment.TotalPrice,
+	}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	shipment.Status = shipmentStatus(shipment.Parcels)
+	// The parcels are audited on their own
+	audited := *shipment
+	audited.Parcels = nil
+	if err := recordAudit(ctx, tx, config.AuditActionCreate, config.AuditEntityShipment, shipment.ID, nil, &audited); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
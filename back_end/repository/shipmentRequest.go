@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"logistic_company/config"
+	"logistic_company/model"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RequestPackage stores a shipment a client requested online. It is priced
+// from the client's weight estimate and waits for an employee to accept it.
+func (r *PackageRepository) RequestPackage(ctx context.Context, packageModel *model.Package) error {
+	tx := r.db.WithContext(ctx).Begin()
+
+	if packageModel.PickupAddressID != nil {
+		if err := tx.Scopes(notArchived).Where("id = ? AND client_id = ?", *packageModel.PickupAddressID, packageModel.SenderID).
+			First(&model.Address{}).Error; err != nil {
+			tx.Rollback()
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrorInvalidAddress
+			}
+			return err
+		}
+	}
+	packageModel.Weight = packageModel.EstimatedWeight
+	packageModel.DeliveryStatus = config.StatusRequested
+	if err := createPackage(ctx, tx, packageModel); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// GetRequestedPackages returns the shipment requests of a company that were
+// not accepted yet, oldest first.
+func (r *PackageRepository) GetRequestedPackages(ctx context.Context, packages *[]model.Package, companyID string, limit, offset int) error {
+	return r.db.WithContext(ctx).Preload("Sender").Preload("Receiver").Preload("PickupAddress").
+		Where("company_id = ? AND delivery_status = ?", companyID, config.StatusRequested).
+		Order("created_at").Offset(offset).Limit(limit).Find(packages).Error
+}
+
+// AcceptPackage accepts a requested shipment that was handed in at, or
+// collected to, an office. The package is priced again by its measured
+// weight and size and the sender is told when the price differs from the
+// quote.
+func (r *PackageRepository) AcceptPackage(ctx context.Context, packageModel *model.Package, id, employeeID string, confirmation model.ShipmentConfirmation) error {
+	tx := r.db.WithContext(ctx).Begin()
+
+	if err := tx.Where("id = ?", id).First(packageModel).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := acceptPackage(ctx, tx, packageModel, employeeID, confirmation); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func acceptPackage(ctx context.Context, tx *gorm.DB, packageModel *model.Package, employeeID string, confirmation model.ShipmentConfirmation) error {
+	if packageModel.DeliveryStatus != config.StatusRequested {
+		return ErrorNotRequested
+	}
+
+	before := *packageModel
+	packageModel.Weight = confirmation.Weight
+	packageModel.Length = confirmation.Length
+	packageModel.Width = confirmation.Width
+	packageModel.Height = confirmation.Height
+	packageModel.OfficeAcceptedAtID = &confirmation.OfficeAcceptedAtID
+	packageModel.RegisteredByID = &employeeID
+	if err := lockerDestination(tx, packageModel); err != nil {
+		return err
+	}
+	if packageModel.CourrierID == nil {
+		if err := assignCourrier(tx, packageModel); err != nil {
+			return err
+		}
+	} else if err := checkCourrier(tx, packageModel); err != nil {
+		return err
+	}
+	if err := pricePackage(tx, packageModel); err != nil {
+		return err
+	}
+	if err := priceInsurance(tx, packageModel); err != nil {
+		return err
+	}
+	if err := tx.Model(packageModel).Omit(clause.Associations).Where("id = ?", packageModel.ID).Updates(packageModel).Error; err != nil {
+		return err
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionUpdate, config.AuditEntityPackage, packageModel.ID, &before, packageModel); err != nil {
+		return err
+	}
+	if err := reserveCompartment(ctx, tx, packageModel); err != nil {
+		return err
+	}
+	if err := planNewPackageHops(ctx, tx, packageModel); err != nil {
+		return err
+	}
+	if err := setPackageStatus(ctx, tx, packageModel, config.StatusRegistered); err != nil {
+		return err
+	}
+	if err := addToInbox(tx, *packageModel.CourrierID, config.InboxPackageEvent,
+		fmt.Sprintf("Package %s assigned to you", packageModel.TrackingNumber),
+		fmt.Sprintf("Package %s was accepted and assigned to you for delivery.", packageModel.TrackingNumber),
+		&packageModel.ID); err != nil {
+		return err
+	}
+
+	if packageModel.Price != packageModel.QuotedPrice {
+		if err := queueNotification(tx, packageModel.SenderID, packageModel.ID, config.TemplatePriceAdjusted, time.Now()); err != nil {
+			return err
+		}
+		if err := addToInbox(tx, packageModel.SenderID, config.InboxPackageEvent,
+			fmt.Sprintf("Package %s price adjusted", packageModel.TrackingNumber),
+			fmt.Sprintf("Package %s weighs %.2f kg instead of the estimated %.2f kg, its price is %.2f instead of the quoted %.2f.",
+				packageModel.TrackingNumber, packageModel.Weight, packageModel.EstimatedWeight, packageModel.Price, packageModel.QuotedPrice),
+			&packageModel.ID); err != nil {
+			return err
+		}
+	}
+
+	// Requests dropped off at the office they are picked up from stay there
+	if packageModel.IsDeliveredToOffice && stringValue(packageModel.OfficeDeliveredAtID) == stringValue(packageModel.OfficeAcceptedAtID) {
+		return setPackageStatus(ctx, tx, packageModel, config.StatusArrivedAtOffice)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"logistic_company/config"
+	"logistic_company/model"
+	"testing"
+	"time"
+)
+
+func TestRequestAndCollectPackage(t *testing.T) {
+	r := newTestRepository(t)
+	ctx := context.Background()
+	company := createTestCompany(t, r, model.Company{})
+	office := createTestOffice(t, r, company.ID)
+	courrier := createTestEmployee(t, r, config.RoleCourrier, company.ID, &office.ID)
+	sender := createTestClient(t, r, "", "")
+	receiver := createTestClient(t, r, "", "")
+	address := model.Address{ClientID: sender.ID, Street: "Vitosha", City: "Sofia", PostalCode: "1000", Country: "BG"}
+	if err := r.db.Create(&address).Error; err != nil {
+		t.Fatal(err)
+	}
+	location := "receiver street 1"
+
+	// Requests have no courrier, registering employee or offices yet
+	requested := model.Package{
+		SenderID: sender.ID, ReceiverID: &receiver.ID, CompanyID: company.ID, EstimatedWeight: 2,
+		PickupAddressID: &address.ID, DeliveryLocation: &location,
+	}
+	if err := r.PackageRepository.RequestPackage(ctx, &requested); err != nil {
+		t.Fatal(err)
+	}
+	stored := getTestPackage(t, r, requested.ID)
+	if stored.DeliveryStatus != config.StatusRequested || stored.CourrierID != nil || stored.RegisteredByID != nil ||
+		stored.OfficeAcceptedAtID != nil || stored.OfficeDeliveredAtID != nil {
+		t.Fatalf("RequestPackage() stored %+v, want a request without courrier, employee and offices", stored)
+	}
+
+	start := time.Now().Add(time.Hour).Truncate(time.Hour)
+	if start.Format(config.DateFormat) != start.Add(time.Hour).Format(config.DateFormat) {
+		start = start.Add(-2 * time.Hour)
+	}
+	collection := model.Collection{
+		CompanyID: company.ID, SenderID: sender.ID, AddressID: address.ID, WindowStart: start, WindowEnd: start.Add(time.Hour),
+	}
+	if err := r.CollectionRepository.CreateCollection(ctx, &collection, []string{requested.ID}); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.CollectionRepository.AssignCollection(ctx, &collection, collection.ID, courrier.ID); err != nil {
+		t.Fatal(err)
+	}
+	completion := model.CollectionCompletion{Parcels: map[string]model.ShipmentConfirmation{requested.ID: {Weight: 3}}}
+	if err := r.CollectionRepository.CompleteCollection(ctx, &collection, collection.ID, completion); err != nil {
+		t.Fatal(err)
+	}
+
+	accepted := getTestPackage(t, r, requested.ID)
+	if accepted.DeliveryStatus != config.StatusRegistered || accepted.Weight != 3 {
+		t.Errorf("CompleteCollection() left status %q and weight %v, want %q and 3", accepted.DeliveryStatus, accepted.Weight, config.StatusRegistered)
+	}
+	if stringValue(accepted.RegisteredByID) != courrier.ID || stringValue(accepted.OfficeAcceptedAtID) != office.ID ||
+		stringValue(accepted.CourrierID) != courrier.ID {
+		t.Errorf("CompleteCollection() accepted %+v, want it registered by and assigned to the courrier at their office", accepted)
+	}
+}
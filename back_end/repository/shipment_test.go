@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"logistic_company/config"
+	"logistic_company/model"
+	"testing"
+)
+
+func TestShipmentStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []string
+		want     string
+	}{
+		{"no parcels", nil, config.StatusRegistered},
+		{"all registered", []string{config.StatusRegistered, config.StatusRegistered}, config.StatusRegistered},
+		{"all delivered", []string{config.StatusDelivired, config.StatusDelivired}, config.StatusDelivired},
+		{"some delivered", []string{config.StatusDelivired, config.StatusOutForDelivery}, config.ShipmentStatusPartiallyDelivered},
+		{"some out for delivery", []string{config.StatusInTransit, config.StatusOutForDelivery}, config.StatusOutForDelivery},
+		{"mixed in transit", []string{config.StatusRegistered, config.StatusArrivedAtOffice}, config.ShipmentStatusInTransit},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			parcels := []model.Package{}
+			for _, status := range test.statuses {
+				parcels = append(parcels, model.Package{DeliveryStatus: status})
+			}
+			if got := shipmentStatus(parcels); got != test.want {
+				t.Errorf("shipmentStatus() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestCreateShipment(t *testing.T) {
+	r := newTestRepository(t)
+	company := createTestCompany(t, r, model.Company{CODFeePercentage: 2, InsuranceRatePercentage: 1, InsuranceMinPremium: 3})
+	office := createTestOffice(t, r, company.ID)
+	courrier := createTestEmployee(t, r, config.RoleCourrier, company.ID, &office.ID)
+	sender := createTestClient(t, r, "", "")
+	receiver := createTestClient(t, r, "", "")
+	location := "receiver street 1"
+
+	// Address deliveries have no pickup office
+	shipment := model.Shipment{}
+	template := model.Package{
+		SenderID: sender.ID, ReceiverID: &receiver.ID, CompanyID: company.ID, CourrierID: &courrier.ID,
+		RegisteredByID: &courrier.ID, OfficeAcceptedAtID: &office.ID, DeliveryLocation: &location, CODAmount: 50,
+	}
+	parcels := []model.Package{{Weight: 1}, {Weight: 2, DeclaredValue: 500, Insured: true}}
+	if err := r.ShipmentRepository.CreateShipment(context.Background(), &shipment, template, parcels); err != nil {
+		t.Fatal(err)
+	}
+
+	price := 0.0
+	trackingNumbers := map[string]bool{shipment.TrackingNumber: true}
+	for _, parcel := range shipment.Parcels {
+		stored := getTestPackage(t, r, parcel.ID)
+		if stored.OfficeDeliveredAtID != nil {
+			t.Errorf("parcel %d is delivered at office %q, want none", parcel.ParcelNumber, *stored.OfficeDeliveredAtID)
+		}
+		price += stored.Price
+		trackingNumbers[stored.TrackingNumber] = true
+	}
+	// Both parcels pay a COD fee of 1, the insured one a premium of 5
+	if want := roundCents(price + 2*1 + 5); shipment.TotalPrice != want {
+		t.Errorf("TotalPrice = %v, want %v", shipment.TotalPrice, want)
+	}
+	if len(trackingNumbers) != 3 {
+		t.Errorf("got %d distinct tracking numbers, want 3", len(trackingNumbers))
+	}
+}
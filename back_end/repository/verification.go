@@ -0,0 +1,236 @@
+package repository
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"logistic_company/config"
+	"logistic_company/model"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// normalizePhone keeps the digits of a phone number and a leading plus so
+// numbers written with spaces, dashes or brackets compare equal.
+func normalizePhone(phone string) string {
+	phone = strings.TrimSpace(phone)
+	var normalized strings.Builder
+	for i, r := range phone {
+		if (r >= '0' && r <= '9') || (r == '+' && i == 0) {
+			normalized.WriteRune(r)
+		}
+	}
+	return normalized.String()
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// verificationTarget is the email or phone of a client a channel verifies.
+func verificationTarget(client *model.Client, channel string) string {
+	switch channel {
+	case config.ChannelEmail:
+		return normalizeEmail(client.Email)
+	case config.ChannelSMS:
+		return normalizePhone(client.Phone)
+	}
+	return ""
+}
+
+// packageReceiver checks who a package is sent to. Registered receivers lend
+// their contact details to the package. Receivers without an account need a
+// name and a phone or email, when a client verified that phone or email the
+// package goes to them.
+func packageReceiver(tx *gorm.DB, packageModel *model.Package) error {
+	if packageModel.ReceiverID != nil {
+		receiver := model.Client{}
+		if err := tx.Where("id = ?", *packageModel.ReceiverID).First(&receiver).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrorInvalidReceiver
+			}
+			return err
+		}
+		if packageModel.ReceiverName == "" {
+			packageModel.ReceiverName = receiver.Name
+		}
+		if packageModel.ReceiverPhone == "" {
+			packageModel.ReceiverPhone = normalizePhone(receiver.Phone)
+		}
+		if packageModel.ReceiverEmail == "" {
+			packageModel.ReceiverEmail = normalizeEmail(receiver.Email)
+		}
+		return nil
+	}
+
+	packageModel.ReceiverName = strings.TrimSpace(packageModel.ReceiverName)
+	packageModel.ReceiverPhone = normalizePhone(packageModel.ReceiverPhone)
+	packageModel.ReceiverEmail = normalizeEmail(packageModel.ReceiverEmail)
+	if packageModel.ReceiverName == "" || (packageModel.ReceiverPhone == "" && packageModel.ReceiverEmail == "") {
+		return ErrorInvalidReceiver
+	}
+
+	// Clients can change or drop the email and phone they verified, so the
+	// verification only counts while it still is their contact
+	verifications := []model.ClientVerification{}
+	if err := tx.Joins("JOIN client ON client.id = client_verification.client_id").
+		Where("verified_at IS NOT NULL").
+		Where("(channel = ? AND target = ?) OR (channel = ? AND target = ?)",
+			config.ChannelEmail, packageModel.ReceiverEmail, config.ChannelSMS, packageModel.ReceiverPhone).
+		Order("verified_at DESC").Find(&verifications).Error; err != nil {
+		return err
+	}
+	for _, verification := range verifications {
+		client := model.Client{}
+		if err := tx.Where("id = ?", verification.ClientID).First(&client).Error; err != nil {
+			return err
+		}
+		if verificationTarget(&client, verification.Channel) == verification.Target {
+			packageModel.ReceiverID = &verification.ClientID
+			return nil
+		}
+	}
+	return nil
+}
+
+// forgetVerifications drops the verifications of a client for the given
+// channels, with no channels every verification of the client is dropped.
+func forgetVerifications(tx *gorm.DB, clientID string, channels ...string) error {
+	query := tx.Where("client_id = ?", clientID)
+	if len(channels) > 0 {
+		query = query.Where("channel IN ?", channels)
+	}
+	return query.Delete(&model.ClientVerification{}).Error
+}
+
+// RequestVerification creates a code to verify the email or the phone of a
+// client. Codes requested before for the same channel stop working. A client
+// and a target get only MaxVerificationRequests codes per
+// VerificationWindowMinutes.
+func (c *ClientRepository) RequestVerification(ctx context.Context, verification *model.ClientVerification, clientID, channel string) error {
+	tx := c.db.WithContext(ctx).Begin()
+
+	client := model.Client{}
+	if err := tx.Where("id = ?", clientID).First(&client).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	target := verificationTarget(&client, channel)
+	if target == "" {
+		tx.Rollback()
+		return ErrorInvalidVerification
+	}
+	now := time.Now()
+	var requested int64
+	if err := tx.Model(&model.ClientVerification{}).
+		Where("(client_id = ? AND channel = ?) OR target = ?", clientID, channel, target).
+		Where("created_at > ?", now.Add(-config.VerificationWindowMinutes*time.Minute)).
+		Count(&requested).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if requested >= config.MaxVerificationRequests {
+		tx.Rollback()
+		return ErrorTooManyVerifications
+	}
+	// Older codes are expired rather than deleted so they keep counting
+	if err := tx.Model(&model.ClientVerification{}).
+		Where("client_id = ? AND channel = ? AND verified_at IS NULL AND expires_at > ?", clientID, channel, now).
+		Update("expires_at", now).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	code, err := newCode(config.VerificationCodeLength)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	*verification = model.ClientVerification{
+		ClientID:  clientID,
+		Channel:   channel,
+		Target:    target,
+		Code:      code,
+		ExpiresAt: now.Add(config.VerificationCodeMinutes * time.Minute),
+		CreatedAt: now,
+	}
+	if err := tx.Create(verification).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// ConfirmVerification checks a verification code and links the packages
+// sent to the verified email or phone before the client had an account.
+// Every wrong code counts, codes stop working after too many attempts.
+func (c *ClientRepository) ConfirmVerification(ctx context.Context, claimed *[]model.Package, clientID, channel, code string) error {
+	tx := c.db.WithContext(ctx).Begin()
+
+	verification := model.ClientVerification{}
+	err := tx.Where("client_id = ? AND channel = ? AND verified_at IS NULL", clientID, channel).
+		Order("created_at DESC").First(&verification).Error
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrorInvalidVerification
+		}
+		return err
+	}
+	if verification.Attempts >= config.MaxVerificationAttempts || time.Now().After(verification.ExpiresAt) {
+		tx.Rollback()
+		return ErrorInvalidVerification
+	}
+	if subtle.ConstantTimeCompare([]byte(verification.Code), []byte(code)) != 1 {
+		if err := tx.Model(&model.ClientVerification{}).Where("id = ?", verification.ID).
+			Update("attempts", gorm.Expr("attempts + 1")).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := tx.Commit().Error; err != nil {
+			return err
+		}
+		return ErrorInvalidVerification
+	}
+
+	now := time.Now()
+	verification.VerifiedAt = &now
+	if err := tx.Model(&model.ClientVerification{}).Where("id = ?", verification.ID).
+		Update("verified_at", verification.VerifiedAt).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := recordAudit(ctx, tx, config.AuditActionUpdate, config.AuditEntityVerification, verification.ID, nil, &verification); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	column := "receiver_email"
+	if channel == config.ChannelSMS {
+		column = "receiver_phone"
+	}
+	*claimed = []model.Package{}
+	if err := tx.Where("receiver_id IS NULL AND "+column+" = ?", verification.Target).
+		Order("created_at").Find(claimed).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	for i := range *claimed {
+		packageModel := &(*claimed)[i]
+		before := *packageModel
+		packageModel.ReceiverID = &clientID
+		if err := tx.Model(&model.Package{}).Where("id = ?", packageModel.ID).Update("receiver_id", clientID).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := recordAudit(ctx, tx, config.AuditActionClaim, config.AuditEntityPackage, packageModel.ID, &before, packageModel); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"logistic_company/config"
+	"logistic_company/model"
+	"testing"
+)
+
+// verifyTestClient verifies the email or phone of a client.
+func verifyTestClient(t *testing.T, r *Repository, clientID, channel string) {
+	t.Helper()
+	ctx := context.Background()
+	verification := model.ClientVerification{}
+	if err := r.ClientRepository.RequestVerification(ctx, &verification, clientID, channel); err != nil {
+		t.Fatal(err)
+	}
+	claimed := []model.Package{}
+	if err := r.ClientRepository.ConfirmVerification(ctx, &claimed, clientID, channel, verification.Code); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPackageReceiverFollowsCurrentContact(t *testing.T) {
+	r := newTestRepository(t)
+	ctx := context.Background()
+	company := createTestCompany(t, r, model.Company{})
+	office := createTestOffice(t, r, company.ID)
+	courrier := createTestEmployee(t, r, config.RoleCourrier, company.ID, &office.ID)
+	sender := createTestClient(t, r, "", "")
+	moved := createTestClient(t, r, "old@example.com", "")
+	deleted := createTestClient(t, r, "", "+359 888 123 456")
+	verified := createTestClient(t, r, "kept@example.com", "")
+
+	verifyTestClient(t, r, moved.ID, config.ChannelEmail)
+	verifyTestClient(t, r, deleted.ID, config.ChannelSMS)
+	verifyTestClient(t, r, verified.ID, config.ChannelEmail)
+
+	update := model.ClientRegister{Client: model.Client{ID: moved.ID, Email: "new@example.com"}}
+	if err := r.ClientRepository.UpdateClient(ctx, &update); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.ClientRepository.DeleteClient(ctx, deleted.ID); err != nil {
+		t.Fatal(err)
+	}
+	// Someone else takes over the email the first client gave up
+	taken := createTestClient(t, r, "old@example.com", "")
+
+	tests := []struct {
+		name  string
+		email string
+		phone string
+		want  string
+	}{
+		{"changed email", "old@example.com", "", ""},
+		{"deleted client", "", "+359888123456", ""},
+		{"verified email", " Kept@example.com", "", verified.ID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			packageModel := createTestPackage(t, r, model.Package{
+				SenderID: sender.ID, ReceiverName: "receiver", ReceiverEmail: tt.email, ReceiverPhone: tt.phone,
+				CompanyID: company.ID, Weight: 1, RegisteredByID: &courrier.ID, CourrierID: &courrier.ID,
+				IsDeliveredToOffice: true, OfficeAcceptedAtID: &office.ID, OfficeDeliveredAtID: &office.ID,
+			})
+			if got := stringValue(packageModel.ReceiverID); got != tt.want {
+				t.Errorf("package goes to %q, want %q", got, tt.want)
+			}
+			if got := stringValue(packageModel.ReceiverID); got == taken.ID {
+				t.Errorf("package goes to a client that never verified the email")
+			}
+		})
+	}
+
+	var left int64
+	if err := r.db.Model(&model.ClientVerification{}).Where("client_id IN ?", []string{moved.ID, deleted.ID}).
+		Count(&left).Error; err != nil {
+		t.Fatal(err)
+	}
+	if left != 0 {
+		t.Errorf("%d verifications of changed or deleted contacts are left", left)
+	}
+}
+
+func TestRequestVerificationIsThrottled(t *testing.T) {
+	r := newTestRepository(t)
+	ctx := context.Background()
+	client := createTestClient(t, r, "", "")
+
+	var first model.ClientVerification
+	for i := 0; i < config.MaxVerificationRequests; i++ {
+		verification := model.ClientVerification{}
+		if err := r.ClientRepository.RequestVerification(ctx, &verification, client.ID, config.ChannelEmail); err != nil {
+			t.Fatalf("request %d: %v", i+1, err)
+		}
+		if i == 0 {
+			first = verification
+		}
+	}
+	verification := model.ClientVerification{}
+	err := r.ClientRepository.RequestVerification(ctx, &verification, client.ID, config.ChannelEmail)
+	if !errors.Is(err, ErrorTooManyVerifications) {
+		t.Errorf("RequestVerification() = %v, want %v", err, ErrorTooManyVerifications)
+	}
+
+	// Only the last code works
+	claimed := []model.Package{}
+	err = r.ClientRepository.ConfirmVerification(ctx, &claimed, client.ID, config.ChannelEmail, first.Code)
+	if !errors.Is(err, ErrorInvalidVerification) {
+		t.Errorf("ConfirmVerification() with an old code = %v, want %v", err, ErrorInvalidVerification)
+	}
+}